@@ -3,6 +3,7 @@ package cmd
 import (
 	"log"
 	"os"
+	"strconv"
 
 	"github.com/jejevj/ykp_pos/migrations"
 	"gorm.io/gorm"
@@ -12,8 +13,14 @@ func Commands(db *gorm.DB) {
 	migrate := false
 	seed := false
 	fresh := false
+	migrateUp := false
+	migrateDown := 0
+	migrateStatus := false
+	makeMigration := ""
 
-	for _, arg := range os.Args[1:] {
+	args := os.Args[1:]
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
 		if arg == "--migrate" {
 			migrate = true
 		}
@@ -23,6 +30,40 @@ func Commands(db *gorm.DB) {
 		if arg == "--migrate-fresh" {
 			fresh = true
 		}
+		if arg == "--migrate-up" {
+			migrateUp = true
+		}
+		if arg == "--migrate-status" {
+			migrateStatus = true
+		}
+		if arg == "--migrate-down" {
+			// The number of migrations to roll back is optional and defaults to one
+			migrateDown = 1
+			if i+1 < len(args) {
+				if n, err := strconv.Atoi(args[i+1]); err == nil {
+					if n <= 0 {
+						log.Fatalf("error migration down: step must be positive, got %d", n)
+					}
+					migrateDown = n
+					i++
+				}
+			}
+		}
+		if arg == "--make-migration" {
+			if i+1 >= len(args) {
+				log.Fatal("error make migration: name is required, e.g. --make-migration add_pembayaran")
+			}
+			makeMigration = args[i+1]
+			i++
+		}
+	}
+
+	if makeMigration != "" {
+		file, err := migrations.MakeMigration(makeMigration)
+		if err != nil {
+			log.Fatalf("error make migration: %v", err)
+		}
+		log.Printf("created %s and %s", file.UpPath, file.DownPath)
 	}
 
 	if migrate {
@@ -32,6 +73,42 @@ func Commands(db *gorm.DB) {
 		log.Println("migration completed successfully")
 	}
 
+	if migrateUp {
+		done, err := migrations.MigrateUp(db)
+		for _, file := range done {
+			log.Printf("applied %s_%s", file.Version, file.Name)
+		}
+		if err != nil {
+			log.Fatalf("error migration up: %v", err)
+		}
+		log.Printf("migration up completed successfully, %d applied", len(done))
+	}
+
+	if migrateDown > 0 {
+		done, err := migrations.MigrateDown(db, migrateDown)
+		for _, file := range done {
+			log.Printf("rolled back %s_%s", file.Version, file.Name)
+		}
+		if err != nil {
+			log.Fatalf("error migration down: %v", err)
+		}
+		log.Printf("migration down completed successfully, %d rolled back", len(done))
+	}
+
+	if migrateStatus {
+		statuses, err := migrations.GetMigrationStatus(db)
+		if err != nil {
+			log.Fatalf("error migration status: %v", err)
+		}
+		for _, status := range statuses {
+			applied := "pending"
+			if status.AppliedAt != nil {
+				applied = "applied " + status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			log.Printf("%s_%s\t%s", status.Version, status.Name, applied)
+		}
+	}
+
 	if seed {
 		if err := migrations.Seeder(db); err != nil {
 			log.Fatalf("error migration seeder: %v", err)
@@ -8,6 +8,108 @@ const (
 	ENUM_RUN_PRODUCTION = "production"
 	ENUM_RUN_TESTING    = "testing"
 
-	ENUM_PAGINATION_LIMIT = 10
-	ENUM_PAGINATION_PAGE  = 1
+	ENUM_PAGINATION_LIMIT     = 10
+	ENUM_PAGINATION_PAGE      = 1
+	ENUM_PAGINATION_MAX_LIMIT = 100
+
+	ENUM_DATE_FORMAT = "2006-01-02"
+
+	ENUM_FAKTUR_BELUM_BAYAR = "belum_bayar"
+	ENUM_FAKTUR_SEBAGIAN    = "sebagian"
+	ENUM_FAKTUR_LUNAS       = "lunas"
+	ENUM_FAKTUR_BATAL       = "batal"
+
+	ENUM_CARA_BAYAR_TUNAI  = "tunai"
+	ENUM_CARA_BAYAR_KREDIT = "kredit"
+
+	ENUM_ISI_LUSIN = 12
+
+	ENUM_DOKUMEN_FAKTUR      = "faktur"
+	ENUM_DOKUMEN_LOADING     = "loading"
+	ENUM_DOKUMEN_RETUR       = "retur"
+	ENUM_DOKUMEN_NOTA_KREDIT = "nota_kredit"
+	ENUM_DOKUMEN_PENJUALAN   = "penjualan"
+	ENUM_DOKUMEN_PO          = "po"
+	ENUM_DOKUMEN_PENERIMAAN  = "penerimaan"
+
+	ENUM_RESET_NOMOR_BULANAN = "bulanan"
+	ENUM_RESET_NOMOR_TAHUNAN = "tahunan"
+	ENUM_RESET_NOMOR_TIDAK   = "tidak"
+
+	ENUM_URUTAN_DISKON_PERSEN_NOMINAL = "persen_nominal"
+	ENUM_URUTAN_DISKON_NOMINAL_PERSEN = "nominal_persen"
+
+	ENUM_STOK_RECEIPT    = "receipt"
+	ENUM_STOK_LOADING    = "loading"
+	ENUM_STOK_SALE       = "sale"
+	ENUM_STOK_RETURN     = "return"
+	ENUM_STOK_ADJUSTMENT = "adjustment"
+	ENUM_STOK_OPNAME     = "opname"
+
+	ENUM_LOADING_DRAFT      = "draft"
+	ENUM_LOADING_SUBMITTED  = "submitted"
+	ENUM_LOADING_APPROVED   = "approved"
+	ENUM_LOADING_DISPATCHED = "dispatched"
+	ENUM_LOADING_RETURNED   = "returned"
+	ENUM_LOADING_SETTLED    = "settled"
+
+	ENUM_DOKUMEN_SETORAN = "setoran"
+
+	ENUM_KEKURANGAN_BARANG = "barang"
+	ENUM_KEKURANGAN_UANG   = "uang"
+
+	ENUM_METODE_BAYAR_TUNAI    = "tunai"
+	ENUM_METODE_BAYAR_TRANSFER = "transfer"
+	ENUM_METODE_BAYAR_QRIS     = "qris"
+	ENUM_METODE_BAYAR_GIRO     = "giro"
+	ENUM_METODE_BAYAR_CAMPURAN = "campuran"
+	ENUM_METODE_BAYAR_VA       = "virtual_account"
+	ENUM_METODE_BAYAR_EWALLET  = "ewallet"
+
+	ENUM_GATEWAY_MIDTRANS = "midtrans"
+
+	ENUM_GATEWAY_PENDING  = "pending"
+	ENUM_GATEWAY_BERHASIL = "berhasil"
+	ENUM_GATEWAY_GAGAL    = "gagal"
+
+	ENUM_PO_OPEN    = "open"
+	ENUM_PO_PARTIAL = "partial"
+	ENUM_PO_CLOSED  = "closed"
+
+	ENUM_BUKTI_PENDING  = "pending"
+	ENUM_BUKTI_DITERIMA = "diterima"
+	ENUM_BUKTI_DITOLAK  = "ditolak"
+	ENUM_BUKTI_BATAL    = "batal"
+
+	ENUM_KONDISI_BAIK  = "baik"
+	ENUM_KONDISI_RUSAK = "rusak"
+
+	ENUM_LAPORAN_GROUP_CUSTOMER = "customer"
+	ENUM_LAPORAN_GROUP_SALES    = "sales"
+
+	ENUM_EXPORT_JSON = "json"
+	ENUM_EXPORT_CSV  = "csv"
+	ENUM_EXPORT_XLSX = "xlsx"
+
+	ENUM_KERTAS_A4 = "A4"
+	ENUM_KERTAS_A5 = "A5"
+
+	ENUM_STRUK_ESCPOS = "escpos"
+	ENUM_STRUK_TEKS   = "teks"
+
+	// Thermal printers are 32 columns wide on 58mm paper and 48 on 80mm
+	ENUM_STRUK_KOLOM_58MM = 32
+	ENUM_STRUK_KOLOM_80MM = 48
+
+	ENUM_UMUR_CURRENT = "current"
+	ENUM_UMUR_1_30    = "1-30"
+	ENUM_UMUR_31_60   = "31-60"
+	ENUM_UMUR_61_90   = "61-90"
+	ENUM_UMUR_OVER_90 = ">90"
+
+	ENUM_SHIFT_BUKA  = "buka"
+	ENUM_SHIFT_TUTUP = "tutup"
+
+	ENUM_KAS_MASUK  = "masuk"
+	ENUM_KAS_KELUAR = "keluar"
 )
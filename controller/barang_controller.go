@@ -13,10 +13,13 @@ type (
 	BarangController interface {
 		AddBarang(ctx *fiber.Ctx) error
 		GetBarangById(ctx *fiber.Ctx) error
+		GetBarangByKode(ctx *fiber.Ctx) error
 		GetAllBarangWithPagination(ctx *fiber.Ctx) error
 		UpdateBarang(ctx *fiber.Ctx) error
 		UpdateStokBarang(ctx *fiber.Ctx) error
+		OpnameBarang(ctx *fiber.Ctx) error
 		DeleteBarang(ctx *fiber.Ctx) error
+		GetKartuStok(ctx *fiber.Ctx) error
 	}
 
 	barangController struct {
@@ -38,7 +41,9 @@ func (c *barangController) AddBarang(ctx *fiber.Ctx) error {
 		return ctx.Status(http.StatusBadRequest).JSON(res)
 	}
 
-	result, err := c.barangService.AddBarang(ctx.Context(), barang)
+	userId, _ := ctx.Locals("user_id").(string)
+
+	result, err := c.barangService.AddBarang(ctx.Context(), barang, userId)
 	if err != nil {
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_REGISTER_USER, err.Error(), nil)
 		return ctx.Status(http.StatusBadRequest).JSON(res)
@@ -64,9 +69,36 @@ func (c *barangController) GetBarangById(ctx *fiber.Ctx) error {
 	return ctx.Status(http.StatusOK).JSON(res)
 }
 
+func (c *barangController) GetBarangByKode(ctx *fiber.Ctx) error {
+	var req dto.GetBarangByKodeRequest
+	if err := ctx.QueryParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	if req.KodeBarang == "" {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER, "kode_barang is missing or empty", nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	result, err := c.barangService.GetBarangByKode(ctx.Context(), req.KodeBarang)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_USER, result)
+	return ctx.Status(http.StatusOK).JSON(res)
+}
+
 func (c *barangController) GetAllBarangWithPagination(ctx *fiber.Ctx) error {
+	var req dto.PaginationRequest
+	if err := ctx.QueryParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
 
-	result, err := c.barangService.GetAllBarangWithPagination(ctx.Context())
+	result, err := c.barangService.GetAllBarangWithPagination(ctx.Context(), req)
 	if err != nil {
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_USER, err.Error(), nil)
 		return ctx.Status(http.StatusBadRequest).JSON(res)
@@ -74,8 +106,8 @@ func (c *barangController) GetAllBarangWithPagination(ctx *fiber.Ctx) error {
 
 	// Respond in DataTable format
 	resp := map[string]interface{}{
-		"draw":            1,                               // Draw is the request counter from the frontend (for synchronizing requests)
-		"recordsTotal":    result.PaginationResponse.Count, // Total records in the database (without filter)
+		"draw":            req.Draw,                        // Draw is the request counter from the frontend (for synchronizing requests)
+		"recordsTotal":    result.Total,                    // Total records in the database (without filter)
 		"recordsFiltered": result.PaginationResponse.Count, // Total records after filter
 		"data":            result.Data,                     // The actual data to be displayed
 		"meta":            result.PaginationResponse,       // Pagination metadata
@@ -111,7 +143,6 @@ func (c *barangController) UpdateBarang(ctx *fiber.Ctx) error {
 	existingBarang.HargaBeli = req.HargaBeli
 	existingBarang.HargaJual = req.HargaJual
 	existingBarang.IdSatuan = req.IdSatuan
-	existingBarang.Stok = req.Stok
 
 	// Call the service to update the Barang
 	result, err := c.barangService.UpdateBarang(ctx.Context(), req, req.ID)
@@ -139,8 +170,10 @@ func (c *barangController) UpdateStokBarang(ctx *fiber.Ctx) error {
 		return ctx.Status(http.StatusBadRequest).JSON(res)
 	}
 
+	userId, _ := ctx.Locals("user_id").(string)
+
 	// Call the service to update the Barang
-	result, err := c.barangService.UpdateStokBarang(ctx.Context(), req, req.ID)
+	result, err := c.barangService.UpdateStokBarang(ctx.Context(), req, req.ID, userId)
 	if err != nil {
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_UPDATE_USER, err.Error(), nil)
 		return ctx.Status(http.StatusBadRequest).JSON(res)
@@ -151,6 +184,30 @@ func (c *barangController) UpdateStokBarang(ctx *fiber.Ctx) error {
 	return ctx.Status(http.StatusOK).JSON(res)
 }
 
+func (c *barangController) OpnameBarang(ctx *fiber.Ctx) error {
+	var req dto.BarangOpnameRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	if req.ID == "" {
+		res := utils.BuildResponseFailed("failed update data", "ID is missing or empty", nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	userId, _ := ctx.Locals("user_id").(string)
+
+	result, err := c.barangService.OpnameBarang(ctx.Context(), req, userId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_UPDATE_USER, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UPDATE_USER, result)
+	return ctx.Status(http.StatusOK).JSON(res)
+}
+
 func (c *barangController) DeleteBarang(ctx *fiber.Ctx) error {
 	var req dto.GetBarangByIdRequest
 	if err := ctx.BodyParser(&req); err != nil {
@@ -172,3 +229,25 @@ func (c *barangController) DeleteBarang(ctx *fiber.Ctx) error {
 	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_DELETE_USER, nil)
 	return ctx.Status(http.StatusOK).JSON(res)
 }
+
+func (c *barangController) GetKartuStok(ctx *fiber.Ctx) error {
+	var req dto.KartuStokRequest
+	if err := ctx.QueryParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	if req.IdBarang == "" {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER, "id_barang is missing or empty", nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	result, err := c.barangService.GetKartuStok(ctx.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_USER, result)
+	return ctx.Status(http.StatusOK).JSON(res)
+}
@@ -0,0 +1,32 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jejevj/ykp_pos/constants"
+	"github.com/jejevj/ykp_pos/dto"
+)
+
+// ownerFilter returns the id of the logged in user when their role may only
+// see and change their own records, and an empty string otherwise.
+func ownerFilter(ctx *fiber.Ctx) string {
+	role, _ := ctx.Locals("role").(string)
+	if role != constants.ENUM_ROLE_SALES {
+		return ""
+	}
+
+	userId, _ := ctx.Locals("user_id").(string)
+	return userId
+}
+
+// failedStatus picks the HTTP status for a failed request: access to a record
+// of another user is forbidden, everything else answers the fallback.
+func failedStatus(err error, fallback int) int {
+	if errors.Is(err, dto.ErrLoadingNotOwned) || errors.Is(err, dto.ErrFakturNotOwned) {
+		return http.StatusForbidden
+	}
+
+	return fallback
+}
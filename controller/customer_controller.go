@@ -16,6 +16,9 @@ type (
 		GetAllCustomerWithPagination(ctx *fiber.Ctx) error
 		UpdateCustomer(ctx *fiber.Ctx) error
 		DeleteCustomer(ctx *fiber.Ctx) error
+		UpdateKreditCustomer(ctx *fiber.Ctx) error
+		AddOverrideKredit(ctx *fiber.Ctx) error
+		GetOverrideKredit(ctx *fiber.Ctx) error
 	}
 
 	customerController struct {
@@ -63,9 +66,13 @@ func (c *customerController) GetCustomerById(ctx *fiber.Ctx) error {
 	return ctx.Status(http.StatusOK).JSON(res)
 }
 func (c *customerController) GetAllCustomerWithPagination(ctx *fiber.Ctx) error {
-	// var req dto.PaginationRequest
+	var req dto.PaginationRequest
+	if err := ctx.QueryParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
 
-	result, err := c.customerService.GetAllCustomerWithPagination(ctx.Context())
+	result, err := c.customerService.GetAllCustomerWithPagination(ctx.Context(), req)
 	if err != nil {
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_USER, err.Error(), nil)
 		return ctx.Status(http.StatusBadRequest).JSON(res)
@@ -141,3 +148,67 @@ func (c *customerController) DeleteCustomer(ctx *fiber.Ctx) error {
 	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_DELETE_USER, nil)
 	return ctx.Status(http.StatusOK).JSON(res)
 }
+
+func (c *customerController) UpdateKreditCustomer(ctx *fiber.Ctx) error {
+	var req dto.CustomerKreditRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	if req.ID == "" {
+		res := utils.BuildResponseFailed("failed update data", "ID is missing or empty", nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	result, err := c.customerService.UpdateKreditCustomer(ctx.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_UPDATE_USER, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UPDATE_USER, result)
+	return ctx.Status(http.StatusOK).JSON(res)
+}
+
+func (c *customerController) AddOverrideKredit(ctx *fiber.Ctx) error {
+	var req dto.OverrideKreditCreateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	// The override is recorded under the admin granting it
+	userId, _ := ctx.Locals("user_id").(string)
+
+	result, err := c.customerService.AddOverrideKredit(ctx.Context(), req, userId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_REGISTER_USER, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_REGISTER_USER, result)
+	return ctx.Status(http.StatusOK).JSON(res)
+}
+
+func (c *customerController) GetOverrideKredit(ctx *fiber.Ctx) error {
+	var req dto.GetOverrideKreditRequest
+	if err := ctx.QueryParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	if req.IdCustomer == "" {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_USER, "id_customer is missing or empty", nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	result, err := c.customerService.GetOverrideKredit(ctx.Context(), req.IdCustomer)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_USER, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_LIST_USER, result)
+	return ctx.Status(http.StatusOK).JSON(res)
+}
@@ -0,0 +1,198 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jejevj/ykp_pos/constants"
+	"github.com/jejevj/ykp_pos/dto"
+	"github.com/jejevj/ykp_pos/service"
+	"github.com/jejevj/ykp_pos/utils"
+)
+
+type (
+	FakturController interface {
+		AddFaktur(ctx *fiber.Ctx) error
+		GetFakturById(ctx *fiber.Ctx) error
+		GetAllFakturWithPagination(ctx *fiber.Ctx) error
+		UpdateFaktur(ctx *fiber.Ctx) error
+		CancelFaktur(ctx *fiber.Ctx) error
+		CetakFaktur(ctx *fiber.Ctx) error
+		CetakStrukFaktur(ctx *fiber.Ctx) error
+	}
+
+	fakturController struct {
+		fakturService service.FakturService
+	}
+)
+
+func NewFakturController(us service.FakturService) FakturController {
+	return &fakturController{
+		fakturService: us,
+	}
+}
+
+// sendStruk answers with a receipt: raw ESC/POS bytes for the printer app to
+// pass on, or the plain-text preview.
+func sendStruk(ctx *fiber.Ctx, struk []byte, format string) error {
+	if strings.EqualFold(format, constants.ENUM_STRUK_TEKS) {
+		ctx.Set(fiber.HeaderContentType, fiber.MIMETextPlainCharsetUTF8)
+	} else {
+		ctx.Set(fiber.HeaderContentType, fiber.MIMEOctetStream)
+	}
+
+	return ctx.Status(http.StatusOK).Send(struk)
+}
+
+func (c *fakturController) AddFaktur(ctx *fiber.Ctx) error {
+	var faktur dto.FakturCreateRequest
+
+	if err := ctx.BodyParser(&faktur); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	// The sales user issuing the invoice is always the authenticated user
+	userId, _ := ctx.Locals("user_id").(string)
+
+	result, err := c.fakturService.AddFaktur(ctx.Context(), faktur, userId, ownerFilter(ctx))
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_REGISTER_USER, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_REGISTER_USER, result)
+	return ctx.Status(http.StatusOK).JSON(res)
+}
+
+func (c *fakturController) GetFakturById(ctx *fiber.Ctx) error {
+	var req dto.GetFakturByIdRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(response)
+	}
+
+	result, err := c.fakturService.GetFakturById(ctx.Context(), req.ID, ownerFilter(ctx))
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER, err.Error(), nil)
+		return ctx.Status(failedStatus(err, http.StatusBadRequest)).JSON(res)
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_USER, result)
+	return ctx.Status(http.StatusOK).JSON(res)
+}
+
+func (c *fakturController) GetAllFakturWithPagination(ctx *fiber.Ctx) error {
+	var req dto.FakturFilterRequest
+	if err := ctx.QueryParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	// Sales users only see the fakturs they issued
+	if ownerId := ownerFilter(ctx); ownerId != "" {
+		req.IdUser = ownerId
+	}
+
+	result, err := c.fakturService.GetAllFakturWithPagination(ctx.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_USER, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	resp := utils.Response{
+		Status:  true,
+		Message: dto.MESSAGE_SUCCESS_GET_LIST_USER,
+		Data:    result.Data,
+		Meta:    result.PaginationResponse,
+	}
+
+	return ctx.Status(http.StatusOK).JSON(resp)
+}
+
+func (c *fakturController) UpdateFaktur(ctx *fiber.Ctx) error {
+	// Parse the request body to get the update request
+	var req dto.FakturUpdateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	// Check if ID is provided in the request body
+	if req.ID == "" {
+		res := utils.BuildResponseFailed("failed update data", "ID is missing or empty", nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	userId, _ := ctx.Locals("user_id").(string)
+
+	// Call the service to update the Faktur
+	result, err := c.fakturService.UpdateFaktur(ctx.Context(), req, req.ID, userId, ownerFilter(ctx))
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_UPDATE_USER, err.Error(), nil)
+		return ctx.Status(failedStatus(err, http.StatusBadRequest)).JSON(res)
+	}
+
+	// Return the success response with the updated Faktur
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UPDATE_USER, result)
+	return ctx.Status(http.StatusOK).JSON(res)
+}
+
+func (c *fakturController) CancelFaktur(ctx *fiber.Ctx) error {
+	var req dto.FakturCancelRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	if req.ID == "" {
+		res := utils.BuildResponseFailed("failed update data", "ID is missing or empty", nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	userId, _ := ctx.Locals("user_id").(string)
+
+	// The void is recorded under the admin who made it
+	result, err := c.fakturService.CancelFaktur(ctx.Context(), req, userId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_UPDATE_USER, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UPDATE_USER, result)
+	return ctx.Status(http.StatusOK).JSON(res)
+}
+
+func (c *fakturController) CetakFaktur(ctx *fiber.Ctx) error {
+	fakturId := ctx.Params("id")
+
+	result, err := c.fakturService.CetakFaktur(ctx.Context(), fakturId, ctx.Query("ukuran"), ownerFilter(ctx))
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER, err.Error(), nil)
+		return ctx.Status(failedStatus(err, http.StatusBadRequest)).JSON(res)
+	}
+
+	ctx.Set(fiber.HeaderContentType, "application/pdf")
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf("inline; filename=\"faktur-%s.pdf\"", fakturId))
+	return ctx.Status(http.StatusOK).Send(result)
+}
+
+func (c *fakturController) CetakStrukFaktur(ctx *fiber.Ctx) error {
+	var req dto.StrukRequest
+	if err := ctx.QueryParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	fakturId := ctx.Params("id")
+
+	result, err := c.fakturService.CetakStrukFaktur(ctx.Context(), fakturId, req, ownerFilter(ctx))
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER, err.Error(), nil)
+		return ctx.Status(failedStatus(err, http.StatusBadRequest)).JSON(res)
+	}
+
+	return sendStruk(ctx, result, req.Format)
+}
@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jejevj/ykp_pos/dto"
+	"github.com/jejevj/ykp_pos/service"
+	"github.com/jejevj/ykp_pos/utils"
+)
+
+type (
+	GatewayController interface {
+		TerimaNotifikasi(ctx *fiber.Ctx) error
+		GetTransaksiGatewayByFaktur(ctx *fiber.Ctx) error
+	}
+
+	gatewayController struct {
+		gatewayService service.GatewayService
+	}
+)
+
+func NewGatewayController(us service.GatewayService) GatewayController {
+	return &gatewayController{
+		gatewayService: us,
+	}
+}
+
+func (c *gatewayController) TerimaNotifikasi(ctx *fiber.Ctx) error {
+	// The signature is over the raw body, so it is passed on unparsed
+	result, err := c.gatewayService.TerimaNotifikasi(ctx.Context(), ctx.Body())
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, dto.ErrSignatureGateway) {
+			status = http.StatusUnauthorized
+		}
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_REGISTER_USER, err.Error(), nil)
+		return ctx.Status(status).JSON(res)
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_REGISTER_USER, result)
+	return ctx.Status(http.StatusOK).JSON(res)
+}
+
+func (c *gatewayController) GetTransaksiGatewayByFaktur(ctx *fiber.Ctx) error {
+	var req dto.GetTransaksiGatewayByFakturRequest
+	if err := ctx.QueryParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	if req.IdFaktur == "" {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_USER, "id_faktur is missing or empty", nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	result, err := c.gatewayService.GetTransaksiGatewayByFaktur(ctx.Context(), req.IdFaktur)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_USER, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_LIST_USER, result)
+	return ctx.Status(http.StatusOK).JSON(res)
+}
@@ -0,0 +1,94 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jejevj/ykp_pos/constants"
+	"github.com/jejevj/ykp_pos/dto"
+	"github.com/jejevj/ykp_pos/service"
+	"github.com/jejevj/ykp_pos/utils"
+)
+
+type (
+	LaporanController interface {
+		GetUmurPiutang(ctx *fiber.Ctx) error
+		GetRekapPpn(ctx *fiber.Ctx) error
+	}
+
+	laporanController struct {
+		laporanService service.LaporanService
+	}
+)
+
+func NewLaporanController(us service.LaporanService) LaporanController {
+	return &laporanController{
+		laporanService: us,
+	}
+}
+
+// exportContentTypes maps the export formats to their MIME type.
+var exportContentTypes = map[string]string{
+	constants.ENUM_EXPORT_CSV:  "text/csv",
+	constants.ENUM_EXPORT_XLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+func (c *laporanController) GetUmurPiutang(ctx *fiber.Ctx) error {
+	var req dto.UmurPiutangRequest
+	if err := ctx.QueryParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	if req.Format == "" || req.Format == constants.ENUM_EXPORT_JSON {
+		result, err := c.laporanService.GetUmurPiutang(ctx.Context(), req)
+		if err != nil {
+			res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER, err.Error(), nil)
+			return ctx.Status(http.StatusBadRequest).JSON(res)
+		}
+
+		res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_USER, result)
+		return ctx.Status(http.StatusOK).JSON(res)
+	}
+
+	result, err := c.laporanService.ExportUmurPiutang(ctx.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	ctx.Set(fiber.HeaderContentType, exportContentTypes[req.Format])
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=\"umur-piutang.%s\"", req.Format))
+	return ctx.Status(http.StatusOK).Send(result)
+}
+
+func (c *laporanController) GetRekapPpn(ctx *fiber.Ctx) error {
+	var req dto.RekapPpnRequest
+	if err := ctx.QueryParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	if req.Format == "" || req.Format == constants.ENUM_EXPORT_JSON {
+		result, err := c.laporanService.GetRekapPpn(ctx.Context(), req)
+		if err != nil {
+			res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER, err.Error(), nil)
+			return ctx.Status(http.StatusBadRequest).JSON(res)
+		}
+
+		res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_USER, result)
+		return ctx.Status(http.StatusOK).JSON(res)
+	}
+
+	// Any other format is the e-Faktur import file
+	result, err := c.laporanService.ExportEFaktur(ctx.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	ctx.Set(fiber.HeaderContentType, exportContentTypes[req.Format])
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=\"e-faktur-%s.csv\"", req.Bulan))
+	return ctx.Status(http.StatusOK).Send(result)
+}
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gofiber/fiber/v2"
@@ -15,6 +16,11 @@ type (
 		GetLoadingById(ctx *fiber.Ctx) error
 		GetAllLoadingWithPagination(ctx *fiber.Ctx) error
 		UpdateLoading(ctx *fiber.Ctx) error
+		SubmitLoading(ctx *fiber.Ctx) error
+		ApproveLoading(ctx *fiber.Ctx) error
+		UnapproveLoading(ctx *fiber.Ctx) error
+		DispatchLoading(ctx *fiber.Ctx) error
+		ReturnLoading(ctx *fiber.Ctx) error
 		DeleteLoading(ctx *fiber.Ctx) error
 	}
 
@@ -37,7 +43,7 @@ func (c *loadingController) AddLoading(ctx *fiber.Ctx) error {
 		return ctx.Status(http.StatusBadRequest).JSON(res)
 	}
 
-	result, err := c.loadingService.AddLoading(ctx.Context(), loading)
+	result, err := c.loadingService.AddLoading(ctx.Context(), loading, ownerFilter(ctx))
 	if err != nil {
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_REGISTER_USER, err.Error(), nil)
 		return ctx.Status(http.StatusBadRequest).JSON(res)
@@ -53,18 +59,28 @@ func (c *loadingController) GetLoadingById(ctx *fiber.Ctx) error {
 		return ctx.Status(http.StatusBadRequest).JSON(response)
 	}
 
-	result, err := c.loadingService.GetLoadingById(ctx.Context(), req.ID)
+	result, err := c.loadingService.GetLoadingById(ctx.Context(), req.ID, ownerFilter(ctx))
 	if err != nil {
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER, err.Error(), nil)
-		return ctx.Status(http.StatusBadRequest).JSON(res)
+		return ctx.Status(failedStatus(err, http.StatusBadRequest)).JSON(res)
 	}
 
 	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_USER, result)
 	return ctx.Status(http.StatusOK).JSON(res)
 }
 func (c *loadingController) GetAllLoadingWithPagination(ctx *fiber.Ctx) error {
+	var req dto.LoadingFilterRequest
+	if err := ctx.QueryParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	// Sales users only ever see their own loadings
+	if ownerId := ownerFilter(ctx); ownerId != "" {
+		req.IdUser = ownerId
+	}
 
-	result, err := c.loadingService.GetAllLoadingWithPagination(ctx.Context())
+	result, err := c.loadingService.GetAllLoadingWithPagination(ctx.Context(), req)
 	if err != nil {
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_USER, err.Error(), nil)
 		return ctx.Status(http.StatusBadRequest).JSON(res)
@@ -95,20 +111,16 @@ func (c *loadingController) UpdateLoading(ctx *fiber.Ctx) error {
 	}
 
 	// Get the existing data by ID
-	existingLoading, err := c.loadingService.GetLoadingById(ctx.Context(), req.ID)
-	if err != nil {
+	if _, err := c.loadingService.GetLoadingById(ctx.Context(), req.ID, ownerFilter(ctx)); err != nil {
 		res := utils.BuildResponseFailed("failed update data", "Loading not found: "+err.Error(), nil)
-		return ctx.Status(http.StatusNotFound).JSON(res)
+		return ctx.Status(failedStatus(err, http.StatusNotFound)).JSON(res)
 	}
 
-	// Use the existing entity and update the fields
-	existingLoading.IsApproved = req.IsApproved
-
 	// Call the service to update the Loading
-	result, err := c.loadingService.UpdateLoading(ctx.Context(), req, req.ID)
+	result, err := c.loadingService.UpdateLoading(ctx.Context(), req, req.ID, ownerFilter(ctx))
 	if err != nil {
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_UPDATE_USER, err.Error(), nil)
-		return ctx.Status(http.StatusBadRequest).JSON(res)
+		return ctx.Status(failedStatus(err, http.StatusBadRequest)).JSON(res)
 	}
 
 	// Return the success response with the updated Loading
@@ -116,6 +128,55 @@ func (c *loadingController) UpdateLoading(ctx *fiber.Ctx) error {
 	return ctx.Status(http.StatusOK).JSON(res)
 }
 
+// transitionLoading moves the loading in the body to its next status. An
+// action that is not allowed from the current status answers 409 Conflict.
+func (c *loadingController) transitionLoading(ctx *fiber.Ctx, aksi string) error {
+	var req dto.GetLoadingByIdRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	if req.ID == "" {
+		res := utils.BuildResponseFailed("failed update data", "ID is missing or empty", nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	userId, _ := ctx.Locals("user_id").(string)
+
+	result, err := c.loadingService.TransitionLoading(ctx.Context(), req.ID, aksi, userId, ownerFilter(ctx))
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_UPDATE_USER, err.Error(), nil)
+		if errors.Is(err, dto.ErrLoadingInvalidTransition) {
+			return ctx.Status(http.StatusConflict).JSON(res)
+		}
+		return ctx.Status(failedStatus(err, http.StatusBadRequest)).JSON(res)
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UPDATE_USER, result)
+	return ctx.Status(http.StatusOK).JSON(res)
+}
+
+func (c *loadingController) SubmitLoading(ctx *fiber.Ctx) error {
+	return c.transitionLoading(ctx, service.LoadingSubmit)
+}
+
+func (c *loadingController) ApproveLoading(ctx *fiber.Ctx) error {
+	return c.transitionLoading(ctx, service.LoadingApprove)
+}
+
+func (c *loadingController) UnapproveLoading(ctx *fiber.Ctx) error {
+	return c.transitionLoading(ctx, service.LoadingUnapprove)
+}
+
+func (c *loadingController) DispatchLoading(ctx *fiber.Ctx) error {
+	return c.transitionLoading(ctx, service.LoadingDispatch)
+}
+
+func (c *loadingController) ReturnLoading(ctx *fiber.Ctx) error {
+	return c.transitionLoading(ctx, service.LoadingReturn)
+}
+
 func (c *loadingController) DeleteLoading(ctx *fiber.Ctx) error {
 	var req dto.GetLoadingByIdRequest
 	if err := ctx.BodyParser(&req); err != nil {
@@ -128,10 +189,12 @@ func (c *loadingController) DeleteLoading(ctx *fiber.Ctx) error {
 		return ctx.Status(http.StatusBadRequest).JSON(res)
 	}
 
-	err := c.loadingService.DeleteLoading(ctx.Context(), req.ID)
+	userId, _ := ctx.Locals("user_id").(string)
+
+	err := c.loadingService.DeleteLoading(ctx.Context(), req.ID, userId, ownerFilter(ctx))
 	if err != nil {
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_DELETE_USER, err.Error(), nil)
-		return ctx.Status(http.StatusBadRequest).JSON(res)
+		return ctx.Status(failedStatus(err, http.StatusBadRequest)).JSON(res)
 	}
 
 	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_DELETE_USER, nil)
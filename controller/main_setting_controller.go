@@ -19,6 +19,9 @@ type (
 		GetAllMainSettingWithPagination(ctx *fiber.Ctx) error
 		UpdateMainSetting(ctx *fiber.Ctx) error
 		DeleteMainSetting(ctx *fiber.Ctx) error
+		UpdatePajakMainSetting(ctx *fiber.Ctx) error
+		UpdateQrisMainSetting(ctx *fiber.Ctx) error
+		BuatQris(ctx *fiber.Ctx) error
 	}
 
 	mainSettingController struct {
@@ -80,9 +83,13 @@ func (c *mainSettingController) GetMainSettingById(ctx *fiber.Ctx) error {
 	return ctx.Status(http.StatusOK).JSON(res)
 }
 func (c *mainSettingController) GetAllMainSettingWithPagination(ctx *fiber.Ctx) error {
-	// var req dto.PaginationRequest
+	var req dto.PaginationRequest
+	if err := ctx.QueryParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
 
-	result, err := c.mainSettingService.GetAllMainSettingWithPagination(ctx.Context())
+	result, err := c.mainSettingService.GetAllMainSettingWithPagination(ctx.Context(), req)
 	if err != nil {
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_USER, err.Error(), nil)
 		return ctx.Status(http.StatusBadRequest).JSON(res)
@@ -163,6 +170,8 @@ func (c *mainSettingController) UpdateMainSetting(ctx *fiber.Ctx) error {
 		Alamat:     req.Alamat,
 		Hp:         req.Hp,
 		Logo:       logoFile, // This is the logo file, if uploaded
+
+		NomorDokumenSetting: req.NomorDokumenSetting,
 	}
 
 	// Log the updated existingMainSetting for debugging purposes
@@ -201,3 +210,64 @@ func (c *mainSettingController) DeleteMainSetting(ctx *fiber.Ctx) error {
 	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_DELETE_USER, nil)
 	return ctx.Status(http.StatusOK).JSON(res)
 }
+
+func (c *mainSettingController) UpdatePajakMainSetting(ctx *fiber.Ctx) error {
+	var req dto.MainSettingPajakRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	if req.ID == "" {
+		res := utils.BuildResponseFailed("failed update data", "ID is missing or empty", nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	result, err := c.mainSettingService.UpdatePajakMainSetting(ctx.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_UPDATE_USER, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UPDATE_USER, result)
+	return ctx.Status(http.StatusOK).JSON(res)
+}
+
+func (c *mainSettingController) UpdateQrisMainSetting(ctx *fiber.Ctx) error {
+	var req dto.MainSettingQrisRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	if req.ID == "" {
+		res := utils.BuildResponseFailed("failed update data", "ID is missing or empty", nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	result, err := c.mainSettingService.UpdateQrisMainSetting(ctx.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_UPDATE_USER, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UPDATE_USER, result)
+	return ctx.Status(http.StatusOK).JSON(res)
+}
+
+func (c *mainSettingController) BuatQris(ctx *fiber.Ctx) error {
+	var req dto.QrisRequest
+	if err := ctx.QueryParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	result, err := c.mainSettingService.BuatQris(ctx.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_USER, result)
+	return ctx.Status(http.StatusOK).JSON(res)
+}
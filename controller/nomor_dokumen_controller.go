@@ -0,0 +1,43 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jejevj/ykp_pos/dto"
+	"github.com/jejevj/ykp_pos/service"
+	"github.com/jejevj/ykp_pos/utils"
+)
+
+type (
+	NomorDokumenController interface {
+		PreviewNomor(ctx *fiber.Ctx) error
+	}
+
+	nomorDokumenController struct {
+		nomorDokumenService service.NomorDokumenService
+	}
+)
+
+func NewNomorDokumenController(us service.NomorDokumenService) NomorDokumenController {
+	return &nomorDokumenController{
+		nomorDokumenService: us,
+	}
+}
+
+func (c *nomorDokumenController) PreviewNomor(ctx *fiber.Ctx) error {
+	var req dto.NomorDokumenPreviewRequest
+	if err := ctx.QueryParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	result, err := c.nomorDokumenService.PreviewNomor(ctx.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_USER, result)
+	return ctx.Status(http.StatusOK).JSON(res)
+}
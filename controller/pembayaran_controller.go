@@ -0,0 +1,205 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jejevj/ykp_pos/dto"
+	"github.com/jejevj/ykp_pos/service"
+	"github.com/jejevj/ykp_pos/utils"
+)
+
+type (
+	PembayaranController interface {
+		AddPembayaran(ctx *fiber.Ctx) error
+		GetPembayaranByFaktur(ctx *fiber.Ctx) error
+		GetPembayaranPending(ctx *fiber.Ctx) error
+		TerimaPembayaran(ctx *fiber.Ctx) error
+		TolakPembayaran(ctx *fiber.Ctx) error
+		DeletePembayaran(ctx *fiber.Ctx) error
+		PakaiKredit(ctx *fiber.Ctx) error
+		GetSaldoKredit(ctx *fiber.Ctx) error
+	}
+
+	pembayaranController struct {
+		pembayaranService service.PembayaranService
+	}
+)
+
+func NewPembayaranController(us service.PembayaranService) PembayaranController {
+	return &pembayaranController{
+		pembayaranService: us,
+	}
+}
+
+func (c *pembayaranController) AddPembayaran(ctx *fiber.Ctx) error {
+	var pembayaran dto.PembayaranCreateRequest
+
+	if err := ctx.BodyParser(&pembayaran); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	// The proof is checked by the service, a missing file is reported there
+	if buktiBayar, err := ctx.FormFile("bukti_bayar"); err == nil {
+		pembayaran.BuktiBayar = buktiBayar
+	}
+
+	// The payment is collected by the authenticated user
+	userId, _ := ctx.Locals("user_id").(string)
+
+	result, err := c.pembayaranService.AddPembayaran(ctx.Context(), pembayaran, userId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_REGISTER_USER, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_REGISTER_USER, result)
+	return ctx.Status(http.StatusOK).JSON(res)
+}
+
+func (c *pembayaranController) GetPembayaranByFaktur(ctx *fiber.Ctx) error {
+	var req dto.GetPembayaranByFakturRequest
+	if err := ctx.QueryParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	if req.IdFaktur == "" {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_USER, "id_faktur is missing or empty", nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	result, err := c.pembayaranService.GetPembayaranByFaktur(ctx.Context(), req.IdFaktur)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_USER, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_LIST_USER, result)
+	return ctx.Status(http.StatusOK).JSON(res)
+}
+
+func (c *pembayaranController) GetPembayaranPending(ctx *fiber.Ctx) error {
+	result, err := c.pembayaranService.GetPembayaranPending(ctx.Context())
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_USER, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_LIST_USER, result)
+	return ctx.Status(http.StatusOK).JSON(res)
+}
+
+func (c *pembayaranController) TerimaPembayaran(ctx *fiber.Ctx) error {
+	var req dto.PembayaranReviewRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	if req.ID == "" {
+		res := utils.BuildResponseFailed("failed update data", "ID is missing or empty", nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	userId, _ := ctx.Locals("user_id").(string)
+
+	result, err := c.pembayaranService.TerimaPembayaran(ctx.Context(), req.ID, userId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_UPDATE_USER, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UPDATE_USER, result)
+	return ctx.Status(http.StatusOK).JSON(res)
+}
+
+func (c *pembayaranController) TolakPembayaran(ctx *fiber.Ctx) error {
+	var req dto.PembayaranReviewRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	if req.ID == "" {
+		res := utils.BuildResponseFailed("failed update data", "ID is missing or empty", nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	userId, _ := ctx.Locals("user_id").(string)
+
+	result, err := c.pembayaranService.TolakPembayaran(ctx.Context(), req, userId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_UPDATE_USER, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UPDATE_USER, result)
+	return ctx.Status(http.StatusOK).JSON(res)
+}
+
+func (c *pembayaranController) DeletePembayaran(ctx *fiber.Ctx) error {
+	var req dto.GetPembayaranByIdRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	if req.ID == "" {
+		res := utils.BuildResponseFailed("failed delete data", "ID is missing or empty", nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	if err := c.pembayaranService.DeletePembayaran(ctx.Context(), req.ID); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_DELETE_USER, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_DELETE_USER, nil)
+	return ctx.Status(http.StatusOK).JSON(res)
+}
+
+func (c *pembayaranController) PakaiKredit(ctx *fiber.Ctx) error {
+	var req dto.PakaiKreditRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	if req.IdFaktur == "" {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_UPDATE_USER, "id_faktur is missing or empty", nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	result, err := c.pembayaranService.PakaiKredit(ctx.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_UPDATE_USER, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UPDATE_USER, result)
+	return ctx.Status(http.StatusOK).JSON(res)
+}
+
+func (c *pembayaranController) GetSaldoKredit(ctx *fiber.Ctx) error {
+	var req dto.GetKreditCustomerRequest
+	if err := ctx.QueryParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	if req.IdCustomer == "" {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER, "id_customer is missing or empty", nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	result, err := c.pembayaranService.GetSaldoKredit(ctx.Context(), req.IdCustomer)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_USER, result)
+	return ctx.Status(http.StatusOK).JSON(res)
+}
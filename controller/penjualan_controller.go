@@ -0,0 +1,122 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jejevj/ykp_pos/dto"
+	"github.com/jejevj/ykp_pos/service"
+	"github.com/jejevj/ykp_pos/utils"
+)
+
+type (
+	PenjualanController interface {
+		HitungPenjualan(ctx *fiber.Ctx) error
+		AddPenjualan(ctx *fiber.Ctx) error
+		GetPenjualanById(ctx *fiber.Ctx) error
+		GetAllPenjualanWithPagination(ctx *fiber.Ctx) error
+		CetakStrukPenjualan(ctx *fiber.Ctx) error
+	}
+
+	penjualanController struct {
+		penjualanService service.PenjualanService
+	}
+)
+
+func NewPenjualanController(us service.PenjualanService) PenjualanController {
+	return &penjualanController{
+		penjualanService: us,
+	}
+}
+
+func (c *penjualanController) HitungPenjualan(ctx *fiber.Ctx) error {
+	var req dto.PenjualanCreateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	result, err := c.penjualanService.HitungPenjualan(ctx.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_USER, result)
+	return ctx.Status(http.StatusOK).JSON(res)
+}
+
+func (c *penjualanController) AddPenjualan(ctx *fiber.Ctx) error {
+	var req dto.PenjualanCreateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	// The sale is rung up on the shift of the authenticated cashier
+	userId, _ := ctx.Locals("user_id").(string)
+
+	result, err := c.penjualanService.AddPenjualan(ctx.Context(), req, userId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_REGISTER_USER, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_REGISTER_USER, result)
+	return ctx.Status(http.StatusOK).JSON(res)
+}
+
+func (c *penjualanController) GetPenjualanById(ctx *fiber.Ctx) error {
+	var req dto.GetPenjualanByIdRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	if req.ID == "" {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER, "ID is missing or empty", nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	result, err := c.penjualanService.GetPenjualanById(ctx.Context(), req.ID)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_USER, result)
+	return ctx.Status(http.StatusOK).JSON(res)
+}
+
+func (c *penjualanController) GetAllPenjualanWithPagination(ctx *fiber.Ctx) error {
+	var req dto.PenjualanFilterRequest
+	if err := ctx.QueryParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	result, err := c.penjualanService.GetAllPenjualanWithPagination(ctx.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_USER, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_LIST_USER, result)
+	return ctx.Status(http.StatusOK).JSON(res)
+}
+
+func (c *penjualanController) CetakStrukPenjualan(ctx *fiber.Ctx) error {
+	var req dto.StrukRequest
+	if err := ctx.QueryParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	result, err := c.penjualanService.CetakStrukPenjualan(ctx.Context(), ctx.Params("id"), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	return sendStruk(ctx, result, req.Format)
+}
@@ -0,0 +1,153 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jejevj/ykp_pos/dto"
+	"github.com/jejevj/ykp_pos/service"
+	"github.com/jejevj/ykp_pos/utils"
+)
+
+type (
+	PurchaseOrderController interface {
+		AddPurchaseOrder(ctx *fiber.Ctx) error
+		GetPurchaseOrderById(ctx *fiber.Ctx) error
+		GetAllPurchaseOrderWithPagination(ctx *fiber.Ctx) error
+		TutupPurchaseOrder(ctx *fiber.Ctx) error
+		AddPenerimaan(ctx *fiber.Ctx) error
+		GetPenerimaanById(ctx *fiber.Ctx) error
+	}
+
+	purchaseOrderController struct {
+		purchaseOrderService service.PurchaseOrderService
+	}
+)
+
+func NewPurchaseOrderController(us service.PurchaseOrderService) PurchaseOrderController {
+	return &purchaseOrderController{
+		purchaseOrderService: us,
+	}
+}
+
+func (c *purchaseOrderController) AddPurchaseOrder(ctx *fiber.Ctx) error {
+	var req dto.PurchaseOrderCreateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	// The order is recorded under the admin placing it
+	userId, _ := ctx.Locals("user_id").(string)
+
+	result, err := c.purchaseOrderService.AddPurchaseOrder(ctx.Context(), req, userId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_REGISTER_USER, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_REGISTER_USER, result)
+	return ctx.Status(http.StatusOK).JSON(res)
+}
+
+func (c *purchaseOrderController) GetPurchaseOrderById(ctx *fiber.Ctx) error {
+	var req dto.GetPurchaseOrderByIdRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	if req.ID == "" {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER, "ID is missing or empty", nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	result, err := c.purchaseOrderService.GetPurchaseOrderById(ctx.Context(), req.ID)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_USER, result)
+	return ctx.Status(http.StatusOK).JSON(res)
+}
+
+func (c *purchaseOrderController) GetAllPurchaseOrderWithPagination(ctx *fiber.Ctx) error {
+	var req dto.PurchaseOrderFilterRequest
+	if err := ctx.QueryParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	result, err := c.purchaseOrderService.GetAllPurchaseOrderWithPagination(ctx.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_USER, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_LIST_USER, result)
+	return ctx.Status(http.StatusOK).JSON(res)
+}
+
+func (c *purchaseOrderController) TutupPurchaseOrder(ctx *fiber.Ctx) error {
+	var req dto.TutupPurchaseOrderRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	if req.ID == "" {
+		res := utils.BuildResponseFailed("failed update data", "ID is missing or empty", nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	result, err := c.purchaseOrderService.TutupPurchaseOrder(ctx.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_UPDATE_USER, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UPDATE_USER, result)
+	return ctx.Status(http.StatusOK).JSON(res)
+}
+
+func (c *purchaseOrderController) AddPenerimaan(ctx *fiber.Ctx) error {
+	var req dto.PenerimaanBarangCreateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	userId, _ := ctx.Locals("user_id").(string)
+
+	result, err := c.purchaseOrderService.AddPenerimaan(ctx.Context(), req, userId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_REGISTER_USER, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_REGISTER_USER, result)
+	return ctx.Status(http.StatusOK).JSON(res)
+}
+
+func (c *purchaseOrderController) GetPenerimaanById(ctx *fiber.Ctx) error {
+	var req dto.GetPenerimaanByIdRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	if req.ID == "" {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER, "ID is missing or empty", nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	result, err := c.purchaseOrderService.GetPenerimaanById(ctx.Context(), req.ID)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_USER, result)
+	return ctx.Status(http.StatusOK).JSON(res)
+}
@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jejevj/ykp_pos/dto"
+	"github.com/jejevj/ykp_pos/service"
+	"github.com/jejevj/ykp_pos/utils"
+)
+
+type (
+	ReturController interface {
+		AddRetur(ctx *fiber.Ctx) error
+		GetReturById(ctx *fiber.Ctx) error
+		GetReturByFaktur(ctx *fiber.Ctx) error
+	}
+
+	returController struct {
+		returService service.ReturService
+	}
+)
+
+func NewReturController(us service.ReturService) ReturController {
+	return &returController{
+		returService: us,
+	}
+}
+
+func (c *returController) AddRetur(ctx *fiber.Ctx) error {
+	var retur dto.ReturCreateRequest
+
+	if err := ctx.BodyParser(&retur); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	// The goods are taken back by the authenticated user
+	userId, _ := ctx.Locals("user_id").(string)
+
+	result, err := c.returService.AddRetur(ctx.Context(), retur, userId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_REGISTER_USER, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_REGISTER_USER, result)
+	return ctx.Status(http.StatusOK).JSON(res)
+}
+
+func (c *returController) GetReturById(ctx *fiber.Ctx) error {
+	var req dto.GetReturByIdRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	if req.ID == "" {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER, "ID is missing or empty", nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	result, err := c.returService.GetReturById(ctx.Context(), req.ID)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_USER, result)
+	return ctx.Status(http.StatusOK).JSON(res)
+}
+
+func (c *returController) GetReturByFaktur(ctx *fiber.Ctx) error {
+	var req dto.GetReturByFakturRequest
+	if err := ctx.QueryParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	if req.IdFaktur == "" {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_USER, "id_faktur is missing or empty", nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	result, err := c.returService.GetReturByFaktur(ctx.Context(), req.IdFaktur)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_USER, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_LIST_USER, result)
+	return ctx.Status(http.StatusOK).JSON(res)
+}
@@ -63,8 +63,13 @@ func (c *satuanController) GetSatuanById(ctx *fiber.Ctx) error {
 	return ctx.Status(http.StatusOK).JSON(res)
 }
 func (c *satuanController) GetAllSatuanWithPagination(ctx *fiber.Ctx) error {
+	var req dto.PaginationRequest
+	if err := ctx.QueryParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
 
-	result, err := c.satuanService.GetAllSatuanWithPagination(ctx.Context())
+	result, err := c.satuanService.GetAllSatuanWithPagination(ctx.Context(), req)
 	if err != nil {
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_USER, err.Error(), nil)
 		return ctx.Status(http.StatusBadRequest).JSON(res)
@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jejevj/ykp_pos/dto"
+	"github.com/jejevj/ykp_pos/service"
+	"github.com/jejevj/ykp_pos/utils"
+)
+
+type (
+	SetoranController interface {
+		AddSetoran(ctx *fiber.Ctx) error
+		GetSetoranByLoading(ctx *fiber.Ctx) error
+		CetakSetoran(ctx *fiber.Ctx) error
+	}
+
+	setoranController struct {
+		setoranService service.SetoranService
+	}
+)
+
+func NewSetoranController(us service.SetoranService) SetoranController {
+	return &setoranController{
+		setoranService: us,
+	}
+}
+
+func (c *setoranController) AddSetoran(ctx *fiber.Ctx) error {
+	var req dto.SetoranCreateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	if req.IdLoading == "" {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_REGISTER_USER, "id_loading is missing or empty", nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	// The warehouse user receiving the setoran
+	userId, _ := ctx.Locals("user_id").(string)
+
+	result, err := c.setoranService.AddSetoran(ctx.Context(), req, userId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_REGISTER_USER, err.Error(), nil)
+		if errors.Is(err, dto.ErrLoadingInvalidTransition) {
+			return ctx.Status(http.StatusConflict).JSON(res)
+		}
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_REGISTER_USER, result)
+	return ctx.Status(http.StatusOK).JSON(res)
+}
+
+func (c *setoranController) GetSetoranByLoading(ctx *fiber.Ctx) error {
+	var req dto.GetSetoranByLoadingRequest
+	if err := ctx.QueryParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	result, err := c.setoranService.GetSetoranByLoading(ctx.Context(), req.IdLoading, ownerFilter(ctx))
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER, err.Error(), nil)
+		return ctx.Status(failedStatus(err, http.StatusBadRequest)).JSON(res)
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_USER, result)
+	return ctx.Status(http.StatusOK).JSON(res)
+}
+
+func (c *setoranController) CetakSetoran(ctx *fiber.Ctx) error {
+	var req dto.GetSetoranByLoadingRequest
+	if err := ctx.QueryParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
+
+	result, err := c.setoranService.CetakSetoran(ctx.Context(), req.IdLoading, ownerFilter(ctx))
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER, err.Error(), nil)
+		return ctx.Status(failedStatus(err, http.StatusBadRequest)).JSON(res)
+	}
+
+	ctx.Set(fiber.HeaderContentType, "application/pdf")
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf("inline; filename=\"setoran-%s.pdf\"", req.IdLoading))
+	return ctx.Status(http.StatusOK).Send(result)
+}
@@ -37,10 +37,12 @@ func (c *transaksiController) AddTransaksi(ctx *fiber.Ctx) error {
 		return ctx.Status(http.StatusBadRequest).JSON(res)
 	}
 
-	result, err := c.transaksiService.AddTransaksi(ctx.Context(), transaksi)
+	userId, _ := ctx.Locals("user_id").(string)
+
+	result, err := c.transaksiService.AddTransaksi(ctx.Context(), transaksi, userId, ownerFilter(ctx))
 	if err != nil {
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_REGISTER_USER, err.Error(), nil)
-		return ctx.Status(http.StatusBadRequest).JSON(res)
+		return ctx.Status(failedStatus(err, http.StatusBadRequest)).JSON(res)
 	}
 
 	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_REGISTER_USER, result)
@@ -53,18 +55,28 @@ func (c *transaksiController) GetTransaksiById(ctx *fiber.Ctx) error {
 		return ctx.Status(http.StatusBadRequest).JSON(response)
 	}
 
-	result, err := c.transaksiService.GetTransaksiById(ctx.Context(), req.ID)
+	result, err := c.transaksiService.GetTransaksiById(ctx.Context(), req.ID, ownerFilter(ctx))
 	if err != nil {
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER, err.Error(), nil)
-		return ctx.Status(http.StatusBadRequest).JSON(res)
+		return ctx.Status(failedStatus(err, http.StatusBadRequest)).JSON(res)
 	}
 
 	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_USER, result)
 	return ctx.Status(http.StatusOK).JSON(res)
 }
 func (c *transaksiController) GetAllTransaksiWithPagination(ctx *fiber.Ctx) error {
+	var req dto.TransaksiFilterRequest
+	if err := ctx.QueryParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
 
-	result, err := c.transaksiService.GetAllTransaksiWithPagination(ctx.Context())
+	// Sales users only ever see the lines of their own loadings
+	if ownerId := ownerFilter(ctx); ownerId != "" {
+		req.IdUser = ownerId
+	}
+
+	result, err := c.transaksiService.GetAllTransaksiWithPagination(ctx.Context(), req)
 	if err != nil {
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_USER, err.Error(), nil)
 		return ctx.Status(http.StatusBadRequest).JSON(res)
@@ -95,10 +107,10 @@ func (c *transaksiController) UpdateTransaksi(ctx *fiber.Ctx) error {
 	}
 
 	// Get the existing data by ID
-	existingTransaksi, err := c.transaksiService.GetTransaksiById(ctx.Context(), req.ID)
+	existingTransaksi, err := c.transaksiService.GetTransaksiById(ctx.Context(), req.ID, ownerFilter(ctx))
 	if err != nil {
 		res := utils.BuildResponseFailed("failed update data", "Transaksi not found: "+err.Error(), nil)
-		return ctx.Status(http.StatusNotFound).JSON(res)
+		return ctx.Status(failedStatus(err, http.StatusNotFound)).JSON(res)
 	}
 
 	// Use the existing entity and update the fields
@@ -106,11 +118,13 @@ func (c *transaksiController) UpdateTransaksi(ctx *fiber.Ctx) error {
 	existingTransaksi.IdBarang = req.IdBarang
 	existingTransaksi.Jumlah = req.Jumlah
 
+	userId, _ := ctx.Locals("user_id").(string)
+
 	// Call the service to update the Transaksi
-	result, err := c.transaksiService.UpdateTransaksi(ctx.Context(), req, req.ID)
+	result, err := c.transaksiService.UpdateTransaksi(ctx.Context(), req, req.ID, userId, ownerFilter(ctx))
 	if err != nil {
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_UPDATE_USER, err.Error(), nil)
-		return ctx.Status(http.StatusBadRequest).JSON(res)
+		return ctx.Status(failedStatus(err, http.StatusBadRequest)).JSON(res)
 	}
 
 	// Return the success response with the updated Transaksi
@@ -130,10 +144,12 @@ func (c *transaksiController) DeleteTransaksi(ctx *fiber.Ctx) error {
 		return ctx.Status(http.StatusBadRequest).JSON(res)
 	}
 
-	err := c.transaksiService.DeleteTransaksi(ctx.Context(), req.ID)
+	userId, _ := ctx.Locals("user_id").(string)
+
+	err := c.transaksiService.DeleteTransaksi(ctx.Context(), req.ID, userId, ownerFilter(ctx))
 	if err != nil {
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_DELETE_USER, err.Error(), nil)
-		return ctx.Status(http.StatusBadRequest).JSON(res)
+		return ctx.Status(failedStatus(err, http.StatusBadRequest)).JSON(res)
 	}
 
 	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_DELETE_USER, nil)
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/jejevj/ykp_pos/constants"
 	"github.com/jejevj/ykp_pos/dto"
 	"github.com/jejevj/ykp_pos/service"
 	"github.com/jejevj/ykp_pos/utils"
@@ -64,8 +65,13 @@ func (c *userController) Register(ctx *fiber.Ctx) error {
 }
 
 func (c *userController) GetAllUser(ctx *fiber.Ctx) error {
+	var req dto.PaginationRequest
+	if err := ctx.QueryParser(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		return ctx.Status(http.StatusBadRequest).JSON(res)
+	}
 
-	result, err := c.userService.GetAllUserWithPagination(ctx.Context())
+	result, err := c.userService.GetAllUserWithPagination(ctx.Context(), req)
 	if err != nil {
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_USER, err.Error(), nil)
 		return ctx.Status(http.StatusBadRequest).JSON(res)
@@ -152,7 +158,13 @@ func (c *userController) Update(ctx *fiber.Ctx) error {
 		return ctx.Status(http.StatusBadRequest).JSON(res)
 	}
 
-	userId := req.ID
+	// Only an admin may edit another account, everyone else edits their own
+	userId, _ := ctx.Locals("user_id").(string)
+	role, _ := ctx.Locals("role").(string)
+	if (role == constants.ENUM_ROLE_ADMIN || role == constants.ENUM_ROLE_SU) && req.ID != "" {
+		userId = req.ID
+	}
+
 	result, err := c.userService.UpdateUser(ctx.Context(), req, userId)
 	if err != nil {
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_UPDATE_USER, err.Error(), nil)
@@ -5,6 +5,29 @@ import (
 )
 
 type (
+	// JumlahSatuanRequest is a quantity in one of the units of a barang, e.g.
+	// {"satuan": "krat", "jumlah": 2}.
+	JumlahSatuanRequest struct {
+		Satuan string `json:"satuan" form:"satuan"`
+		Jumlah int    `json:"jumlah" form:"jumlah"`
+	}
+
+	JumlahSatuanResponse struct {
+		Satuan string `json:"satuan"`
+		Jumlah int    `json:"jumlah"`
+	}
+
+	BarangSatuanRequest struct {
+		NamaSatuan string `json:"nama_satuan" form:"nama_satuan"`
+		Isi        int    `json:"isi" form:"isi"`
+	}
+
+	BarangSatuanResponse struct {
+		ID         string `json:"id"`
+		NamaSatuan string `json:"nama_satuan"`
+		Isi        int    `json:"isi"`
+	}
+
 	BarangCreateRequest struct {
 		NamaBarang   string `json:"nama_barang" form:"nama_barang"`
 		KodeBarang   string `json:"kode_barang" form:"kode_barang"`
@@ -14,63 +37,137 @@ type (
 		JumlahKrat   int    `json:"jumlah_krat" form:"jumlah_krat"`
 		JumlahSatuan int    `json:"jumlah_satuan" form:"jumlah_satuan"`
 		// Stok         int    `json:"stok" form:"stok"`
+		Satuans []BarangSatuanRequest `json:"satuans" form:"satuans"`
+		Rincian []JumlahSatuanRequest `json:"rincian" form:"rincian"`
 	}
 
 	GetBarangByIdRequest struct {
 		ID string `json:"id" form:"id"`
 	}
 
+	// GetBarangByKodeRequest looks a barang up by the code scanned at the
+	// counter.
+	GetBarangByKodeRequest struct {
+		KodeBarang string `query:"kode_barang" form:"kode_barang"`
+	}
+
 	BarangResponse struct {
-		ID           string         `json:"id"`
-		NamaBarang   string         `json:"nama_barang"`
-		KodeBarang   string         `json:"kode_barang"`
-		HargaBeli    int            `json:"harga_beli"`
-		HargaJual    int            `json:"harga_jual"`
-		IdSatuan     string         `json:"id_satuan"`
-		Satuan       SatuanResponse `json:"satuan"`
-		JumlahKrat   int            `json:"jumlah_krat" form:"jumlah_krat"`
-		JumlahSatuan int            `json:"jumlah_satuan" form:"jumlah_satuan"`
-		Stok         int            `json:"stok"`
+		ID           string                 `json:"id"`
+		NamaBarang   string                 `json:"nama_barang"`
+		KodeBarang   string                 `json:"kode_barang"`
+		HargaBeli    int                    `json:"harga_beli"`
+		HargaJual    int                    `json:"harga_jual"`
+		IdSatuan     string                 `json:"id_satuan"`
+		Satuan       SatuanResponse         `json:"satuan"`
+		JumlahKrat   int                    `json:"jumlah_krat" form:"jumlah_krat"`
+		JumlahSatuan int                    `json:"jumlah_satuan" form:"jumlah_satuan"`
+		Stok         int                    `json:"stok"`
+		StokFormat   string                 `json:"stok_format"`
+		StokRincian  []JumlahSatuanResponse `json:"stok_rincian"`
+		StokRusak    int                    `json:"stok_rusak"`
+		Satuans      []BarangSatuanResponse `json:"satuans"`
 	}
 
+	// BarangPaginationResponse carries Total, the number of barang before the
+	// search, next to the filtered Count for the DataTable.
 	BarangPaginationResponse struct {
-		Data []BarangResponse `json:"data"`
+		Data  []BarangResponse `json:"data"`
+		Total int64            `json:"total"`
 		PaginationResponse
 	}
 
 	GetAllBarangRepositoryResponse struct {
 		Barangs []entity.Barang
+		Total   int64
 		PaginationResponse
 	}
 
 	BarangUpdateRequest struct {
-		ID           string `json:"id" form:"id"`
-		NamaBarang   string `json:"nama_barang" form:"nama_barang"`
-		KodeBarang   string `json:"kode_barang" form:"kode_barang"`
-		HargaBeli    int    `json:"harga_beli" form:"harga_beli"`
-		HargaJual    int    `json:"harga_jual" form:"harga_jual"`
-		IdSatuan     string `json:"id_satuan" form:"id_satuan"`
-		JumlahKrat   int    `json:"jumlah_krat" form:"jumlah_krat"`
-		JumlahSatuan int    `json:"jumlah_satuan" form:"jumlah_satuan"`
-		Stok         int    `json:"stok" form:"stok"`
+		ID           string                `json:"id" form:"id"`
+		NamaBarang   string                `json:"nama_barang" form:"nama_barang"`
+		KodeBarang   string                `json:"kode_barang" form:"kode_barang"`
+		HargaBeli    int                   `json:"harga_beli" form:"harga_beli"`
+		HargaJual    int                   `json:"harga_jual" form:"harga_jual"`
+		IdSatuan     string                `json:"id_satuan" form:"id_satuan"`
+		JumlahKrat   int                   `json:"jumlah_krat" form:"jumlah_krat"`
+		JumlahSatuan int                   `json:"jumlah_satuan" form:"jumlah_satuan"`
+		Satuans      []BarangSatuanRequest `json:"satuans" form:"satuans"`
 	}
 
 	BarangUpdateStokRequest struct {
-		ID           string `json:"id" form:"id"`
-		JumlahKrat   int    `json:"jumlah_krat" form:"jumlah_krat"`
-		JumlahSatuan int    `json:"jumlah_satuan" form:"jumlah_satuan"`
+		ID           string                `json:"id" form:"id"`
+		JumlahKrat   int                   `json:"jumlah_krat" form:"jumlah_krat"`
+		JumlahSatuan int                   `json:"jumlah_satuan" form:"jumlah_satuan"`
+		Rincian      []JumlahSatuanRequest `json:"rincian" form:"rincian"`
+	}
+
+	// BarangOpnameRequest carries the quantity counted on the shelf, which
+	// becomes the new stock.
+	BarangOpnameRequest struct {
+		ID           string                `json:"id" form:"id"`
+		JumlahKrat   int                   `json:"jumlah_krat" form:"jumlah_krat"`
+		JumlahSatuan int                   `json:"jumlah_satuan" form:"jumlah_satuan"`
+		Rincian      []JumlahSatuanRequest `json:"rincian" form:"rincian"`
+		Keterangan   string                `json:"keterangan" form:"keterangan"`
 	}
 
 	BarangUpdateResponse struct {
-		ID           string         `json:"id"`
-		NamaBarang   string         `json:"nama_barang"`
-		KodeBarang   string         `json:"kode_barang"`
-		HargaBeli    int            `json:"harga_beli"`
-		HargaJual    int            `json:"harga_jual"`
-		IdSatuan     string         `json:"id_satuan"`
-		Satuan       SatuanResponse `json:"satuan"`
-		JumlahKrat   int            `json:"jumlah_krat" form:"jumlah_krat"`
-		JumlahSatuan int            `json:"jumlah_satuan" form:"jumlah_satuan"`
-		Stok         int            `json:"stok"`
+		ID           string                 `json:"id"`
+		NamaBarang   string                 `json:"nama_barang"`
+		KodeBarang   string                 `json:"kode_barang"`
+		HargaBeli    int                    `json:"harga_beli"`
+		HargaJual    int                    `json:"harga_jual"`
+		IdSatuan     string                 `json:"id_satuan"`
+		Satuan       SatuanResponse         `json:"satuan"`
+		JumlahKrat   int                    `json:"jumlah_krat" form:"jumlah_krat"`
+		JumlahSatuan int                    `json:"jumlah_satuan" form:"jumlah_satuan"`
+		Stok         int                    `json:"stok"`
+		StokFormat   string                 `json:"stok_format"`
+		StokRincian  []JumlahSatuanResponse `json:"stok_rincian"`
+		StokRusak    int                    `json:"stok_rusak"`
+		Satuans      []BarangSatuanResponse `json:"satuans"`
+	}
+)
+
+type (
+	KartuStokRequest struct {
+		IdBarang     string `query:"id_barang" form:"id_barang"`
+		TanggalMulai string `query:"tanggal_mulai" form:"tanggal_mulai"`
+		TanggalAkhir string `query:"tanggal_akhir" form:"tanggal_akhir"`
+	}
+
+	StockMovementResponse struct {
+		ID           string `json:"id"`
+		Tanggal      string `json:"tanggal"`
+		Jumlah       int    `json:"jumlah"`
+		JumlahFormat string `json:"jumlah_format"`
+		Alasan       string `json:"alasan"`
+		RefTipe      string `json:"ref_tipe"`
+		RefId        string `json:"ref_id"`
+		RefNo        string `json:"ref_no"`
+		IdUser       string `json:"id_user"`
+		Masuk        int    `json:"masuk"`
+		Keluar       int    `json:"keluar"`
+		Saldo        int    `json:"saldo"`
+		Keterangan   string `json:"keterangan"`
+	}
+
+	KartuStokResponse struct {
+		Barang           BarangResponse          `json:"barang"`
+		TanggalMulai     string                  `json:"tanggal_mulai"`
+		TanggalAkhir     string                  `json:"tanggal_akhir"`
+		SaldoAwal        int                     `json:"saldo_awal"`
+		SaldoAwalFormat  string                  `json:"saldo_awal_format"`
+		TotalMasuk       int                     `json:"total_masuk"`
+		TotalKeluar      int                     `json:"total_keluar"`
+		SaldoAkhir       int                     `json:"saldo_akhir"`
+		SaldoAkhirFormat string                  `json:"saldo_akhir_format"`
+		Data             []StockMovementResponse `json:"data"`
+	}
+
+	GetKartuStokRepositoryResponse struct {
+		Barang    entity.Barang
+		SaldoAwal int
+		Movements []entity.StockMovement
 	}
 )
@@ -10,17 +10,47 @@ type (
 		NamaPemilik string `json:"nama_pemilik" form:"nama_pemilik"`
 		Alamat      string `json:"alamat" form:"alamat"`
 		HP          string `json:"hp" form:"hp"`
+		Npwp        string `json:"npwp" form:"npwp"`
 	}
 	GetCustomerByIdRequest struct {
 		ID string `json:"id" form:"id"`
 	}
 
 	CustomerResponse struct {
-		ID          string `json:"id"`
-		NamaToko    string `json:"nama_toko"`
-		NamaPemilik string `json:"nama_pemilik"`
-		Alamat      string `json:"alamat"`
-		HP          string `json:"hp"`
+		ID           string `json:"id"`
+		NamaToko     string `json:"nama_toko"`
+		NamaPemilik  string `json:"nama_pemilik"`
+		Alamat       string `json:"alamat"`
+		HP           string `json:"hp"`
+		Npwp         string `json:"npwp"`
+		LimitKredit  int    `json:"limit_kredit"`
+		MaxHariTempo int    `json:"max_hari_tempo"`
+	}
+
+	CustomerKreditRequest struct {
+		ID           string `json:"id" form:"id"`
+		LimitKredit  int    `json:"limit_kredit" form:"limit_kredit"`
+		MaxHariTempo int    `json:"max_hari_tempo" form:"max_hari_tempo"`
+	}
+
+	OverrideKreditCreateRequest struct {
+		IdCustomer string `json:"id_customer" form:"id_customer"`
+		Alasan     string `json:"alasan" form:"alasan"`
+	}
+
+	GetOverrideKreditRequest struct {
+		IdCustomer string `query:"id_customer" form:"id_customer"`
+	}
+
+	OverrideKreditResponse struct {
+		ID            string       `json:"id"`
+		IdCustomer    string       `json:"id_customer"`
+		Alasan        string       `json:"alasan"`
+		IdUser        string       `json:"id_user"`
+		Admin         UserResponse `json:"admin"`
+		BerlakuSampai string       `json:"berlaku_sampai"`
+		IdFaktur      string       `json:"id_faktur"`
+		Dipakai       bool         `json:"dipakai"`
 	}
 
 	CustomerPaginationResponse struct {
@@ -39,6 +69,7 @@ type (
 		NamaPemilik string `json:"nama_pemilik"`
 		Alamat      string `json:"alamat"`
 		HP          string `json:"hp"`
+		Npwp        string `json:"npwp"`
 	}
 
 	CustomerUpdateResponse struct {
@@ -47,5 +78,6 @@ type (
 		NamaPemilik string `json:"nama_pemilik"`
 		Alamat      string `json:"alamat"`
 		HP          string `json:"hp"`
+		Npwp        string `json:"npwp"`
 	}
 )
@@ -0,0 +1,140 @@
+package dto
+
+import (
+	"github.com/jejevj/ykp_pos/entity"
+)
+
+type (
+	TransaksiFakturRequest struct {
+		IdBarang string                `json:"id_barang" form:"id_barang"`
+		Krat     int                   `json:"krat" form:"krat"`
+		Lusin    int                   `json:"lusin" form:"lusin"`
+		Satuan   int                   `json:"satuan" form:"satuan"`
+		Diskon   int                   `json:"diskon" form:"diskon"`
+		DiskonP  float32               `json:"diskon_p" form:"diskon_p"`
+		Ket      string                `json:"keterangan" form:"keterangan"`
+		Rincian  []JumlahSatuanRequest `json:"rincian" form:"rincian"`
+	}
+
+	FakturCreateRequest struct {
+		TanggalFaktur string                   `json:"tanggal_faktur" form:"tanggal_faktur"`
+		TanggalTempo  string                   `json:"tanggal_tempo" form:"tanggal_tempo"`
+		CaraBayar     string                   `json:"cara_bayar" form:"cara_bayar"`
+		IdCustomer    string                   `json:"id_customer" form:"id_customer"`
+		IdLoading     string                   `json:"id_loading" form:"id_loading"`
+		Keterangan    string                   `json:"keterangan" form:"keterangan"`
+		Items         []TransaksiFakturRequest `json:"items" form:"items"`
+		// Invoice discount, taken off after the line discounts
+		Diskon  int     `json:"diskon" form:"diskon"`
+		DiskonP float32 `json:"diskon_p" form:"diskon_p"`
+		// HargaTermasukPpn overrides the pricing of the main setting
+		HargaTermasukPpn *bool `json:"harga_termasuk_ppn" form:"harga_termasuk_ppn"`
+		// PakaiKredit pays the faktur with the credit of the customer as far
+		// as it goes
+		PakaiKredit bool `json:"pakai_kredit" form:"pakai_kredit"`
+	}
+
+	GetFakturByIdRequest struct {
+		ID string `json:"id" form:"id"`
+	}
+
+	FakturCancelRequest struct {
+		ID     string `json:"id" form:"id"`
+		Alasan string `json:"alasan" form:"alasan"`
+	}
+
+	// StrukRequest picks how a receipt is printed: raw ESC/POS bytes or a
+	// plain-text preview, 32 or 48 columns wide.
+	StrukRequest struct {
+		Format string `query:"format" form:"format"`
+		Kolom  int    `query:"kolom" form:"kolom"`
+	}
+
+	FakturFilterRequest struct {
+		PaginationRequest
+		IdCustomer   string `query:"id_customer" form:"id_customer"`
+		IdUser       string `query:"id_user" form:"id_user"`
+		IdLoading    string `query:"id_loading" form:"id_loading"`
+		Status       string `query:"status" form:"status"`
+		CaraBayar    string `query:"cara_bayar" form:"cara_bayar"`
+		TanggalMulai string `query:"tanggal_mulai" form:"tanggal_mulai"`
+		TanggalAkhir string `query:"tanggal_akhir" form:"tanggal_akhir"`
+	}
+
+	TransaksiFakturResponse struct {
+		ID           string         `json:"id"`
+		IdFaktur     string         `json:"id_faktur"`
+		IdBarang     string         `json:"id_barang"`
+		Barang       BarangResponse `json:"barang"`
+		Krat         int            `json:"krat"`
+		Lusin        int            `json:"lusin"`
+		Satuan       int            `json:"satuan"`
+		Jumlah       int            `json:"jumlah"`
+		JumlahFormat string         `json:"jumlah_format"`
+		Harga        int            `json:"harga"`
+		JumlahRP     int            `json:"jumlah_rp"`
+		Dpp          int            `json:"dpp"`
+		Ppn          int            `json:"ppn"`
+		Diskon       int            `json:"diskon"`
+		DiskonP      float32        `json:"diskon_p"`
+		DiskonFaktur int            `json:"diskon_faktur"`
+		Ket          string         `json:"keterangan"`
+	}
+
+	FakturResponse struct {
+		ID               string                    `json:"id"`
+		NoFaktur         string                    `json:"no_faktur"`
+		TanggalFaktur    string                    `json:"tanggal_faktur"`
+		TanggalTempo     string                    `json:"tanggal_tempo"`
+		CaraBayar        string                    `json:"cara_bayar"`
+		IdCustomer       string                    `json:"id_customer"`
+		Customer         CustomerResponse          `json:"customer"`
+		IdUser           string                    `json:"id_user"`
+		Driver           UserResponse              `json:"driver"`
+		IdLoading        string                    `json:"id_loading"`
+		Status           string                    `json:"status"`
+		Subtotal         int                       `json:"subtotal"`
+		Diskon           int                       `json:"diskon"`
+		DiskonP          float32                   `json:"diskon_p"`
+		PotonganDiskon   int                       `json:"potongan_diskon"`
+		Total            int                       `json:"total"`
+		TotalBayar       int                       `json:"total_bayar"`
+		TarifPpn         float64                   `json:"tarif_ppn"`
+		HargaTermasukPpn bool                      `json:"harga_termasuk_ppn"`
+		Dpp              int                       `json:"dpp"`
+		Ppn              int                       `json:"ppn"`
+		Eceran           bool                      `json:"eceran"`
+		SisaTagihan      int                       `json:"sisa_tagihan"`
+		BuktiBayar       string                    `json:"bukti_bayar"`
+		IdOverrideKredit string                    `json:"id_override_kredit"`
+		Keterangan       string                    `json:"keterangan"`
+		AlasanBatal      string                    `json:"alasan_batal"`
+		IdUserBatal      string                    `json:"id_user_batal"`
+		TanggalBatal     string                    `json:"tanggal_batal"`
+		Items            []TransaksiFakturResponse `json:"items"`
+	}
+
+	FakturPaginationResponse struct {
+		Data []FakturResponse `json:"data"`
+		PaginationResponse
+	}
+
+	GetAllFakturRepositoryResponse struct {
+		Fakturs []entity.Faktur
+		PaginationResponse
+	}
+
+	FakturUpdateRequest struct {
+		ID               string                   `json:"id" form:"id"`
+		TanggalFaktur    string                   `json:"tanggal_faktur" form:"tanggal_faktur"`
+		TanggalTempo     string                   `json:"tanggal_tempo" form:"tanggal_tempo"`
+		CaraBayar        string                   `json:"cara_bayar" form:"cara_bayar"`
+		IdCustomer       string                   `json:"id_customer" form:"id_customer"`
+		Keterangan       string                   `json:"keterangan" form:"keterangan"`
+		Items            []TransaksiFakturRequest `json:"items" form:"items"`
+		HargaTermasukPpn *bool                    `json:"harga_termasuk_ppn" form:"harga_termasuk_ppn"`
+		// The invoice discount is kept when it is not sent
+		Diskon  *int     `json:"diskon" form:"diskon"`
+		DiskonP *float32 `json:"diskon_p" form:"diskon_p"`
+	}
+)
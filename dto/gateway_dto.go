@@ -0,0 +1,39 @@
+package dto
+
+import "time"
+
+type (
+	// NotifikasiGateway is a payment notification or status answer of a
+	// payment gateway, already checked and translated. Status is one of
+	// pending, berhasil or gagal; StatusGateway and Payload are kept as the
+	// gateway sent them.
+	NotifikasiGateway struct {
+		OrderId       string
+		IdTransaksi   string
+		Status        string
+		StatusGateway string
+		Metode        string
+		Jumlah        int
+		WaktuBayar    *time.Time
+		Payload       string
+	}
+
+	GetTransaksiGatewayByFakturRequest struct {
+		IdFaktur string `query:"id_faktur" form:"id_faktur"`
+	}
+
+	TransaksiGatewayResponse struct {
+		ID            string `json:"id"`
+		Gateway       string `json:"gateway"`
+		OrderId       string `json:"order_id"`
+		IdTransaksi   string `json:"id_transaksi"`
+		IdFaktur      string `json:"id_faktur"`
+		NoFaktur      string `json:"no_faktur"`
+		Status        string `json:"status"`
+		StatusGateway string `json:"status_gateway"`
+		Metode        string `json:"metode"`
+		Jumlah        int    `json:"jumlah"`
+		WaktuBayar    string `json:"waktu_bayar"`
+		IdPembayaran  string `json:"id_pembayaran"`
+	}
+)
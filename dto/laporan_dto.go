@@ -0,0 +1,98 @@
+package dto
+
+import (
+	"github.com/jejevj/ykp_pos/entity"
+)
+
+type (
+	UmurPiutangRequest struct {
+		// Tanggal is the date the report is computed for, today when empty
+		Tanggal    string `query:"tanggal" form:"tanggal"`
+		Group      string `query:"group" form:"group"`
+		IdCustomer string `query:"id_customer" form:"id_customer"`
+		IdUser     string `query:"id_user" form:"id_user"`
+		Format     string `query:"format" form:"format"`
+	}
+
+	// UmurPiutangBucket splits an outstanding balance by the number of days it
+	// is past its due date.
+	UmurPiutangBucket struct {
+		Current    int `json:"current"`
+		Hari1To30  int `json:"hari_1_30"`
+		Hari31To60 int `json:"hari_31_60"`
+		Hari61To90 int `json:"hari_61_90"`
+		HariOver90 int `json:"hari_over_90"`
+		Total      int `json:"total"`
+	}
+
+	UmurPiutangGroupResponse struct {
+		ID   string `json:"id"`
+		Nama string `json:"nama"`
+		UmurPiutangBucket
+	}
+
+	UmurPiutangFakturResponse struct {
+		IdFaktur      string `json:"id_faktur"`
+		NoFaktur      string `json:"no_faktur"`
+		TanggalFaktur string `json:"tanggal_faktur"`
+		TanggalTempo  string `json:"tanggal_tempo"`
+		IdCustomer    string `json:"id_customer"`
+		NamaCustomer  string `json:"nama_customer"`
+		IdUser        string `json:"id_user"`
+		NamaSales     string `json:"nama_sales"`
+		Total         int    `json:"total"`
+		Dibayar       int    `json:"dibayar"`
+		Sisa          int    `json:"sisa"`
+		HariLewat     int    `json:"hari_lewat"`
+		Kelompok      string `json:"kelompok"`
+	}
+
+	UmurPiutangResponse struct {
+		Tanggal string                      `json:"tanggal"`
+		Group   string                      `json:"group"`
+		Groups  []UmurPiutangGroupResponse  `json:"groups"`
+		Total   UmurPiutangBucket           `json:"total"`
+		Fakturs []UmurPiutangFakturResponse `json:"fakturs"`
+	}
+
+	RekapPpnRequest struct {
+		// Bulan is the tax period as YYYY-MM
+		Bulan  string `query:"bulan" form:"bulan"`
+		Format string `query:"format" form:"format"`
+	}
+
+	RekapPpnTotal struct {
+		Jumlah int `json:"jumlah"`
+		Dpp    int `json:"dpp"`
+		Ppn    int `json:"ppn"`
+	}
+
+	RekapPpnFakturResponse struct {
+		IdFaktur      string `json:"id_faktur"`
+		NoFaktur      string `json:"no_faktur"`
+		TanggalFaktur string `json:"tanggal_faktur"`
+		IdCustomer    string `json:"id_customer"`
+		NamaCustomer  string `json:"nama_customer"`
+		Npwp          string `json:"npwp"`
+		Eceran        bool   `json:"eceran"`
+		Dpp           int    `json:"dpp"`
+		Ppn           int    `json:"ppn"`
+		Total         int    `json:"total"`
+	}
+
+	// RekapPpnResponse sums the PPN of a month. Retail invoices to customers
+	// without NPWP are totalled separately and left out of the e-Faktur file.
+	RekapPpnResponse struct {
+		Bulan      string                   `json:"bulan"`
+		FakturNpwp RekapPpnTotal            `json:"faktur_npwp"`
+		Eceran     RekapPpnTotal            `json:"eceran"`
+		Total      RekapPpnTotal            `json:"total"`
+		Fakturs    []RekapPpnFakturResponse `json:"fakturs"`
+	}
+
+	GetPiutangRepositoryResponse struct {
+		Fakturs []entity.Faktur
+		// Dibayar holds the accepted payments per faktur up to the report date
+		Dibayar map[string]int
+	}
+)
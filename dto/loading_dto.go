@@ -12,11 +12,35 @@ type (
 	GetLoadingByIdRequest struct {
 		ID string `json:"id" form:"id"`
 	}
+
+	LoadingFilterRequest struct {
+		PaginationRequest
+		IdUser string `query:"id_user" form:"id_user"`
+		Status string `query:"status" form:"status"`
+	}
+
+	// LoadingStatusResponse holds the moment and the acting user of every
+	// step a loading has gone through.
+	LoadingStatusResponse struct {
+		SubmittedAt  string `json:"submitted_at"`
+		SubmittedBy  string `json:"submitted_by"`
+		ApprovedAt   string `json:"approved_at"`
+		ApprovedBy   string `json:"approved_by"`
+		DispatchedAt string `json:"dispatched_at"`
+		DispatchedBy string `json:"dispatched_by"`
+		ReturnedAt   string `json:"returned_at"`
+		ReturnedBy   string `json:"returned_by"`
+		SettledAt    string `json:"settled_at"`
+		SettledBy    string `json:"settled_by"`
+	}
+
 	LoadingResponse struct {
-		ID         string       `json:"id"`
-		IdUser     string       `json:"id_user"`
-		User       UserResponse `json:"user"`
-		IsApproved bool         `json:"is_approved"`
+		ID        string       `json:"id"`
+		NoLoading string       `json:"no_loading"`
+		IdUser    string       `json:"id_user"`
+		User      UserResponse `json:"user"`
+		Status    string       `json:"status"`
+		LoadingStatusResponse
 	}
 
 	LoadingPaginationResponse struct {
@@ -30,14 +54,16 @@ type (
 	}
 
 	LoadingUpdateRequest struct {
-		ID         string `json:"id" form:"id"`
-		IsApproved bool   `json:"is_approved" form:"is_approved"`
+		ID     string `json:"id" form:"id"`
+		IdUser string `json:"id_user" form:"id_user"`
 	}
 
 	LoadingUpdateResponse struct {
-		ID         string       `json:"id"`
-		IdUser     string       `json:"id_user"`
-		User       UserResponse `json:"user"`
-		IsApproved bool         `json:"is_approved"`
+		ID        string       `json:"id"`
+		NoLoading string       `json:"no_loading"`
+		IdUser    string       `json:"id_user"`
+		User      UserResponse `json:"user"`
+		Status    string       `json:"status"`
+		LoadingStatusResponse
 	}
 )
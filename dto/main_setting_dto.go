@@ -7,12 +7,67 @@ import (
 )
 
 type (
+	// NomorDokumenSetting holds the configurable document numbering of a business.
+	NomorDokumenSetting struct {
+		PrefixFaktur     string `json:"prefix_faktur" form:"prefix_faktur"`
+		PrefixLoading    string `json:"prefix_loading" form:"prefix_loading"`
+		PrefixRetur      string `json:"prefix_retur" form:"prefix_retur"`
+		PrefixNotaKredit string `json:"prefix_nota_kredit" form:"prefix_nota_kredit"`
+		PrefixPenjualan  string `json:"prefix_penjualan" form:"prefix_penjualan"`
+		PrefixPo         string `json:"prefix_po" form:"prefix_po"`
+		PrefixPenerimaan string `json:"prefix_penerimaan" form:"prefix_penerimaan"`
+		FormatNomor      string `json:"format_nomor" form:"format_nomor"`
+		PanjangNomor     int    `json:"panjang_nomor" form:"panjang_nomor"`
+		ResetNomor       string `json:"reset_nomor" form:"reset_nomor"`
+	}
+
+	// PajakSetting holds the PPN and pricing configuration of a business. A
+	// TarifPpn of 0 turns PPN off.
+	PajakSetting struct {
+		Npwp             string  `json:"npwp" form:"npwp"`
+		TarifPpn         float64 `json:"tarif_ppn" form:"tarif_ppn"`
+		HargaTermasukPpn bool    `json:"harga_termasuk_ppn" form:"harga_termasuk_ppn"`
+		UrutanDiskon     string  `json:"urutan_diskon" form:"urutan_diskon"`
+	}
+
+	MainSettingPajakRequest struct {
+		ID string `json:"id" form:"id"`
+		PajakSetting
+	}
+
+	// QrisSetting holds the static QRIS payload the merchant got from its
+	// acquirer. An empty payload turns QRIS off.
+	QrisSetting struct {
+		QrisStatis string `json:"qris_statis" form:"qris_statis"`
+	}
+
+	MainSettingQrisRequest struct {
+		ID string `json:"id" form:"id"`
+		QrisSetting
+	}
+
+	// QrisRequest asks for the QRIS of one payment; without a Jumlah the
+	// static QRIS is returned.
+	QrisRequest struct {
+		Jumlah    int    `query:"jumlah" form:"jumlah"`
+		Referensi string `query:"referensi" form:"referensi"`
+	}
+
+	QrisResponse struct {
+		Jumlah    int    `json:"jumlah"`
+		Referensi string `json:"referensi"`
+		Payload   string `json:"payload"`
+	}
+
 	MainSettingCreateRequest struct {
 		NamaUsaha  string                `json:"nama_usaha" form:"nama_usaha"`
 		JenisUsaha string                `json:"jenis_usaha" form:"jenis_usaha"`
 		Alamat     string                `json:"alamat" form:"alamat"`
 		Logo       *multipart.FileHeader `json:"logo" form:"logo"`
 		Hp         string                `json:"hp" form:"hp"`
+		NomorDokumenSetting
+		PajakSetting
+		QrisSetting
 	}
 	GetMainSettingByIdRequest struct {
 		ID string `json:"id" form:"id"`
@@ -25,6 +80,9 @@ type (
 		Alamat     string `json:"alamat"`
 		LogoUrl    string `json:"logo" form:"logo"`
 		Hp         string `json:"hp"`
+		NomorDokumenSetting
+		PajakSetting
+		QrisSetting
 	}
 
 	MainSettingPaginationResponse struct {
@@ -44,6 +102,7 @@ type (
 		Alamat     string                `json:"alamat" form:"alamat"`
 		Logo       *multipart.FileHeader `json:"logo" form:"logo"`
 		Hp         string                `json:"hp" form:"hp"`
+		NomorDokumenSetting
 	}
 
 	MainSettingUpdateResponse struct {
@@ -53,5 +112,8 @@ type (
 		Alamat     string `json:"alamat"`
 		Logo       string `json:"logo" form:"logo"`
 		Hp         string `json:"hp"`
+		NomorDokumenSetting
+		PajakSetting
+		QrisSetting
 	}
 )
@@ -50,6 +50,10 @@ var (
 	ErrTokenExpired           = errors.New("token expired")
 	ErrAccountAlreadyVerified = errors.New("account already verified")
 
+	// Pagination Error
+	ErrInvalidSort      = errors.New("sorting by this column is not allowed")
+	ErrInvalidSortOrder = errors.New("invalid sort order, use asc or desc")
+
 	// Satuan Error
 	ErrCreateSatuan   = errors.New("failed to create satuan")
 	ErrGetSatuanById  = errors.New("failed to get satuan by id")
@@ -58,18 +62,35 @@ var (
 	ErrDeleteSatuan   = errors.New("failed to delete satuan")
 
 	// Barang Error
-	ErrCreateBarang   = errors.New("failed to create barang")
-	ErrGetBarangById  = errors.New("failed to get barang by id")
-	ErrUpdateBarang   = errors.New("failed to update barang")
-	ErrBarangNotFound = errors.New("data not found")
-	ErrDeleteBarang   = errors.New("failed to delete barang")
+	ErrCreateBarang        = errors.New("failed to create barang")
+	ErrGetBarangById       = errors.New("failed to get barang by id")
+	ErrGetBarangByKode     = errors.New("failed to get barang by kode")
+	ErrUpdateBarang        = errors.New("failed to update barang")
+	ErrBarangNotFound      = errors.New("data not found")
+	ErrDeleteBarang        = errors.New("failed to delete barang")
+	ErrStokTidakCukup      = errors.New("insufficient stock")
+	ErrOpnameInvalid       = errors.New("counted quantity must not be negative")
+	ErrGetKartuStok        = errors.New("failed to get kartu stok")
+	ErrSatuanBarangInvalid = errors.New("every unit needs a unique name and a positive isi")
 
 	// Loading Error
-	ErrCreateLoading   = errors.New("failed to create loading")
-	ErrGetLoadingById  = errors.New("failed to get loading by id")
-	ErrUpdateLoading   = errors.New("failed to update loading")
-	ErrLoadingNotFound = errors.New("data not found")
-	ErrDeleteLoading   = errors.New("failed to delete loading")
+	ErrCreateLoading            = errors.New("failed to create loading")
+	ErrGetLoadingById           = errors.New("failed to get loading by id")
+	ErrUpdateLoading            = errors.New("failed to update loading")
+	ErrLoadingNotFound          = errors.New("data not found")
+	ErrDeleteLoading            = errors.New("failed to delete loading")
+	ErrLoadingLocked            = errors.New("loading already dispatched, its lines can no longer be changed")
+	ErrLoadingNotEditable       = errors.New("loading can no longer be changed")
+	ErrLoadingInvalidTransition = errors.New("invalid loading status transition")
+	ErrLoadingNotOwned          = errors.New("loading belongs to another user")
+	// Setoran Error
+	ErrCreateSetoran          = errors.New("failed to create setoran")
+	ErrSetoranNotFound        = errors.New("data not found")
+	ErrSetoranJumlahInvalid   = errors.New("returned quantity cannot be negative")
+	ErrSetoranBukanMuatan     = errors.New("returned barang was not on the loading")
+	ErrSetoranKembaliMelebihi = errors.New("returned quantity exceeds what was loaded and not sold")
+	ErrSetoranTerjualMelebihi = errors.New("invoiced quantity exceeds what was loaded")
+	ErrCetakSetoran           = errors.New("failed to print setoran")
 	// Transaksi Error
 	ErrCreateTransaksi   = errors.New("failed to create transaksi")
 	ErrGetTransaksiById  = errors.New("failed to get transaksi by id")
@@ -82,10 +103,129 @@ var (
 	ErrUpdateCustomer   = errors.New("failed to update customer")
 	ErrCustomerNotFound = errors.New("data not found")
 	ErrDeleteCustomer   = errors.New("failed to delete customer")
+	ErrKreditInvalid    = errors.New("credit limit and max overdue days cannot be negative")
+	ErrLimitKredit      = errors.New("customer credit limit exceeded")
+	ErrTempoTerlampaui  = errors.New("customer has invoices overdue beyond the allowed days")
+	// Override Kredit Error
+	ErrCreateOverrideKredit   = errors.New("failed to create credit override")
+	ErrGetOverrideKredit      = errors.New("failed to get credit override")
+	ErrAlasanOverrideRequired = errors.New("a reason is required to override the credit check")
 	// MainSetting Error
 	ErrCreateMainSetting   = errors.New("failed to create main settings")
 	ErrGetMainSettingById  = errors.New("failed to get main settings by id")
 	ErrUpdateMainSetting   = errors.New("failed to update main settings")
 	ErrMainSettingNotFound = errors.New("data not found")
 	ErrDeleteMainSetting   = errors.New("failed to delete main settings")
+	ErrInvalidTarifPpn     = errors.New("PPN rate must be at least 0 and below 100 percent")
+	ErrInvalidUrutanDiskon = errors.New("invalid urutan diskon, use persen_nominal or nominal_persen")
+	ErrQrisBelumDiatur     = errors.New("the merchant QRIS is not set")
+	ErrBuatQris            = errors.New("failed to generate QRIS")
+	// Faktur Error
+	ErrCreateFaktur        = errors.New("failed to create faktur")
+	ErrGetFakturById       = errors.New("failed to get faktur by id")
+	ErrUpdateFaktur        = errors.New("failed to update faktur")
+	ErrFakturNotFound      = errors.New("data not found")
+	ErrCancelFaktur        = errors.New("failed to cancel faktur")
+	ErrFakturItemsEmpty    = errors.New("faktur must have at least one item")
+	ErrFakturNotEditable   = errors.New("faktur can no longer be changed")
+	ErrFakturAdaRetur      = errors.New("faktur has returned goods and can no longer be changed")
+	ErrAlasanBatalRequired = errors.New("a reason is required to cancel a faktur")
+	ErrInvalidDateFormat   = errors.New("invalid date format, use YYYY-MM-DD")
+	ErrInvalidCaraBayar    = errors.New("invalid cara bayar")
+	ErrFakturBatal         = errors.New("faktur has been cancelled")
+	ErrFakturLunas         = errors.New("faktur is already paid in full")
+	ErrFakturNotOwned      = errors.New("faktur belongs to another user")
+	ErrFakturLoadingStatus = errors.New("goods can only be invoiced from a dispatched loading")
+	ErrFakturMelebihiMuat  = errors.New("invoiced quantity exceeds what is left on the loading")
+	ErrCetakFaktur         = errors.New("failed to print faktur")
+	ErrInvalidUkuranKertas = errors.New("invalid paper size, use A4 or A5")
+	// Struk Error
+	ErrCetakStruk         = errors.New("failed to print receipt")
+	ErrInvalidFormatStruk = errors.New("invalid receipt format, use escpos or teks")
+	ErrInvalidKolomStruk  = errors.New("invalid receipt width, use 32 or 48 columns")
+	// Pembayaran Error
+	ErrCreatePembayaran        = errors.New("failed to create pembayaran")
+	ErrGetPembayaran           = errors.New("failed to get pembayaran")
+	ErrPembayaranNotFound      = errors.New("data not found")
+	ErrDeletePembayaran        = errors.New("failed to delete pembayaran")
+	ErrPembayaranJumlahInvalid = errors.New("payment amount must be greater than zero")
+	ErrPembayaranMelebihi      = errors.New("payment exceeds the outstanding amount")
+	ErrInvalidMetodeBayar      = errors.New("invalid metode bayar, use tunai, transfer, qris or giro")
+	ErrBuktiBayarRequired      = errors.New("payment proof is required")
+	ErrBuktiBayarInvalid       = errors.New("payment proof must be a jpg, png or pdf file")
+	ErrReviewPembayaran        = errors.New("failed to review pembayaran")
+	ErrPembayaranSudahDireview = errors.New("payment has already been reviewed")
+	ErrPembayaranPending       = errors.New("faktur has payments waiting for review")
+	ErrSaldoKreditKurang       = errors.New("customer credit balance is not enough")
+	ErrPakaiKredit             = errors.New("failed to apply customer credit")
+	ErrKreditTerpakai          = errors.New("the credit from this payment has already been spent")
+	// Gateway Error
+	ErrNotifikasiGateway   = errors.New("failed to process gateway notification")
+	ErrGetTransaksiGateway = errors.New("failed to get gateway transactions")
+	ErrSignatureGateway    = errors.New("invalid gateway signature")
+	ErrOrderIdGateway      = errors.New("order id does not refer to a faktur")
+	ErrStatusGateway       = errors.New("failed to check the transaction status with the gateway")
+	ErrAlasanTolakRequired = errors.New("a reason is required to reject a payment")
+	// Retur Error
+	ErrCreateRetur         = errors.New("failed to create retur")
+	ErrGetRetur            = errors.New("failed to get retur")
+	ErrReturItemsEmpty     = errors.New("retur must have at least one item")
+	ErrInvalidKondisiRetur = errors.New("invalid kondisi, use baik or rusak")
+	ErrReturJumlahInvalid  = errors.New("returned quantity must be greater than zero")
+	ErrReturMelebihi       = errors.New("returned quantity exceeds what was sold")
+	// Shift Kasir Error
+	ErrBukaShift          = errors.New("failed to open shift")
+	ErrGetShift           = errors.New("failed to get shift")
+	ErrTutupShift         = errors.New("failed to close shift")
+	ErrCreateKasShift     = errors.New("failed to record cash in or out")
+	ErrShiftMasihBuka     = errors.New("cashier already has an open shift")
+	ErrShiftTidakAktif    = errors.New("cashier has no open shift")
+	ErrModalAwalInvalid   = errors.New("opening float must not be negative")
+	ErrKasDihitungInvalid = errors.New("counted cash must not be negative")
+	ErrInvalidJenisKas    = errors.New("invalid jenis, use masuk or keluar")
+	ErrKasJumlahInvalid   = errors.New("cash amount must be greater than zero")
+	ErrKasTidakCukup      = errors.New("not enough cash in the drawer")
+	// Penjualan Error
+	ErrCreatePenjualan        = errors.New("failed to create penjualan")
+	ErrGetPenjualan           = errors.New("failed to get penjualan")
+	ErrPenjualanItemsEmpty    = errors.New("penjualan must have at least one item")
+	ErrPenjualanJumlahInvalid = errors.New("sold quantity must be greater than zero")
+	ErrInvalidMetodePenjualan = errors.New("invalid metode bayar, use tunai, transfer or qris")
+	ErrBayarKurang            = errors.New("payment is less than the total")
+	ErrPembayaranInvalid      = errors.New("payment amount must not be negative")
+	ErrPembayaranSisaGanda    = errors.New("only one non-cash payment can be left without an amount")
+	ErrNonTunaiMelebihi       = errors.New("non-cash payments are more than the total")
+	// Supplier Error
+	ErrCreateSupplier   = errors.New("failed to create supplier")
+	ErrGetSupplierById  = errors.New("failed to get supplier by id")
+	ErrUpdateSupplier   = errors.New("failed to update supplier")
+	ErrSupplierNotFound = errors.New("data not found")
+	ErrDeleteSupplier   = errors.New("failed to delete supplier")
+	ErrSupplierAdaPo    = errors.New("supplier still has purchase orders that are not closed")
+	// Purchase Order Error
+	ErrCreatePurchaseOrder        = errors.New("failed to create purchase order")
+	ErrGetPurchaseOrder           = errors.New("failed to get purchase order")
+	ErrTutupPurchaseOrder         = errors.New("failed to close purchase order")
+	ErrPurchaseOrderItemsEmpty    = errors.New("purchase order must have at least one item")
+	ErrPurchaseOrderJumlahInvalid = errors.New("ordered quantity must be greater than zero")
+	ErrHargaBeliInvalid           = errors.New("purchase price must not be negative")
+	ErrPurchaseOrderClosed        = errors.New("purchase order is already closed")
+	ErrInvalidStatusPo            = errors.New("invalid status, use open, partial or closed")
+	ErrAlasanTutupRequired        = errors.New("a reason is required to close a purchase order")
+	// Penerimaan Barang Error
+	ErrCreatePenerimaan        = errors.New("failed to create penerimaan barang")
+	ErrGetPenerimaan           = errors.New("failed to get penerimaan barang")
+	ErrPenerimaanItemsEmpty    = errors.New("penerimaan barang must have at least one item")
+	ErrPenerimaanJumlahInvalid = errors.New("received quantity must be greater than zero")
+	ErrPenerimaanMelebihi      = errors.New("received quantity exceeds what is still open on the order")
+	// Laporan Error
+	ErrGetLaporan           = errors.New("failed to get laporan")
+	ErrExportLaporan        = errors.New("failed to export laporan")
+	ErrInvalidGroupLaporan  = errors.New("invalid group, use customer or sales")
+	ErrInvalidFormatLaporan = errors.New("invalid format, use json, csv or xlsx")
+	ErrInvalidBulan         = errors.New("invalid month format, use YYYY-MM")
+	// Nomor Dokumen Error
+	ErrInvalidJenisDokumen = errors.New("invalid document type")
+	ErrInvalidResetNomor   = errors.New("invalid reset nomor, use bulanan, tahunan or tidak")
+	ErrInvalidFormatNomor  = errors.New("format nomor needs {SEQ} and the {YYYY} or {YY} year, plus {MM} when numbers reset monthly")
 )
@@ -0,0 +1,13 @@
+package dto
+
+type (
+	NomorDokumenPreviewRequest struct {
+		Jenis   string `query:"jenis" form:"jenis"`
+		Tanggal string `query:"tanggal" form:"tanggal"`
+	}
+
+	NomorDokumenResponse struct {
+		Jenis string `json:"jenis"`
+		Nomor string `json:"nomor"`
+	}
+)
@@ -2,9 +2,12 @@ package dto
 
 type (
 	PaginationRequest struct {
-		Search  string `form:"search"`
-		Page    int    `form:"page"`
-		PerPage int    `form:"per_page"`
+		Search  string `query:"search" form:"search"`
+		Page    int    `query:"page" form:"page"`
+		PerPage int    `query:"per_page" form:"per_page"`
+		Sort    string `query:"sort" form:"sort"`
+		Order   string `query:"order" form:"order"`
+		Draw    int    `query:"draw" form:"draw"`
 	}
 
 	PaginationResponse struct {
@@ -0,0 +1,90 @@
+package dto
+
+import (
+	"mime/multipart"
+)
+
+type (
+	PembayaranCreateRequest struct {
+		IdFaktur     string `json:"id_faktur" form:"id_faktur"`
+		Jumlah       int    `json:"jumlah" form:"jumlah"`
+		Metode       string `json:"metode" form:"metode"`
+		TanggalBayar string `json:"tanggal_bayar" form:"tanggal_bayar"`
+		Referensi    string `json:"referensi" form:"referensi"`
+		Keterangan   string `json:"keterangan" form:"keterangan"`
+		// SimpanKredit keeps an overpayment as customer credit instead of
+		// rejecting the payment
+		SimpanKredit bool                  `json:"simpan_kredit" form:"simpan_kredit"`
+		BuktiBayar   *multipart.FileHeader `json:"bukti_bayar" form:"bukti_bayar"`
+	}
+
+	PembayaranReviewRequest struct {
+		ID     string `json:"id" form:"id"`
+		Alasan string `json:"alasan" form:"alasan"`
+	}
+
+	GetPembayaranByIdRequest struct {
+		ID string `json:"id" form:"id"`
+	}
+
+	GetPembayaranByFakturRequest struct {
+		IdFaktur string `query:"id_faktur" form:"id_faktur"`
+	}
+
+	// PakaiKreditRequest applies customer credit to a faktur, all that the
+	// balance and the faktur allow when Jumlah is 0.
+	PakaiKreditRequest struct {
+		IdFaktur string `json:"id_faktur" form:"id_faktur"`
+		Jumlah   int    `json:"jumlah" form:"jumlah"`
+	}
+
+	GetKreditCustomerRequest struct {
+		IdCustomer string `query:"id_customer" form:"id_customer"`
+	}
+
+	PembayaranResponse struct {
+		ID            string       `json:"id"`
+		IdFaktur      string       `json:"id_faktur"`
+		NoFaktur      string       `json:"no_faktur"`
+		Jumlah        int          `json:"jumlah"`
+		Kelebihan     int          `json:"kelebihan"`
+		Metode        string       `json:"metode"`
+		TanggalBayar  string       `json:"tanggal_bayar"`
+		Referensi     string       `json:"referensi"`
+		IdUser        string       `json:"id_user"`
+		Penagih       UserResponse `json:"penagih"`
+		Keterangan    string       `json:"keterangan"`
+		BuktiBayar    string       `json:"bukti_bayar"`
+		StatusBukti   string       `json:"status_bukti"`
+		AlasanTolak   string       `json:"alasan_tolak"`
+		IdReviewer    string       `json:"id_reviewer"`
+		TanggalReview string       `json:"tanggal_review"`
+		StatusFaktur  string       `json:"status_faktur"`
+		SisaTagihan   int          `json:"sisa_tagihan"`
+	}
+
+	KreditCustomerResponse struct {
+		ID           string `json:"id"`
+		IdPembayaran string `json:"id_pembayaran"`
+		IdNotaKredit string `json:"id_nota_kredit"`
+		IdFaktur     string `json:"id_faktur"`
+		Jumlah       int    `json:"jumlah"`
+		Keterangan   string `json:"keterangan"`
+		Tanggal      string `json:"tanggal"`
+	}
+
+	PakaiKreditResponse struct {
+		IdFaktur     string `json:"id_faktur"`
+		NoFaktur     string `json:"no_faktur"`
+		Dipakai      int    `json:"dipakai"`
+		StatusFaktur string `json:"status_faktur"`
+		SisaTagihan  int    `json:"sisa_tagihan"`
+		SaldoKredit  int    `json:"saldo_kredit"`
+	}
+
+	SaldoKreditResponse struct {
+		IdCustomer string                   `json:"id_customer"`
+		Saldo      int                      `json:"saldo"`
+		Mutasi     []KreditCustomerResponse `json:"mutasi"`
+	}
+)
@@ -0,0 +1,111 @@
+package dto
+
+import "github.com/jejevj/ykp_pos/entity"
+
+type (
+	TransaksiPenjualanRequest struct {
+		IdBarang string                `json:"id_barang" form:"id_barang"`
+		Krat     int                   `json:"krat" form:"krat"`
+		Lusin    int                   `json:"lusin" form:"lusin"`
+		Satuan   int                   `json:"satuan" form:"satuan"`
+		Rincian  []JumlahSatuanRequest `json:"rincian" form:"rincian"`
+		Diskon   int                   `json:"diskon" form:"diskon"`
+		DiskonP  float32               `json:"diskon_p" form:"diskon_p"`
+	}
+
+	// PembayaranPenjualanRequest is one tender. A non-cash tender without a
+	// Jumlah takes whatever the other tenders leave of the total.
+	PembayaranPenjualanRequest struct {
+		Metode    string `json:"metode" form:"metode"`
+		Jumlah    int    `json:"jumlah" form:"jumlah"`
+		Referensi string `json:"referensi" form:"referensi"`
+	}
+
+	// PenjualanCreateRequest is the cart of a counter sale, paid with the
+	// tenders in Pembayaran. Without them the sale is paid with a single
+	// tender of MetodeBayar for Bayar.
+	PenjualanCreateRequest struct {
+		MetodeBayar string                       `json:"metode_bayar" form:"metode_bayar"`
+		Bayar       int                          `json:"bayar" form:"bayar"`
+		Pembayaran  []PembayaranPenjualanRequest `json:"pembayaran" form:"pembayaran"`
+		Diskon      int                          `json:"diskon" form:"diskon"`
+		DiskonP     float32                      `json:"diskon_p" form:"diskon_p"`
+		Keterangan  string                       `json:"keterangan" form:"keterangan"`
+		Items       []TransaksiPenjualanRequest  `json:"items" form:"items"`
+	}
+
+	GetPenjualanByIdRequest struct {
+		ID string `json:"id" form:"id"`
+	}
+
+	PenjualanFilterRequest struct {
+		PaginationRequest
+		IdShift      string `query:"id_shift" form:"id_shift"`
+		IdUser       string `query:"id_user" form:"id_user"`
+		MetodeBayar  string `query:"metode_bayar" form:"metode_bayar"`
+		TanggalMulai string `query:"tanggal_mulai" form:"tanggal_mulai"`
+		TanggalAkhir string `query:"tanggal_akhir" form:"tanggal_akhir"`
+	}
+
+	TransaksiPenjualanResponse struct {
+		ID           string         `json:"id"`
+		IdBarang     string         `json:"id_barang"`
+		Barang       BarangResponse `json:"barang"`
+		Krat         int            `json:"krat"`
+		Lusin        int            `json:"lusin"`
+		Satuan       int            `json:"satuan"`
+		Jumlah       int            `json:"jumlah"`
+		JumlahFormat string         `json:"jumlah_format"`
+		Harga        int            `json:"harga"`
+		Diskon       int            `json:"diskon"`
+		DiskonP      float32        `json:"diskon_p"`
+		JumlahRP     int            `json:"jumlah_rp"`
+		DiskonFaktur int            `json:"diskon_faktur"`
+		Dpp          int            `json:"dpp"`
+		Ppn          int            `json:"ppn"`
+	}
+
+	// PembayaranPenjualanResponse is a tender of a sale. Qris is the payload to
+	// show for a QRIS tender while the sale is being paid.
+	PembayaranPenjualanResponse struct {
+		ID        string `json:"id"`
+		Metode    string `json:"metode"`
+		Jumlah    int    `json:"jumlah"`
+		Referensi string `json:"referensi"`
+		Qris      string `json:"qris,omitempty"`
+	}
+
+	PenjualanResponse struct {
+		ID             string                        `json:"id"`
+		NoPenjualan    string                        `json:"no_penjualan"`
+		Tanggal        string                        `json:"tanggal"`
+		IdShift        string                        `json:"id_shift"`
+		IdUser         string                        `json:"id_user"`
+		Kasir          UserResponse                  `json:"kasir"`
+		MetodeBayar    string                        `json:"metode_bayar"`
+		Subtotal       int                           `json:"subtotal"`
+		Diskon         int                           `json:"diskon"`
+		DiskonP        float32                       `json:"diskon_p"`
+		PotonganDiskon int                           `json:"potongan_diskon"`
+		TarifPpn       float64                       `json:"tarif_ppn"`
+		Dpp            int                           `json:"dpp"`
+		Ppn            int                           `json:"ppn"`
+		Total          int                           `json:"total"`
+		Bayar          int                           `json:"bayar"`
+		Kembalian      int                           `json:"kembalian"`
+		Tunai          int                           `json:"tunai"`
+		Pembayaran     []PembayaranPenjualanResponse `json:"pembayaran"`
+		Keterangan     string                        `json:"keterangan"`
+		Items          []TransaksiPenjualanResponse  `json:"items"`
+	}
+
+	PenjualanPaginationResponse struct {
+		Data []PenjualanResponse `json:"data"`
+		PaginationResponse
+	}
+
+	GetAllPenjualanRepositoryResponse struct {
+		Penjualans []entity.Penjualan
+		PaginationResponse
+	}
+)
@@ -0,0 +1,136 @@
+package dto
+
+import "github.com/jejevj/ykp_pos/entity"
+
+type (
+	// TransaksiPurchaseOrderRequest is one ordered line. HargaBeli is the
+	// price of one piece; without it the current HargaBeli of the barang is
+	// used.
+	TransaksiPurchaseOrderRequest struct {
+		IdBarang  string                `json:"id_barang" form:"id_barang"`
+		Krat      int                   `json:"krat" form:"krat"`
+		Lusin     int                   `json:"lusin" form:"lusin"`
+		Satuan    int                   `json:"satuan" form:"satuan"`
+		Rincian   []JumlahSatuanRequest `json:"rincian" form:"rincian"`
+		HargaBeli int                   `json:"harga_beli" form:"harga_beli"`
+	}
+
+	PurchaseOrderCreateRequest struct {
+		IdSupplier string                          `json:"id_supplier" form:"id_supplier"`
+		Tanggal    string                          `json:"tanggal" form:"tanggal"`
+		Keterangan string                          `json:"keterangan" form:"keterangan"`
+		Items      []TransaksiPurchaseOrderRequest `json:"items" form:"items"`
+	}
+
+	GetPurchaseOrderByIdRequest struct {
+		ID string `json:"id" form:"id"`
+	}
+
+	PurchaseOrderFilterRequest struct {
+		PaginationRequest
+		IdSupplier   string `query:"id_supplier" form:"id_supplier"`
+		Status       string `query:"status" form:"status"`
+		TanggalMulai string `query:"tanggal_mulai" form:"tanggal_mulai"`
+		TanggalAkhir string `query:"tanggal_akhir" form:"tanggal_akhir"`
+	}
+
+	// TutupPurchaseOrderRequest closes an order whose remaining goods will
+	// not be delivered.
+	TutupPurchaseOrderRequest struct {
+		ID     string `json:"id" form:"id"`
+		Alasan string `json:"alasan" form:"alasan"`
+	}
+
+	// TransaksiPenerimaanRequest is the quantity received on one purchase
+	// order line. HargaBeli overrides the ordered price when the supplier
+	// invoiced a different one.
+	TransaksiPenerimaanRequest struct {
+		IdTransaksiPo string                `json:"id_transaksi_po" form:"id_transaksi_po"`
+		Krat          int                   `json:"krat" form:"krat"`
+		Lusin         int                   `json:"lusin" form:"lusin"`
+		Satuan        int                   `json:"satuan" form:"satuan"`
+		Rincian       []JumlahSatuanRequest `json:"rincian" form:"rincian"`
+		HargaBeli     int                   `json:"harga_beli" form:"harga_beli"`
+	}
+
+	PenerimaanBarangCreateRequest struct {
+		IdPurchaseOrder string                       `json:"id_purchase_order" form:"id_purchase_order"`
+		Tanggal         string                       `json:"tanggal" form:"tanggal"`
+		Keterangan      string                       `json:"keterangan" form:"keterangan"`
+		Items           []TransaksiPenerimaanRequest `json:"items" form:"items"`
+	}
+
+	GetPenerimaanByIdRequest struct {
+		ID string `json:"id" form:"id"`
+	}
+
+	TransaksiPurchaseOrderResponse struct {
+		ID             string         `json:"id"`
+		IdBarang       string         `json:"id_barang"`
+		Barang         BarangResponse `json:"barang"`
+		Krat           int            `json:"krat"`
+		Lusin          int            `json:"lusin"`
+		Satuan         int            `json:"satuan"`
+		Jumlah         int            `json:"jumlah"`
+		JumlahFormat   string         `json:"jumlah_format"`
+		HargaBeli      int            `json:"harga_beli"`
+		JumlahRP       int            `json:"jumlah_rp"`
+		Diterima       int            `json:"diterima"`
+		DiterimaFormat string         `json:"diterima_format"`
+		Sisa           int            `json:"sisa"`
+	}
+
+	TransaksiPenerimaanResponse struct {
+		ID            string         `json:"id"`
+		IdTransaksiPo string         `json:"id_transaksi_po"`
+		IdBarang      string         `json:"id_barang"`
+		Barang        BarangResponse `json:"barang"`
+		Krat          int            `json:"krat"`
+		Lusin         int            `json:"lusin"`
+		Satuan        int            `json:"satuan"`
+		Jumlah        int            `json:"jumlah"`
+		JumlahFormat  string         `json:"jumlah_format"`
+		HargaBeli     int            `json:"harga_beli"`
+		JumlahRP      int            `json:"jumlah_rp"`
+	}
+
+	PenerimaanBarangResponse struct {
+		ID              string                        `json:"id"`
+		NoPenerimaan    string                        `json:"no_penerimaan"`
+		Tanggal         string                        `json:"tanggal"`
+		IdPurchaseOrder string                        `json:"id_purchase_order"`
+		NoPo            string                        `json:"no_po"`
+		StatusPo        string                        `json:"status_po"`
+		IdUser          string                        `json:"id_user"`
+		User            UserResponse                  `json:"user"`
+		Total           int                           `json:"total"`
+		Keterangan      string                        `json:"keterangan"`
+		Items           []TransaksiPenerimaanResponse `json:"items"`
+	}
+
+	PurchaseOrderResponse struct {
+		ID          string                           `json:"id"`
+		NoPo        string                           `json:"no_po"`
+		Tanggal     string                           `json:"tanggal"`
+		IdSupplier  string                           `json:"id_supplier"`
+		Supplier    SupplierResponse                 `json:"supplier"`
+		IdUser      string                           `json:"id_user"`
+		User        UserResponse                     `json:"user"`
+		Status      string                           `json:"status"`
+		Total       int                              `json:"total"`
+		Keterangan  string                           `json:"keterangan"`
+		AlasanTutup string                           `json:"alasan_tutup"`
+		Items       []TransaksiPurchaseOrderResponse `json:"items"`
+		Penerimaans []PenerimaanBarangResponse       `json:"penerimaans"`
+	}
+
+	PurchaseOrderPaginationResponse struct {
+		Data []PurchaseOrderResponse `json:"data"`
+		PaginationResponse
+	}
+
+	GetAllPurchaseOrderRepositoryResponse struct {
+		PurchaseOrders []entity.PurchaseOrder
+		PaginationResponse
+	}
+)
@@ -0,0 +1,77 @@
+package dto
+
+type (
+	TransaksiReturRequest struct {
+		IdTransaksiFaktur string                `json:"id_transaksi_faktur" form:"id_transaksi_faktur"`
+		Krat              int                   `json:"krat" form:"krat"`
+		Lusin             int                   `json:"lusin" form:"lusin"`
+		Satuan            int                   `json:"satuan" form:"satuan"`
+		Rincian           []JumlahSatuanRequest `json:"rincian" form:"rincian"`
+		Kondisi           string                `json:"kondisi" form:"kondisi"`
+		Ket               string                `json:"keterangan" form:"keterangan"`
+	}
+
+	ReturCreateRequest struct {
+		IdFaktur   string                  `json:"id_faktur" form:"id_faktur"`
+		Tanggal    string                  `json:"tanggal" form:"tanggal"`
+		Keterangan string                  `json:"keterangan" form:"keterangan"`
+		Items      []TransaksiReturRequest `json:"items" form:"items"`
+		// KeKredit keeps the whole credit note as customer credit instead of
+		// taking it off the open balance of the faktur first
+		KeKredit bool `json:"ke_kredit" form:"ke_kredit"`
+	}
+
+	GetReturByIdRequest struct {
+		ID string `json:"id" form:"id"`
+	}
+
+	GetReturByFakturRequest struct {
+		IdFaktur string `query:"id_faktur" form:"id_faktur"`
+	}
+
+	TransaksiReturResponse struct {
+		ID                string         `json:"id"`
+		IdTransaksiFaktur string         `json:"id_transaksi_faktur"`
+		IdBarang          string         `json:"id_barang"`
+		Barang            BarangResponse `json:"barang"`
+		Krat              int            `json:"krat"`
+		Lusin             int            `json:"lusin"`
+		Satuan            int            `json:"satuan"`
+		Jumlah            int            `json:"jumlah"`
+		JumlahFormat      string         `json:"jumlah_format"`
+		Kondisi           string         `json:"kondisi"`
+		JumlahRP          int            `json:"jumlah_rp"`
+		Dpp               int            `json:"dpp"`
+		Ppn               int            `json:"ppn"`
+		Ket               string         `json:"keterangan"`
+	}
+
+	NotaKreditResponse struct {
+		ID           string `json:"id"`
+		NoNotaKredit string `json:"no_nota_kredit"`
+		Tanggal      string `json:"tanggal"`
+		Dpp          int    `json:"dpp"`
+		Ppn          int    `json:"ppn"`
+		Total        int    `json:"total"`
+		PotongFaktur int    `json:"potong_faktur"`
+		Kredit       int    `json:"kredit"`
+	}
+
+	ReturResponse struct {
+		ID           string                   `json:"id"`
+		NoRetur      string                   `json:"no_retur"`
+		Tanggal      string                   `json:"tanggal"`
+		IdFaktur     string                   `json:"id_faktur"`
+		NoFaktur     string                   `json:"no_faktur"`
+		IdCustomer   string                   `json:"id_customer"`
+		NamaCustomer string                   `json:"nama_customer"`
+		IdUser       string                   `json:"id_user"`
+		User         UserResponse             `json:"user"`
+		Total        int                      `json:"total"`
+		Keterangan   string                   `json:"keterangan"`
+		StatusFaktur string                   `json:"status_faktur"`
+		SisaTagihan  int                      `json:"sisa_tagihan"`
+		NotaKredit   NotaKreditResponse       `json:"nota_kredit"`
+		Items        []TransaksiReturResponse `json:"items"`
+	}
+)
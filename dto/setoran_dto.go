@@ -0,0 +1,63 @@
+package dto
+
+type (
+	SetoranKembaliRequest struct {
+		IdBarang      string                `json:"id_barang" form:"id_barang"`
+		JumlahKembali int                   `json:"jumlah_kembali" form:"jumlah_kembali"`
+		Rincian       []JumlahSatuanRequest `json:"rincian" form:"rincian"`
+	}
+
+	SetoranCreateRequest struct {
+		IdLoading     string                  `json:"id_loading" form:"id_loading"`
+		TotalTunai    int                     `json:"total_tunai" form:"total_tunai"`
+		TotalTransfer int                     `json:"total_transfer" form:"total_transfer"`
+		Keterangan    string                  `json:"keterangan" form:"keterangan"`
+		Items         []SetoranKembaliRequest `json:"items" form:"items"`
+	}
+
+	GetSetoranByLoadingRequest struct {
+		IdLoading string `query:"id_loading" form:"id_loading"`
+	}
+
+	SetoranDetailResponse struct {
+		ID            string         `json:"id"`
+		IdBarang      string         `json:"id_barang"`
+		Barang        BarangResponse `json:"barang"`
+		JumlahMuat    int            `json:"jumlah_muat"`
+		JumlahTerjual int            `json:"jumlah_terjual"`
+		JumlahKembali int            `json:"jumlah_kembali"`
+		Selisih       int            `json:"selisih"`
+		MuatFormat    string         `json:"muat_format"`
+		TerjualFormat string         `json:"terjual_format"`
+		KembaliFormat string         `json:"kembali_format"`
+		SelisihFormat string         `json:"selisih_format"`
+	}
+
+	KekuranganDriverResponse struct {
+		ID         string `json:"id"`
+		IdUser     string `json:"id_user"`
+		Jenis      string `json:"jenis"`
+		IdBarang   string `json:"id_barang"`
+		Jumlah     int    `json:"jumlah"`
+		Nilai      int    `json:"nilai"`
+		Keterangan string `json:"keterangan"`
+	}
+
+	SetoranResponse struct {
+		ID            string                     `json:"id"`
+		IdLoading     string                     `json:"id_loading"`
+		NoLoading     string                     `json:"no_loading"`
+		IdUser        string                     `json:"id_user"`
+		Driver        UserResponse               `json:"driver"`
+		IdPetugas     string                     `json:"id_petugas"`
+		Tanggal       string                     `json:"tanggal"`
+		TotalTagihan  int                        `json:"total_tagihan"`
+		TotalTunai    int                        `json:"total_tunai"`
+		TotalTransfer int                        `json:"total_transfer"`
+		TotalSetor    int                        `json:"total_setor"`
+		SelisihUang   int                        `json:"selisih_uang"`
+		Keterangan    string                     `json:"keterangan"`
+		Items         []SetoranDetailResponse    `json:"items"`
+		Kekurangan    []KekuranganDriverResponse `json:"kekurangan"`
+	}
+)
@@ -0,0 +1,97 @@
+package dto
+
+import "github.com/jejevj/ykp_pos/entity"
+
+type (
+	ShiftBukaRequest struct {
+		ModalAwal  int    `json:"modal_awal" form:"modal_awal"`
+		Keterangan string `json:"keterangan" form:"keterangan"`
+	}
+
+	KasShiftRequest struct {
+		Jenis      string `json:"jenis" form:"jenis"`
+		Jumlah     int    `json:"jumlah" form:"jumlah"`
+		Keterangan string `json:"keterangan" form:"keterangan"`
+	}
+
+	ShiftTutupRequest struct {
+		KasDihitung int    `json:"kas_dihitung" form:"kas_dihitung"`
+		Keterangan  string `json:"keterangan" form:"keterangan"`
+	}
+
+	GetShiftByIdRequest struct {
+		ID string `json:"id" form:"id"`
+	}
+
+	ShiftFilterRequest struct {
+		PaginationRequest
+		IdUser       string `query:"id_user" form:"id_user"`
+		Status       string `query:"status" form:"status"`
+		TanggalMulai string `query:"tanggal_mulai" form:"tanggal_mulai"`
+		TanggalAkhir string `query:"tanggal_akhir" form:"tanggal_akhir"`
+	}
+
+	RekapShiftRequest struct {
+		IdUser       string `query:"id_user" form:"id_user"`
+		TanggalMulai string `query:"tanggal_mulai" form:"tanggal_mulai"`
+		TanggalAkhir string `query:"tanggal_akhir" form:"tanggal_akhir"`
+	}
+
+	KasShiftResponse struct {
+		ID         string `json:"id"`
+		Jenis      string `json:"jenis"`
+		Jumlah     int    `json:"jumlah"`
+		Waktu      string `json:"waktu"`
+		IdUser     string `json:"id_user"`
+		Keterangan string `json:"keterangan"`
+	}
+
+	// ShiftKasirResponse shows an open shift with its running totals and a
+	// closed one with the totals it was closed with.
+	ShiftKasirResponse struct {
+		ID              string             `json:"id"`
+		IdUser          string             `json:"id_user"`
+		Kasir           UserResponse       `json:"kasir"`
+		Status          string             `json:"status"`
+		WaktuBuka       string             `json:"waktu_buka"`
+		WaktuTutup      string             `json:"waktu_tutup"`
+		ModalAwal       int                `json:"modal_awal"`
+		JumlahPenjualan int                `json:"jumlah_penjualan"`
+		TotalPenjualan  int                `json:"total_penjualan"`
+		TotalTunai      int                `json:"total_tunai"`
+		KasMasuk        int                `json:"kas_masuk"`
+		KasKeluar       int                `json:"kas_keluar"`
+		KasDiharapkan   int                `json:"kas_diharapkan"`
+		KasDihitung     int                `json:"kas_dihitung"`
+		Selisih         int                `json:"selisih"`
+		Keterangan      string             `json:"keterangan"`
+		Kas             []KasShiftResponse `json:"kas"`
+	}
+
+	ShiftKasirPaginationResponse struct {
+		Data []ShiftKasirResponse `json:"data"`
+		PaginationResponse
+	}
+
+	GetAllShiftKasirRepositoryResponse struct {
+		Shifts []entity.ShiftKasir
+		PaginationResponse
+	}
+
+	// RekapShiftKasir adds up the closed shifts of one cashier. Kurang and
+	// Lebih are the shortages and overages counted separately, Selisih is
+	// their net.
+	RekapShiftKasir struct {
+		IdUser          string `json:"id_user"`
+		NamaKasir       string `json:"nama_kasir"`
+		JumlahShift     int    `json:"jumlah_shift"`
+		JumlahPenjualan int    `json:"jumlah_penjualan"`
+		TotalPenjualan  int    `json:"total_penjualan"`
+		TotalTunai      int    `json:"total_tunai"`
+		KasDiharapkan   int    `json:"kas_diharapkan"`
+		KasDihitung     int    `json:"kas_dihitung"`
+		Kurang          int    `json:"kurang"`
+		Lebih           int    `json:"lebih"`
+		Selisih         int    `json:"selisih"`
+	}
+)
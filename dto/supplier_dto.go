@@ -0,0 +1,46 @@
+package dto
+
+import (
+	"github.com/jejevj/ykp_pos/entity"
+)
+
+type (
+	SupplierCreateRequest struct {
+		NamaSupplier string `json:"nama_supplier" form:"nama_supplier"`
+		NamaKontak   string `json:"nama_kontak" form:"nama_kontak"`
+		Alamat       string `json:"alamat" form:"alamat"`
+		HP           string `json:"hp" form:"hp"`
+		Npwp         string `json:"npwp" form:"npwp"`
+	}
+	GetSupplierByIdRequest struct {
+		ID string `json:"id" form:"id"`
+	}
+
+	SupplierResponse struct {
+		ID           string `json:"id"`
+		NamaSupplier string `json:"nama_supplier"`
+		NamaKontak   string `json:"nama_kontak"`
+		Alamat       string `json:"alamat"`
+		HP           string `json:"hp"`
+		Npwp         string `json:"npwp"`
+	}
+
+	SupplierPaginationResponse struct {
+		Data []SupplierResponse `json:"data"`
+		PaginationResponse
+	}
+
+	GetAllSupplierRepositoryResponse struct {
+		Suppliers []entity.Supplier
+		PaginationResponse
+	}
+
+	SupplierUpdateRequest struct {
+		ID           string `json:"id" form:"id"`
+		NamaSupplier string `json:"nama_supplier" form:"nama_supplier"`
+		NamaKontak   string `json:"nama_kontak" form:"nama_kontak"`
+		Alamat       string `json:"alamat" form:"alamat"`
+		HP           string `json:"hp" form:"hp"`
+		Npwp         string `json:"npwp" form:"npwp"`
+	}
+)
@@ -6,20 +6,27 @@ import (
 
 type (
 	TransaksiCreateRequest struct {
-		IdLoading string `json:"id_loading" form:"id_loading"`
-		IdBarang  string `json:"id_barang" form:"id_barang"`
-		Jumlah    int    `json:"jumlah" form:"jumlah"`
+		IdLoading string                `json:"id_loading" form:"id_loading"`
+		IdBarang  string                `json:"id_barang" form:"id_barang"`
+		Jumlah    int                   `json:"jumlah" form:"jumlah"`
+		Rincian   []JumlahSatuanRequest `json:"rincian" form:"rincian"`
 	}
 	GetTransaksiByIdRequest struct {
 		ID string `json:"id" form:"id"`
 	}
+	TransaksiFilterRequest struct {
+		PaginationRequest
+		IdLoading string `query:"id_loading" form:"id_loading"`
+		IdUser    string `query:"id_user" form:"id_user"`
+	}
 	TransaksiResponse struct {
-		ID        string          `json:"id"`
-		IdLoading string          `json:"id_loading"`
-		Loading   LoadingResponse `json:"loading"`
-		IdBarang  string          `json:"id_barang"`
-		Barang    BarangResponse  `json:"barang"`
-		Jumlah    int             `json:"jumlah"`
+		ID           string          `json:"id"`
+		IdLoading    string          `json:"id_loading"`
+		Loading      LoadingResponse `json:"loading"`
+		IdBarang     string          `json:"id_barang"`
+		Barang       BarangResponse  `json:"barang"`
+		Jumlah       int             `json:"jumlah"`
+		JumlahFormat string          `json:"jumlah_format"`
 	}
 	TransaksiPaginationResponse struct {
 		Data []TransaksiResponse `json:"data"`
@@ -31,17 +38,19 @@ type (
 		PaginationResponse
 	}
 	TransaksiUpdateRequest struct {
-		ID        string `json:"id" form:"id"`
-		IdLoading string `json:"id_loading" form:"id_loading"`
-		IdBarang  string `json:"id_barang" form:"id_barang"`
-		Jumlah    int    `json:"jumlah" form:"jumlah"`
+		ID        string                `json:"id" form:"id"`
+		IdLoading string                `json:"id_loading" form:"id_loading"`
+		IdBarang  string                `json:"id_barang" form:"id_barang"`
+		Jumlah    int                   `json:"jumlah" form:"jumlah"`
+		Rincian   []JumlahSatuanRequest `json:"rincian" form:"rincian"`
 	}
 	TransaksiUpdateResponse struct {
-		ID        string          `json:"id"`
-		IdLoading string          `json:"id_loading"`
-		Loading   LoadingResponse `json:"loading"`
-		IdBarang  string          `json:"id_barang"`
-		Barang    BarangResponse  `json:"barang"`
-		Jumlah    int             `json:"jumlah"`
+		ID           string          `json:"id"`
+		IdLoading    string          `json:"id_loading"`
+		Loading      LoadingResponse `json:"loading"`
+		IdBarang     string          `json:"id_barang"`
+		Barang       BarangResponse  `json:"barang"`
+		Jumlah       int             `json:"jumlah"`
+		JumlahFormat string          `json:"jumlah_format"`
 	}
 )
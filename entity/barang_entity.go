@@ -2,20 +2,28 @@ package entity
 
 import (
 	"github.com/google/uuid"
+	"github.com/jejevj/ykp_pos/constants"
+	"github.com/jejevj/ykp_pos/helpers"
 	"gorm.io/gorm"
 )
 
 type Barang struct {
-	ID           uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
-	NamaBarang   string    `json:"nama_barang"`
-	KodeBarang   string    `json:"kode_barang"`
-	HargaBeli    int       `json:"harga_beli"`
-	HargaJual    int       `json:"harga_jual"`
-	IdSatuan     string    `json:"id_satuan"`
-	Satuan       Satuan    `gorm:"foreignKey:IdSatuan" json:"satuan"`
-	JumlahKrat   int       `json:"jumlah_krat"`
-	JumlahSatuan int       `json:"jumlah_satuan"`
-	Stok         int       `json:"stok"`
+	ID           uuid.UUID      `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	NamaBarang   string         `json:"nama_barang"`
+	KodeBarang   string         `json:"kode_barang"`
+	HargaBeli    int            `json:"harga_beli"`
+	HargaJual    int            `json:"harga_jual"`
+	IdSatuan     string         `gorm:"type:uuid" json:"id_satuan"`
+	Satuan       Satuan         `gorm:"foreignKey:IdSatuan" json:"satuan"`
+	JumlahKrat   int            `json:"jumlah_krat"`
+	JumlahSatuan int            `json:"jumlah_satuan"`
+	Stok         int            `json:"stok"`
+	StokRusak    int            `json:"stok_rusak"`
+	Satuans      []BarangSatuan `gorm:"foreignKey:IdBarang" json:"satuans"`
+
+	// Rincian is a quantity entered in any of the units, used when stock is
+	// added; it is never stored.
+	Rincian []helpers.JumlahSatuan `gorm:"-" json:"-"`
 
 	Timestamp
 }
@@ -29,3 +37,26 @@ func (u *Barang) BeforeCreate(tx *gorm.DB) error {
 
 	return nil
 }
+
+// SatuanKonversi returns the units of the barang from the largest to the base
+// unit. Barang without their own list fall back to the legacy Satuan (usually
+// the krat), a lusin and the base unit.
+func (u *Barang) SatuanKonversi() []helpers.SatuanKonversi {
+	var satuans []helpers.SatuanKonversi
+	for _, satuan := range u.Satuans {
+		if satuan.Isi > 0 {
+			satuans = append(satuans, helpers.SatuanKonversi{Nama: satuan.NamaSatuan, Isi: satuan.Isi})
+		}
+	}
+
+	if len(satuans) == 0 {
+		if u.Satuan.Value > 1 {
+			satuans = append(satuans, helpers.SatuanKonversi{Nama: u.Satuan.NamaSatuan, Isi: u.Satuan.Value})
+		}
+		if u.Satuan.Value != constants.ENUM_ISI_LUSIN {
+			satuans = append(satuans, helpers.SatuanKonversi{Nama: "lusin", Isi: constants.ENUM_ISI_LUSIN})
+		}
+	}
+
+	return helpers.UrutkanSatuan(satuans)
+}
@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BarangSatuan is one packaging level of a barang. Isi is the number of base
+// units (pcs) in one of this unit, so a krat of 24 pcs has Isi 24.
+type BarangSatuan struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	IdBarang   string    `gorm:"type:uuid;index" json:"id_barang"`
+	NamaSatuan string    `json:"nama_satuan"`
+	Isi        int       `json:"isi"`
+	Urutan     int       `json:"urutan"`
+
+	Timestamp
+}
+
+func (u *BarangSatuan) BeforeCreate(tx *gorm.DB) error {
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	return nil
+}
@@ -11,6 +11,12 @@ type Customer struct {
 	NamaPemilik string    `json:"nama_pemilik"`
 	Alamat      string    `json:"alamat"`
 	HP          string    `json:"HP"`
+	Npwp        string    `json:"npwp"`
+	// LimitKredit caps the open balance of credit fakturs and MaxHariTempo
+	// the days an invoice may be overdue before new credit is refused; 0
+	// disables the check
+	LimitKredit  int `json:"limit_kredit"`
+	MaxHariTempo int `json:"max_hari_tempo"`
 
 	Timestamp
 }
@@ -1,7 +1,6 @@
 package entity
 
 import (
-	"mime/multipart"
 	"time"
 
 	"github.com/google/uuid"
@@ -9,15 +8,44 @@ import (
 )
 
 type Faktur struct {
-	ID            uuid.UUID             `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
-	NoFaktur      string                `json:"no_faktur"`
-	TanggalFaktur *time.Time            `gorm: json:"tanggal_faktur"`
-	TanggalTempo  *time.Time            `gorm: json:"tanggal_tempo"`
-	CaraBayar     string                `json:"cara_bayar"`
-	IdUser        string                `json:"id_user"`
-	Driver        User                  `gorm:"foreignKey:IdDriver" json:"driver"`
-	BuktiBayar    *multipart.FileHeader `json:"bukti_bayar" form:"bukti_bayar"`
-	Status        string                `gorm:"default:belum_bayar" json:"status"`
+	ID               uuid.UUID         `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	NoFaktur         string            `gorm:"uniqueIndex" json:"no_faktur"`
+	TanggalFaktur    *time.Time        `json:"tanggal_faktur"`
+	TanggalTempo     *time.Time        `json:"tanggal_tempo"`
+	CaraBayar        string            `json:"cara_bayar"`
+	IdCustomer       string            `gorm:"type:uuid" json:"id_customer"`
+	Customer         Customer          `gorm:"foreignKey:IdCustomer" json:"customer"`
+	IdUser           string            `gorm:"type:uuid" json:"id_user"`
+	Driver           User              `gorm:"foreignKey:IdUser" json:"driver"`
+	IdLoading        string            `json:"id_loading"`
+	BuktiBayar       string            `json:"bukti_bayar"`
+	Status           string            `gorm:"default:belum_bayar" json:"status"`
+	Total            int               `json:"total"`
+	TotalBayar       int               `json:"total_bayar"` // accepted payments, credit notes and credit applied
+	Keterangan       string            `json:"keterangan"`
+	IdOverrideKredit string            `json:"id_override_kredit"`
+	Items            []TransaksiFaktur `gorm:"foreignKey:IdFaktur" json:"items"`
+
+	// A voided faktur keeps its number with status batal and records who
+	// voided it and why
+	AlasanBatal  string     `json:"alasan_batal"`
+	IdUserBatal  string     `json:"id_user_batal"`
+	TanggalBatal *time.Time `json:"tanggal_batal"`
+
+	// Invoice discount on the Subtotal of the lines, PotonganDiskon is the
+	// amount it came to
+	Subtotal       int     `json:"subtotal"`
+	Diskon         int     `json:"diskon"`
+	DiskonP        float32 `json:"diskon_p"`
+	PotonganDiskon int     `json:"potongan_diskon"`
+
+	// PPN is fixed when the faktur is issued, Total is always Dpp + Ppn.
+	// Eceran marks a retail tax invoice to a customer without NPWP.
+	TarifPpn         float64 `json:"tarif_ppn"`
+	HargaTermasukPpn *bool   `json:"harga_termasuk_ppn"`
+	Dpp              int     `json:"dpp"`
+	Ppn              int     `json:"ppn"`
+	Eceran           bool    `json:"eceran"`
 
 	Timestamp
 }
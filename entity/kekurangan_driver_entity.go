@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// KekuranganDriver is a shortage found during a setoran that the driver is
+// accountable for, either missing goods or missing money.
+type KekuranganDriver struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	IdSetoran  string    `gorm:"type:uuid;index" json:"id_setoran"`
+	IdUser     string    `gorm:"type:uuid;index" json:"id_user"`
+	Jenis      string    `json:"jenis"`
+	IdBarang   string    `json:"id_barang"`
+	Jumlah     int       `json:"jumlah"`
+	Nilai      int       `json:"nilai"`
+	Keterangan string    `json:"keterangan"`
+
+	Timestamp
+}
+
+func (u *KekuranganDriver) BeforeCreate(tx *gorm.DB) error {
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	return nil
+}
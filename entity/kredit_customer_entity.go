@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// KreditCustomer is a mutation of the credit balance of a customer. An
+// overpayment adds a positive line; using the credit adds a negative one.
+type KreditCustomer struct {
+	ID           uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	IdCustomer   string    `gorm:"type:uuid;index" json:"id_customer"`
+	IdPembayaran string    `gorm:"index" json:"id_pembayaran"`
+	IdNotaKredit string    `gorm:"index" json:"id_nota_kredit"`
+	IdFaktur     string    `gorm:"index" json:"id_faktur"` // faktur the credit was applied to
+	Jumlah       int       `json:"jumlah"`
+	Keterangan   string    `json:"keterangan"`
+
+	Timestamp
+}
+
+func (u *KreditCustomer) BeforeCreate(tx *gorm.DB) error {
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	return nil
+}
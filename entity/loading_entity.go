@@ -1,15 +1,28 @@
 package entity
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 type Loading struct {
-	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
-	IdUser     string    `json:"id_user"`
-	User       User      `gorm:"foreignKey:IdUser" json:"user"`
-	IsApproved bool      `gorm:"default:false" json:"is_approved"`
+	ID           uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	NoLoading    string     `json:"no_loading"`
+	IdUser       string     `gorm:"type:uuid" json:"id_user"`
+	User         User       `gorm:"foreignKey:IdUser" json:"user"`
+	Status       string     `gorm:"default:draft;index" json:"status"`
+	SubmittedAt  *time.Time `json:"submitted_at"`
+	SubmittedBy  string     `json:"submitted_by"`
+	ApprovedAt   *time.Time `json:"approved_at"`
+	ApprovedBy   string     `json:"approved_by"`
+	DispatchedAt *time.Time `json:"dispatched_at"`
+	DispatchedBy string     `json:"dispatched_by"`
+	ReturnedAt   *time.Time `json:"returned_at"`
+	ReturnedBy   string     `json:"returned_by"`
+	SettledAt    *time.Time `json:"settled_at"`
+	SettledBy    string     `json:"settled_by"`
 
 	Timestamp
 }
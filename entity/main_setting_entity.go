@@ -13,6 +13,30 @@ type MainSetting struct {
 	Hp         string    `json:"hp"`
 	LogoUrl    string    `json:"logo_url"`
 
+	// Document numbering, e.g. INV/2026/10/000123
+	PrefixFaktur     string `gorm:"default:INV" json:"prefix_faktur"`
+	PrefixLoading    string `gorm:"default:LD" json:"prefix_loading"`
+	PrefixRetur      string `gorm:"default:RTR" json:"prefix_retur"`
+	PrefixNotaKredit string `gorm:"default:NK" json:"prefix_nota_kredit"`
+	PrefixPenjualan  string `gorm:"default:POS" json:"prefix_penjualan"`
+	PrefixPo         string `gorm:"default:PO" json:"prefix_po"`
+	PrefixPenerimaan string `gorm:"default:GRN" json:"prefix_penerimaan"`
+	FormatNomor      string `gorm:"default:{PREFIX}/{YYYY}/{MM}/{SEQ}" json:"format_nomor"`
+	PanjangNomor     int    `gorm:"default:6" json:"panjang_nomor"`
+	ResetNomor       string `gorm:"default:bulanan" json:"reset_nomor"`
+
+	// Tax, a business that is not a PKP keeps TarifPpn at 0
+	Npwp             string  `json:"npwp"`
+	TarifPpn         float64 `json:"tarif_ppn"`
+	HargaTermasukPpn bool    `json:"harga_termasuk_ppn"`
+
+	// Order in which a percentage and a nominal discount are applied
+	UrutanDiskon string `gorm:"default:persen_nominal" json:"urutan_diskon"`
+
+	// Static QRIS payload issued to the merchant by its acquirer; dynamic
+	// QRIS with an amount are built from it
+	QrisStatis string `json:"qris_statis"`
+
 	Timestamp
 }
 
@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// NomorDokumen keeps the last issued number of a document type per period.
+// The row is locked while a number is taken so the sequence stays gap-free
+// across every server instance sharing the database.
+type NomorDokumen struct {
+	ID      uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	Jenis   string    `gorm:"uniqueIndex:idx_nomor_dokumen_jenis_periode" json:"jenis"`
+	Periode string    `gorm:"uniqueIndex:idx_nomor_dokumen_jenis_periode" json:"periode"`
+	Nomor   int       `json:"nomor"`
+
+	Timestamp
+}
+
+func (u *NomorDokumen) BeforeCreate(tx *gorm.DB) error {
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// NotaKredit is the credit note issued for a retur. PotongFaktur is the part
+// taken off the open balance of the faktur and counts as paid on it; the rest,
+// Kredit, is kept as KreditCustomer for a next invoice.
+type NotaKredit struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	NoNotaKredit string     `gorm:"uniqueIndex" json:"no_nota_kredit"`
+	Tanggal      *time.Time `json:"tanggal"`
+	IdRetur      string     `gorm:"type:uuid;index" json:"id_retur"`
+	IdFaktur     string     `gorm:"type:uuid;index" json:"id_faktur"`
+	IdCustomer   string     `gorm:"type:uuid;index" json:"id_customer"`
+	Dpp          int        `json:"dpp"`
+	Ppn          int        `json:"ppn"`
+	Total        int        `json:"total"`
+	PotongFaktur int        `json:"potong_faktur"`
+	Kredit       int        `json:"kredit"`
+
+	Timestamp
+}
+
+func (u *NotaKredit) BeforeCreate(tx *gorm.DB) error {
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	return nil
+}
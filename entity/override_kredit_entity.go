@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OverrideKredit lets an admin release a customer blocked by its credit limit
+// or overdue invoices for one credit faktur. It is used up by the first faktur
+// that needs it and lapses at BerlakuSampai.
+type OverrideKredit struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	IdCustomer    string     `gorm:"type:uuid;index" json:"id_customer"`
+	Alasan        string     `json:"alasan"`
+	IdUser        string     `gorm:"type:uuid" json:"id_user"`
+	Admin         User       `gorm:"foreignKey:IdUser" json:"admin"`
+	BerlakuSampai *time.Time `json:"berlaku_sampai"`
+	IdFaktur      string     `json:"id_faktur"`
+
+	Timestamp
+}
+
+func (u *OverrideKredit) BeforeCreate(tx *gorm.DB) error {
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	return nil
+}
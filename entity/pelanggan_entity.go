@@ -8,8 +8,8 @@ import (
 type Pelanggan struct {
 	ID            uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
 	NoPelanggan   string    `json:"no+pelanggan"`
-	NamaPelanggan string    `gorm: json:"nama_pelanggan"`
-	Alamat        string    `gorm: json:"alamat"`
+	NamaPelanggan string    `json:"nama_pelanggan"`
+	Alamat        string    `json:"alamat"`
 	Telp          string    `json:"telp"`
 	Npwp          string    `json:"npwp" `
 
@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Pembayaran is one payment received against a faktur. A faktur can be paid in
+// several parts; Jumlah is the part applied to the faktur, Kelebihan is paid on
+// top of the outstanding amount and kept as KreditCustomer. A payment only
+// counts once an admin has accepted its BuktiBayar.
+type Pembayaran struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	IdFaktur     string     `gorm:"type:uuid;index" json:"id_faktur"`
+	Faktur       Faktur     `gorm:"foreignKey:IdFaktur" json:"faktur"`
+	Jumlah       int        `json:"jumlah"`
+	Metode       string     `json:"metode"`
+	TanggalBayar *time.Time `json:"tanggal_bayar"`
+	Referensi    string     `json:"referensi"`
+	IdUser       string     `json:"id_user"` // empty for gateway payments
+	Penagih      User       `gorm:"foreignKey:IdUser" json:"penagih"`
+	Keterangan   string     `json:"keterangan"`
+	Kelebihan    int        `json:"kelebihan"`
+
+	BuktiBayar    string     `json:"bukti_bayar"`
+	StatusBukti   string     `gorm:"default:pending" json:"status_bukti"`
+	AlasanTolak   string     `json:"alasan_tolak"`
+	IdReviewer    string     `json:"id_reviewer"`
+	TanggalReview *time.Time `json:"tanggal_review"`
+
+	Timestamp
+}
+
+func (u *Pembayaran) BeforeCreate(tx *gorm.DB) error {
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PembayaranPenjualan is one tender of a counter sale. A sale can be paid
+// partly in cash and partly by QRIS or transfer; Referensi holds the
+// reference of a non-cash payment.
+type PembayaranPenjualan struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	IdPenjualan string    `gorm:"type:uuid;index" json:"id_penjualan"`
+	Metode      string    `json:"metode"`
+	Jumlah      int       `json:"jumlah"`
+	Referensi   string    `json:"referensi"`
+
+	Timestamp
+}
+
+func (u *PembayaranPenjualan) BeforeCreate(tx *gorm.DB) error {
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	return nil
+}
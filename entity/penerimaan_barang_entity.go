@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PenerimaanBarang is a delivery received against a PurchaseOrder. The goods
+// go into stock through the kartu stok and the price paid becomes the
+// HargaBeli of the barang.
+type PenerimaanBarang struct {
+	ID              uuid.UUID             `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	NoPenerimaan    string                `gorm:"uniqueIndex" json:"no_penerimaan"`
+	Tanggal         *time.Time            `json:"tanggal"`
+	IdPurchaseOrder string                `gorm:"type:uuid;index" json:"id_purchase_order"`
+	PurchaseOrder   PurchaseOrder         `gorm:"foreignKey:IdPurchaseOrder" json:"purchase_order"`
+	IdUser          string                `gorm:"type:uuid" json:"id_user"`
+	User            User                  `gorm:"foreignKey:IdUser" json:"user"`
+	Total           int                   `json:"total"`
+	Keterangan      string                `json:"keterangan"`
+	Items           []TransaksiPenerimaan `gorm:"foreignKey:IdPenerimaan" json:"items"`
+
+	Timestamp
+}
+
+func (u *PenerimaanBarang) BeforeCreate(tx *gorm.DB) error {
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	return nil
+}
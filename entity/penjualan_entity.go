@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Penjualan is a counter sale rung up by a cashier on an open ShiftKasir. It
+// is paid on the spot, possibly with several tenders, and the goods leave the
+// stock when it is saved. Bayar is what the customer handed over in total,
+// Kembalian the change given back and Tunai the cash that stays in the drawer.
+// MetodeBayar is the method of a single tender or campuran for a split one.
+type Penjualan struct {
+	ID          uuid.UUID             `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	NoPenjualan string                `gorm:"uniqueIndex" json:"no_penjualan"`
+	Tanggal     *time.Time            `gorm:"type:timestamp with time zone" json:"tanggal"`
+	IdShift     string                `gorm:"type:uuid;index" json:"id_shift"`
+	IdUser      string                `gorm:"type:uuid;index" json:"id_user"`
+	Kasir       User                  `gorm:"foreignKey:IdUser" json:"kasir"`
+	MetodeBayar string                `json:"metode_bayar"`
+	Bayar       int                   `json:"bayar"`
+	Kembalian   int                   `json:"kembalian"`
+	Tunai       int                   `json:"tunai"`
+	Keterangan  string                `json:"keterangan"`
+	Items       []TransaksiPenjualan  `gorm:"foreignKey:IdPenjualan" json:"items"`
+	Pembayarans []PembayaranPenjualan `gorm:"foreignKey:IdPenjualan" json:"pembayarans"`
+
+	// Priced like a faktur: Subtotal of the lines less the sale discount,
+	// Total is always Dpp + Ppn
+	Subtotal         int     `json:"subtotal"`
+	Diskon           int     `json:"diskon"`
+	DiskonP          float32 `json:"diskon_p"`
+	PotonganDiskon   int     `json:"potongan_diskon"`
+	TarifPpn         float64 `json:"tarif_ppn"`
+	HargaTermasukPpn bool    `json:"harga_termasuk_ppn"`
+	Dpp              int     `json:"dpp"`
+	Ppn              int     `json:"ppn"`
+	Total            int     `json:"total"`
+
+	Timestamp
+}
+
+func (u *Penjualan) BeforeCreate(tx *gorm.DB) error {
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	return nil
+}
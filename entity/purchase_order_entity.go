@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PurchaseOrder is goods ordered from a Supplier. It stays open until goods
+// come in, is partial while only part of the lines has been received and is
+// closed once everything arrived or an admin closes what is left.
+type PurchaseOrder struct {
+	ID          uuid.UUID                `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	NoPo        string                   `gorm:"uniqueIndex" json:"no_po"`
+	Tanggal     *time.Time               `json:"tanggal"`
+	IdSupplier  string                   `gorm:"type:uuid;index" json:"id_supplier"`
+	Supplier    Supplier                 `gorm:"foreignKey:IdSupplier" json:"supplier"`
+	IdUser      string                   `gorm:"type:uuid" json:"id_user"`
+	User        User                     `gorm:"foreignKey:IdUser" json:"user"`
+	Status      string                   `gorm:"default:open" json:"status"`
+	Total       int                      `json:"total"`
+	Keterangan  string                   `json:"keterangan"`
+	AlasanTutup string                   `json:"alasan_tutup"`
+	Items       []TransaksiPurchaseOrder `gorm:"foreignKey:IdPurchaseOrder" json:"items"`
+	Penerimaans []PenerimaanBarang       `gorm:"foreignKey:IdPurchaseOrder" json:"penerimaans"`
+
+	Timestamp
+}
+
+func (u *PurchaseOrder) BeforeCreate(tx *gorm.DB) error {
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	return nil
+}
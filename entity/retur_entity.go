@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Retur is goods a customer sends back from one faktur. Goods that can still
+// be sold go back into Barang.Stok, damaged ones are written off into
+// Barang.StokRusak. Every retur issues a NotaKredit for the value returned.
+type Retur struct {
+	ID         uuid.UUID        `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	NoRetur    string           `gorm:"uniqueIndex" json:"no_retur"`
+	Tanggal    *time.Time       `json:"tanggal"`
+	IdFaktur   string           `gorm:"type:uuid;index" json:"id_faktur"`
+	Faktur     Faktur           `gorm:"foreignKey:IdFaktur" json:"faktur"`
+	IdCustomer string           `gorm:"type:uuid;index" json:"id_customer"`
+	Customer   Customer         `gorm:"foreignKey:IdCustomer" json:"customer"`
+	IdUser     string           `gorm:"type:uuid" json:"id_user"`
+	User       User             `gorm:"foreignKey:IdUser" json:"user"`
+	Total      int              `json:"total"`
+	Keterangan string           `json:"keterangan"`
+	Items      []TransaksiRetur `gorm:"foreignKey:IdRetur" json:"items"`
+	NotaKredit NotaKredit       `gorm:"foreignKey:IdRetur" json:"nota_kredit"`
+
+	Timestamp
+}
+
+func (u *Retur) BeforeCreate(tx *gorm.DB) error {
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	return nil
+}
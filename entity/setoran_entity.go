@@ -0,0 +1,67 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jejevj/ykp_pos/helpers"
+	"gorm.io/gorm"
+)
+
+// Setoran is the end-of-day settlement of a loading: what went out on the
+// truck against what was sold, returned and paid in by the driver.
+type Setoran struct {
+	ID            uuid.UUID          `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	IdLoading     string             `gorm:"type:uuid;uniqueIndex" json:"id_loading"`
+	Loading       Loading            `gorm:"foreignKey:IdLoading" json:"loading"`
+	IdUser        string             `gorm:"type:uuid" json:"id_user"`
+	Driver        User               `gorm:"foreignKey:IdUser" json:"driver"`
+	IdPetugas     string             `gorm:"type:uuid" json:"id_petugas"`
+	Tanggal       time.Time          `json:"tanggal"`
+	TotalTagihan  int                `json:"total_tagihan"`
+	TotalTunai    int                `json:"total_tunai"`
+	TotalTransfer int                `json:"total_transfer"`
+	SelisihUang   int                `json:"selisih_uang"`
+	Keterangan    string             `json:"keterangan"`
+	Items         []SetoranDetail    `gorm:"foreignKey:IdSetoran" json:"items"`
+	Kekurangan    []KekuranganDriver `gorm:"foreignKey:IdSetoran" json:"kekurangan"`
+
+	Timestamp
+}
+
+func (u *Setoran) BeforeCreate(tx *gorm.DB) error {
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	return nil
+}
+
+// SetoranDetail compares the quantities of one barang in pieces. Selisih is
+// what is missing: loaded minus sold minus returned.
+type SetoranDetail struct {
+	ID            uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	IdSetoran     string    `gorm:"type:uuid;index" json:"id_setoran"`
+	IdBarang      string    `gorm:"type:uuid" json:"id_barang"`
+	Barang        Barang    `gorm:"foreignKey:IdBarang" json:"barang"`
+	JumlahMuat    int       `json:"jumlah_muat"`
+	JumlahTerjual int       `json:"jumlah_terjual"`
+	JumlahKembali int       `json:"jumlah_kembali"`
+	Selisih       int       `json:"selisih"`
+
+	Rincian []helpers.JumlahSatuan `gorm:"-" json:"-"`
+
+	Timestamp
+}
+
+func (u *SetoranDetail) BeforeCreate(tx *gorm.DB) error {
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ShiftKasir is one cash register shift of a cashier, from the opening float
+// to the closing count. A cashier has at most one open shift and every
+// counter sale is booked on it. The totals are filled in when the shift is
+// closed: KasDiharapkan is what the drawer should hold, Selisih how far the
+// counted cash is off.
+type ShiftKasir struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	IdUser     string     `gorm:"type:uuid;index" json:"id_user"`
+	Kasir      User       `gorm:"foreignKey:IdUser" json:"kasir"`
+	Status     string     `gorm:"default:buka;index" json:"status"`
+	WaktuBuka  time.Time  `gorm:"type:timestamp with time zone" json:"waktu_buka"`
+	WaktuTutup *time.Time `gorm:"type:timestamp with time zone" json:"waktu_tutup"`
+	ModalAwal  int        `json:"modal_awal"`
+	Kas        []KasShift `gorm:"foreignKey:IdShift" json:"kas"`
+
+	JumlahPenjualan int    `json:"jumlah_penjualan"`
+	TotalPenjualan  int    `json:"total_penjualan"`
+	TotalTunai      int    `json:"total_tunai"`
+	KasMasuk        int    `json:"kas_masuk"`
+	KasKeluar       int    `json:"kas_keluar"`
+	KasDiharapkan   int    `json:"kas_diharapkan"`
+	KasDihitung     int    `json:"kas_dihitung"`
+	Selisih         int    `json:"selisih"`
+	Keterangan      string `json:"keterangan"`
+
+	Timestamp
+}
+
+func (u *ShiftKasir) BeforeCreate(tx *gorm.DB) error {
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	return nil
+}
+
+// KasShift is cash put into or taken out of the drawer during a shift other
+// than through a sale, e.g. change brought in or a petty cash expense.
+type KasShift struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	IdShift    string    `gorm:"type:uuid;index" json:"id_shift"`
+	Jenis      string    `json:"jenis"`
+	Jumlah     int       `json:"jumlah"`
+	Waktu      time.Time `gorm:"type:timestamp with time zone" json:"waktu"`
+	IdUser     string    `gorm:"type:uuid" json:"id_user"`
+	Keterangan string    `json:"keterangan"`
+
+	Timestamp
+}
+
+func (u *KasShift) BeforeCreate(tx *gorm.DB) error {
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	return nil
+}
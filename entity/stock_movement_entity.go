@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// StockMovement is one line of the kartu stok. Every change of Barang.Stok is
+// recorded here together with the resulting balance.
+type StockMovement struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	IdBarang   string    `gorm:"type:uuid;index" json:"id_barang"`
+	Barang     Barang    `gorm:"foreignKey:IdBarang" json:"barang"`
+	Tanggal    time.Time `gorm:"type:timestamp with time zone;index" json:"tanggal"`
+	Jumlah     int       `json:"jumlah"`
+	Alasan     string    `json:"alasan"`
+	RefTipe    string    `gorm:"index:idx_stock_movement_ref" json:"ref_tipe"`
+	RefId      string    `gorm:"index:idx_stock_movement_ref" json:"ref_id"`
+	RefNo      string    `json:"ref_no"`
+	IdUser     string    `gorm:"type:uuid" json:"id_user"`
+	Saldo      int       `json:"saldo"`
+	Keterangan string    `json:"keterangan"`
+
+	Timestamp
+}
+
+func (u *StockMovement) BeforeCreate(tx *gorm.DB) error {
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	return nil
+}
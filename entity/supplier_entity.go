@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Supplier struct {
+	ID           uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	NamaSupplier string    `json:"nama_supplier"`
+	NamaKontak   string    `json:"nama_kontak"`
+	Alamat       string    `json:"alamat"`
+	HP           string    `json:"HP"`
+	Npwp         string    `json:"npwp"`
+
+	Timestamp
+}
+
+func (u *Supplier) BeforeCreate(tx *gorm.DB) error {
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TransaksiGateway is a faktur payment made through a payment gateway, kept
+// once per gateway and order id however often the gateway notifies about it.
+// Status is the normalised state; StatusGateway and Payload are what the
+// gateway sent last. A settled transaction is booked as the Pembayaran in
+// IdPembayaran.
+type TransaksiGateway struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	Gateway       string     `gorm:"uniqueIndex:idx_transaksi_gateways_gateway_order_id" json:"gateway"`
+	OrderId       string     `gorm:"uniqueIndex:idx_transaksi_gateways_gateway_order_id" json:"order_id"`
+	IdTransaksi   string     `json:"id_transaksi"`
+	IdFaktur      string     `gorm:"type:uuid;index" json:"id_faktur"`
+	Faktur        Faktur     `gorm:"foreignKey:IdFaktur" json:"faktur"`
+	Status        string     `json:"status"`
+	StatusGateway string     `json:"status_gateway"`
+	Metode        string     `json:"metode"`
+	Jumlah        int        `json:"jumlah"`
+	WaktuBayar    *time.Time `gorm:"type:timestamp with time zone" json:"waktu_bayar"`
+	IdPembayaran  string     `json:"id_pembayaran"`
+	Payload       string     `json:"payload"`
+
+	Timestamp
+}
+
+func (u *TransaksiGateway) BeforeCreate(tx *gorm.DB) error {
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	return nil
+}
@@ -2,17 +2,20 @@ package entity
 
 import (
 	"github.com/google/uuid"
+	"github.com/jejevj/ykp_pos/helpers"
 	"gorm.io/gorm"
 )
 
 type Transaksi struct {
 	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
-	IdLoading string    `json:"id_loading"`
+	IdLoading string    `gorm:"type:uuid" json:"id_loading"`
 	Loading   Loading   `gorm:"foreignKey:IdLoading" json:"loading"`
-	IdBarang  string    `json:"id_barang"`
+	IdBarang  string    `gorm:"type:uuid" json:"id_barang"`
 	Barang    Barang    `gorm:"foreignKey:IdBarang" json:"barang"`
 	Jumlah    int       `json:"jumlah"`
 
+	Rincian []helpers.JumlahSatuan `gorm:"-" json:"-"`
+
 	Timestamp
 }
 
@@ -2,22 +2,34 @@ package entity
 
 import (
 	"github.com/google/uuid"
+	"github.com/jejevj/ykp_pos/helpers"
 	"gorm.io/gorm"
 )
 
 type TransaksiFaktur struct {
 	ID       uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
-	IdFaktur string    `json:"id_faktur"`
-	IdBarang string    `json:"id_barang"`
+	IdFaktur string    `gorm:"type:uuid" json:"id_faktur"`
+	IdBarang string    `gorm:"type:uuid" json:"id_barang"`
 	Barang   Barang    `gorm:"foreignKey:IdBarang" json:"barang"`
 	Krat     int       `json:"krat"`
 	Lusin    int       `json:"lusin"`
 	Satuan   int       `json:"satuan"`
+	Jumlah   int       `json:"jumlah"`
+	Harga    int       `json:"harga"`
 	JumlahRP int       `json:"jumlah_rp"`
+	Dpp      int       `json:"dpp"`
+	Ppn      int       `json:"ppn"`
 	Diskon   int       `json:"diskon"`
 	DiskonP  float32   `json:"diskon_p"`
 	Ket      string    `json:"keterangan"`
 
+	// Harga is the unit price of a piece when the line was priced, JumlahRP
+	// the line total after its own discounts and DiskonFaktur its share of
+	// the invoice discount. PPN is taken from JumlahRP - DiskonFaktur.
+	DiskonFaktur int `json:"diskon_faktur"`
+
+	Rincian []helpers.JumlahSatuan `gorm:"-" json:"-"`
+
 	Timestamp
 }
 
@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"github.com/jejevj/ykp_pos/helpers"
+	"gorm.io/gorm"
+)
+
+// TransaksiPenerimaan is one received line. It points at the purchase order
+// line the goods were ordered on.
+type TransaksiPenerimaan struct {
+	ID            uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	IdPenerimaan  string    `gorm:"type:uuid;index" json:"id_penerimaan"`
+	IdTransaksiPo string    `gorm:"type:uuid;index" json:"id_transaksi_po"`
+	IdBarang      string    `gorm:"type:uuid" json:"id_barang"`
+	Barang        Barang    `gorm:"foreignKey:IdBarang" json:"barang"`
+	Krat          int       `json:"krat"`
+	Lusin         int       `json:"lusin"`
+	Satuan        int       `json:"satuan"`
+	Jumlah        int       `json:"jumlah"`
+	HargaBeli     int       `json:"harga_beli"`
+	JumlahRP      int       `json:"jumlah_rp"`
+
+	Rincian []helpers.JumlahSatuan `gorm:"-" json:"-"`
+
+	Timestamp
+}
+
+func (u *TransaksiPenerimaan) BeforeCreate(tx *gorm.DB) error {
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"github.com/jejevj/ykp_pos/helpers"
+	"gorm.io/gorm"
+)
+
+// TransaksiPenjualan is one line of a counter sale, priced the same way as a
+// faktur line.
+type TransaksiPenjualan struct {
+	ID           uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	IdPenjualan  string    `gorm:"type:uuid;index" json:"id_penjualan"`
+	IdBarang     string    `gorm:"type:uuid" json:"id_barang"`
+	Barang       Barang    `gorm:"foreignKey:IdBarang" json:"barang"`
+	Krat         int       `json:"krat"`
+	Lusin        int       `json:"lusin"`
+	Satuan       int       `json:"satuan"`
+	Jumlah       int       `json:"jumlah"`
+	Harga        int       `json:"harga"`
+	Diskon       int       `json:"diskon"`
+	DiskonP      float32   `json:"diskon_p"`
+	JumlahRP     int       `json:"jumlah_rp"`
+	DiskonFaktur int       `json:"diskon_faktur"`
+	Dpp          int       `json:"dpp"`
+	Ppn          int       `json:"ppn"`
+
+	Rincian []helpers.JumlahSatuan `gorm:"-" json:"-"`
+
+	Timestamp
+}
+
+func (u *TransaksiPenjualan) BeforeCreate(tx *gorm.DB) error {
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"github.com/jejevj/ykp_pos/helpers"
+	"gorm.io/gorm"
+)
+
+// TransaksiPurchaseOrder is one ordered line. Jumlah and Diterima are in
+// pieces, HargaBeli is the price of one piece.
+type TransaksiPurchaseOrder struct {
+	ID              uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	IdPurchaseOrder string    `gorm:"type:uuid;index" json:"id_purchase_order"`
+	IdBarang        string    `gorm:"type:uuid" json:"id_barang"`
+	Barang          Barang    `gorm:"foreignKey:IdBarang" json:"barang"`
+	Krat            int       `json:"krat"`
+	Lusin           int       `json:"lusin"`
+	Satuan          int       `json:"satuan"`
+	Jumlah          int       `json:"jumlah"`
+	HargaBeli       int       `json:"harga_beli"`
+	JumlahRP        int       `json:"jumlah_rp"`
+	Diterima        int       `json:"diterima"`
+
+	Rincian []helpers.JumlahSatuan `gorm:"-" json:"-"`
+
+	Timestamp
+}
+
+func (u *TransaksiPurchaseOrder) BeforeCreate(tx *gorm.DB) error {
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"github.com/jejevj/ykp_pos/helpers"
+	"gorm.io/gorm"
+)
+
+// TransaksiRetur is one returned line. It points at the faktur line the goods
+// were sold on; JumlahRP is the part of that line's amount being credited.
+type TransaksiRetur struct {
+	ID                uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	IdRetur           string    `gorm:"type:uuid;index" json:"id_retur"`
+	IdTransaksiFaktur string    `gorm:"type:uuid;index" json:"id_transaksi_faktur"`
+	IdBarang          string    `gorm:"type:uuid" json:"id_barang"`
+	Barang            Barang    `gorm:"foreignKey:IdBarang" json:"barang"`
+	Krat              int       `json:"krat"`
+	Lusin             int       `json:"lusin"`
+	Satuan            int       `json:"satuan"`
+	Jumlah            int       `json:"jumlah"`
+	Kondisi           string    `json:"kondisi"`
+	JumlahRP          int       `json:"jumlah_rp"`
+	Dpp               int       `json:"dpp"`
+	Ppn               int       `json:"ppn"`
+	Ket               string    `json:"keterangan"`
+
+	Rincian []helpers.JumlahSatuan `gorm:"-" json:"-"`
+
+	Timestamp
+}
+
+func (u *TransaksiRetur) BeforeCreate(tx *gorm.DB) error {
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package helpers
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	ErrDiskonPersen   = errors.New("discount percentage must be between 0 and 100")
+	ErrDiskonNominal  = errors.New("discount amount must not be negative")
+	ErrDiskonMelebihi = errors.New("discount is larger than the amount")
+)
+
+// BulatkanRupiah rounds an amount to whole rupiah, halves away from zero. All
+// discount and allocation arithmetic goes through it so a faktur is rounded
+// the same way everywhere.
+func BulatkanRupiah(jumlah float64) int {
+	return int(math.Round(jumlah))
+}
+
+// Diskon is a percentage and a nominal discount on one amount. NominalDulu
+// takes the nominal off before the percentage is calculated; by default the
+// percentage comes first.
+type Diskon struct {
+	Persen      float64
+	Nominal     int
+	NominalDulu bool
+}
+
+// HasilDiskon is an amount before and after its discounts.
+type HasilDiskon struct {
+	Bruto           int
+	PotonganPersen  int
+	PotonganNominal int
+	Neto            int
+}
+
+// Potongan is the whole discount taken off the amount.
+func (h HasilDiskon) Potongan() int {
+	return h.PotonganPersen + h.PotonganNominal
+}
+
+// HitungDiskon applies a discount to an amount in its configured order.
+func HitungDiskon(bruto int, diskon Diskon) (HasilDiskon, error) {
+	if diskon.Persen < 0 || diskon.Persen > 100 {
+		return HasilDiskon{}, ErrDiskonPersen
+	}
+	if diskon.Nominal < 0 {
+		return HasilDiskon{}, ErrDiskonNominal
+	}
+
+	hasil := HasilDiskon{Bruto: bruto, Neto: bruto}
+	persen := func() {
+		hasil.PotonganPersen = BulatkanRupiah(float64(hasil.Neto) * diskon.Persen / 100)
+		hasil.Neto -= hasil.PotonganPersen
+	}
+	nominal := func() {
+		hasil.PotonganNominal = diskon.Nominal
+		hasil.Neto -= hasil.PotonganNominal
+	}
+
+	if diskon.NominalDulu {
+		nominal()
+		persen()
+	} else {
+		persen()
+		nominal()
+	}
+
+	if hasil.Neto < 0 {
+		return HasilDiskon{}, ErrDiskonMelebihi
+	}
+
+	return hasil, nil
+}
+
+// BagiPotongan spreads a discount over amounts in proportion to them, using
+// the largest remainder so the shares add up to the discount exactly. Ties go
+// to the earlier amount.
+func BagiPotongan(potongan int, jumlah []int) []int {
+	bagian := make([]int, len(jumlah))
+
+	total := 0
+	for _, j := range jumlah {
+		total += j
+	}
+	if potongan == 0 || total == 0 {
+		return bagian
+	}
+
+	sisa := make([]float64, len(jumlah))
+	terbagi := 0
+	for i, j := range jumlah {
+		porsi := float64(potongan) * float64(j) / float64(total)
+		bagian[i] = int(math.Floor(porsi))
+		sisa[i] = porsi - float64(bagian[i])
+		terbagi += bagian[i]
+	}
+
+	for ; terbagi < potongan; terbagi++ {
+		terbesar := 0
+		for i := range sisa {
+			if sisa[i] > sisa[terbesar] {
+				terbesar = i
+			}
+		}
+		bagian[terbesar]++
+		sisa[terbesar] = -1
+	}
+
+	return bagian
+}
+
+// BarisHarga is one invoice line as priced by HitungHarga.
+type BarisHarga struct {
+	Harga  int
+	Jumlah int
+	Diskon Diskon
+}
+
+// HasilBaris is a priced line. Neto is the line total after the line
+// discounts; PotonganFaktur is its share of the invoice discount and Bersih
+// what is left of the line after it, the base for PPN.
+type HasilBaris struct {
+	HasilDiskon
+	PotonganFaktur int
+	Bersih         int
+}
+
+// HasilHarga is a priced invoice. Subtotal adds up the line totals and Total
+// is what remains after the invoice discount.
+type HasilHarga struct {
+	Baris          []HasilBaris
+	Subtotal       int
+	PotonganFaktur HasilDiskon
+	Total          int
+}
+
+// HitungHarga prices every line as quantity times unit price less its own
+// discounts, then takes the invoice discount off the subtotal and spreads it
+// back over the lines.
+func HitungHarga(baris []BarisHarga, diskonFaktur Diskon) (HasilHarga, error) {
+	hasil := HasilHarga{Baris: make([]HasilBaris, len(baris))}
+
+	neto := make([]int, len(baris))
+	for i, b := range baris {
+		line, err := HitungDiskon(b.Harga*b.Jumlah, b.Diskon)
+		if err != nil {
+			return HasilHarga{}, err
+		}
+		hasil.Baris[i].HasilDiskon = line
+		neto[i] = line.Neto
+		hasil.Subtotal += line.Neto
+	}
+
+	potongan, err := HitungDiskon(hasil.Subtotal, diskonFaktur)
+	if err != nil {
+		return HasilHarga{}, err
+	}
+	hasil.PotonganFaktur = potongan
+	hasil.Total = potongan.Neto
+
+	for i, bagian := range BagiPotongan(potongan.Potongan(), neto) {
+		hasil.Baris[i].PotonganFaktur = bagian
+		hasil.Baris[i].Bersih = neto[i] - bagian
+	}
+
+	return hasil, nil
+}
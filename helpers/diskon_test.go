@@ -0,0 +1,272 @@
+package helpers
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestHitungDiskon(t *testing.T) {
+	tests := []struct {
+		name    string
+		bruto   int
+		diskon  Diskon
+		want    HasilDiskon
+		wantErr error
+	}{
+		{
+			name:   "tanpa diskon",
+			bruto:  100000,
+			diskon: Diskon{},
+			want:   HasilDiskon{Bruto: 100000, Neto: 100000},
+		},
+		{
+			name:   "persen lalu nominal",
+			bruto:  100000,
+			diskon: Diskon{Persen: 10, Nominal: 5000},
+			want:   HasilDiskon{Bruto: 100000, PotonganPersen: 10000, PotonganNominal: 5000, Neto: 85000},
+		},
+		{
+			name:   "nominal lalu persen",
+			bruto:  100000,
+			diskon: Diskon{Persen: 10, Nominal: 5000, NominalDulu: true},
+			want:   HasilDiskon{Bruto: 100000, PotonganPersen: 9500, PotonganNominal: 5000, Neto: 85500},
+		},
+		{
+			name:   "nol persen",
+			bruto:  100000,
+			diskon: Diskon{Persen: 0, Nominal: 2500},
+			want:   HasilDiskon{Bruto: 100000, PotonganNominal: 2500, Neto: 97500},
+		},
+		{
+			name:   "seratus persen",
+			bruto:  100000,
+			diskon: Diskon{Persen: 100},
+			want:   HasilDiskon{Bruto: 100000, PotonganPersen: 100000, Neto: 0},
+		},
+		{
+			name:   "seratus persen setelah nominal",
+			bruto:  100000,
+			diskon: Diskon{Persen: 100, Nominal: 5000, NominalDulu: true},
+			want:   HasilDiskon{Bruto: 100000, PotonganPersen: 95000, PotonganNominal: 5000, Neto: 0},
+		},
+		{
+			name:    "nominal setelah seratus persen",
+			bruto:   100000,
+			diskon:  Diskon{Persen: 100, Nominal: 5000},
+			wantErr: ErrDiskonMelebihi,
+		},
+		{
+			name:    "nominal melebihi bruto",
+			bruto:   1000,
+			diskon:  Diskon{Nominal: 1500},
+			wantErr: ErrDiskonMelebihi,
+		},
+		{
+			name:    "nominal dulu melebihi bruto",
+			bruto:   1000,
+			diskon:  Diskon{Persen: 10, Nominal: 1500, NominalDulu: true},
+			wantErr: ErrDiskonMelebihi,
+		},
+		{
+			name:   "pembulatan setengah ke atas",
+			bruto:  5,
+			diskon: Diskon{Persen: 10},
+			want:   HasilDiskon{Bruto: 5, PotonganPersen: 1, Neto: 4},
+		},
+		{
+			name:   "pembulatan pecahan",
+			bruto:  999,
+			diskon: Diskon{Persen: 12.5},
+			want:   HasilDiskon{Bruto: 999, PotonganPersen: 125, Neto: 874},
+		},
+		{
+			name:    "persen negatif",
+			bruto:   1000,
+			diskon:  Diskon{Persen: -1},
+			wantErr: ErrDiskonPersen,
+		},
+		{
+			name:    "persen di atas seratus",
+			bruto:   1000,
+			diskon:  Diskon{Persen: 100.5},
+			wantErr: ErrDiskonPersen,
+		},
+		{
+			name:    "nominal negatif",
+			bruto:   1000,
+			diskon:  Diskon{Nominal: -1},
+			wantErr: ErrDiskonNominal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := HitungDiskon(tt.bruto, tt.diskon)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("HitungDiskon(%d, %+v) = %+v, want %+v", tt.bruto, tt.diskon, got, tt.want)
+			}
+			if tt.wantErr == nil && got.Bruto-got.Potongan() != got.Neto {
+				t.Errorf("potongan %d does not bridge bruto %d and neto %d", got.Potongan(), got.Bruto, got.Neto)
+			}
+		})
+	}
+}
+
+func TestBagiPotongan(t *testing.T) {
+	tests := []struct {
+		name     string
+		potongan int
+		jumlah   []int
+		want     []int
+	}{
+		{
+			name:     "habis dibagi",
+			potongan: 10,
+			jumlah:   []int{30, 70},
+			want:     []int{3, 7},
+		},
+		{
+			name:     "sisa ke pecahan terbesar",
+			potongan: 10,
+			jumlah:   []int{1, 2},
+			want:     []int{3, 7},
+		},
+		{
+			name:     "sisa seri ke baris pertama",
+			potongan: 100,
+			jumlah:   []int{1, 1, 1},
+			want:     []int{34, 33, 33},
+		},
+		{
+			name:     "satu sisa dari empat seri",
+			potongan: 5,
+			jumlah:   []int{1, 1, 1, 1},
+			want:     []int{2, 1, 1, 1},
+		},
+		{
+			name:     "total nol",
+			potongan: 100,
+			jumlah:   []int{0, 0},
+			want:     []int{0, 0},
+		},
+		{
+			name:     "potongan nol",
+			potongan: 0,
+			jumlah:   []int{5, 5},
+			want:     []int{0, 0},
+		},
+		{
+			name:     "tanpa baris",
+			potongan: 0,
+			jumlah:   []int{},
+			want:     []int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BagiPotongan(tt.potongan, tt.jumlah)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BagiPotongan(%d, %v) = %v, want %v", tt.potongan, tt.jumlah, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHitungHarga(t *testing.T) {
+	tests := []struct {
+		name         string
+		baris        []BarisHarga
+		diskonFaktur Diskon
+		wantSubtotal int
+		wantTotal    int
+		wantBersih   []int
+		wantErr      error
+	}{
+		{
+			name: "diskon baris dan faktur",
+			baris: []BarisHarga{
+				{Harga: 10000, Jumlah: 3, Diskon: Diskon{Persen: 10}},
+				{Harga: 5000, Jumlah: 2, Diskon: Diskon{Nominal: 1000}},
+			},
+			diskonFaktur: Diskon{Persen: 5},
+			wantSubtotal: 36000,
+			wantTotal:    34200,
+			wantBersih:   []int{25650, 8550},
+		},
+		{
+			name: "tanpa diskon faktur",
+			baris: []BarisHarga{
+				{Harga: 2500, Jumlah: 4},
+				{Harga: 1000, Jumlah: 1, Diskon: Diskon{Persen: 50}},
+			},
+			wantSubtotal: 10500,
+			wantTotal:    10500,
+			wantBersih:   []int{10000, 500},
+		},
+		{
+			name: "potongan faktur dibagi dengan sisa",
+			baris: []BarisHarga{
+				{Harga: 100, Jumlah: 1},
+				{Harga: 100, Jumlah: 1},
+				{Harga: 100, Jumlah: 1},
+			},
+			diskonFaktur: Diskon{Nominal: 100},
+			wantSubtotal: 300,
+			wantTotal:    200,
+			wantBersih:   []int{66, 67, 67},
+		},
+		{
+			name: "diskon baris tidak valid",
+			baris: []BarisHarga{
+				{Harga: 1000, Jumlah: 1, Diskon: Diskon{Persen: 150}},
+			},
+			wantErr: ErrDiskonPersen,
+		},
+		{
+			name: "diskon faktur melebihi subtotal",
+			baris: []BarisHarga{
+				{Harga: 1000, Jumlah: 2},
+			},
+			diskonFaktur: Diskon{Nominal: 2500},
+			wantErr:      ErrDiskonMelebihi,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := HitungHarga(tt.baris, tt.diskonFaktur)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if got.Subtotal != tt.wantSubtotal {
+				t.Errorf("Subtotal = %d, want %d", got.Subtotal, tt.wantSubtotal)
+			}
+			if got.Total != tt.wantTotal {
+				t.Errorf("Total = %d, want %d", got.Total, tt.wantTotal)
+			}
+
+			bersih := make([]int, len(got.Baris))
+			jumlahBersih := 0
+			for i, baris := range got.Baris {
+				bersih[i] = baris.Bersih
+				jumlahBersih += baris.Bersih
+			}
+			if !reflect.DeepEqual(bersih, tt.wantBersih) {
+				t.Errorf("Bersih = %v, want %v", bersih, tt.wantBersih)
+			}
+			// The lines must add up to the faktur total after the spread
+			if jumlahBersih != got.Total {
+				t.Errorf("lines add up to %d, total is %d", jumlahBersih, got.Total)
+			}
+		})
+	}
+}
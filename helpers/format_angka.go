@@ -0,0 +1,35 @@
+package helpers
+
+import (
+	"strconv"
+	"strings"
+)
+
+// FormatAngka writes a number with dots as thousand separators, e.g. 1.234.567.
+func FormatAngka(value int) string {
+	digits := strconv.Itoa(value)
+	sign := ""
+	if value < 0 {
+		sign = "-"
+		digits = digits[1:]
+	}
+
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(d)
+	}
+
+	return sign + b.String()
+}
+
+// FormatRupiah writes an amount as Rp 1.234.567.
+func FormatRupiah(value int) string {
+	if value < 0 {
+		return "-Rp " + FormatAngka(-value)
+	}
+
+	return "Rp " + FormatAngka(value)
+}
@@ -0,0 +1,68 @@
+package helpers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jejevj/ykp_pos/constants"
+)
+
+const DEFAULT_FORMAT_NOMOR = "{PREFIX}/{YYYY}/{MM}/{SEQ}"
+
+// PeriodeNomor returns the sequence bucket of a document date. Numbers restart
+// from 1 every time the bucket changes.
+func PeriodeNomor(reset string, tanggal time.Time) string {
+	switch reset {
+	case constants.ENUM_RESET_NOMOR_TAHUNAN:
+		return tanggal.Format("2006")
+	case constants.ENUM_RESET_NOMOR_TIDAK:
+		return "-"
+	default:
+		return tanggal.Format("2006-01")
+	}
+}
+
+// ValidFormatNomor tells whether a numbering pattern yields unique numbers
+// under the given reset period: it needs the sequence, and the year (and
+// month for a monthly reset) since the sequence restarts when they change.
+func ValidFormatNomor(format string, reset string) bool {
+	if format == "" {
+		format = DEFAULT_FORMAT_NOMOR
+	}
+	if !strings.Contains(format, "{SEQ}") {
+		return false
+	}
+
+	adaTahun := strings.Contains(format, "{YYYY}") || strings.Contains(format, "{YY}")
+	switch reset {
+	case constants.ENUM_RESET_NOMOR_TIDAK:
+		return true
+	case constants.ENUM_RESET_NOMOR_TAHUNAN:
+		return adaTahun
+	default:
+		return adaTahun && strings.Contains(format, "{MM}")
+	}
+}
+
+// FormatNomor renders a document number from a pattern such as
+// {PREFIX}/{YYYY}/{MM}/{SEQ}. The sequence is zero padded to the given digits.
+func FormatNomor(format string, prefix string, digits int, tanggal time.Time, nomor int) string {
+	if format == "" {
+		format = DEFAULT_FORMAT_NOMOR
+	}
+	if digits <= 0 {
+		digits = 6
+	}
+
+	replacer := strings.NewReplacer(
+		"{PREFIX}", prefix,
+		"{YYYY}", tanggal.Format("2006"),
+		"{YY}", tanggal.Format("06"),
+		"{MM}", tanggal.Format("01"),
+		"{DD}", tanggal.Format("02"),
+		"{SEQ}", fmt.Sprintf("%0*d", digits, nomor),
+	)
+
+	return replacer.Replace(format)
+}
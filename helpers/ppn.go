@@ -0,0 +1,36 @@
+package helpers
+
+import (
+	"math"
+	"strings"
+)
+
+// HitungPpn splits an amount into DPP and PPN at the given rate in percent.
+// With termasukPpn the amount already contains the tax and the DPP is taken
+// out of it; otherwise the amount is the DPP and the tax comes on top. The
+// tax is rounded down to whole rupiah as on the tax invoice.
+func HitungPpn(jumlah int, tarif float64, termasukPpn bool) (dpp int, ppn int) {
+	if tarif <= 0 {
+		return jumlah, 0
+	}
+
+	if termasukPpn {
+		dpp = int(math.Round(float64(jumlah) * 100 / (100 + tarif)))
+		return dpp, jumlah - dpp
+	}
+
+	return jumlah, int(math.Floor(float64(jumlah) * tarif / 100))
+}
+
+// NormalisasiNpwp keeps only the digits of an NPWP, so 01.234.567.8-901.000
+// becomes 012345678901000.
+func NormalisasiNpwp(npwp string) string {
+	var b strings.Builder
+	for _, r := range npwp {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
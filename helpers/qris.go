@@ -0,0 +1,211 @@
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrQrisFormat    = errors.New("QRIS payload is not a valid EMVCo string")
+	ErrQrisCrc       = errors.New("QRIS payload checksum does not match")
+	ErrQrisJumlah    = errors.New("QRIS amount must not be negative")
+	ErrQrisReferensi = errors.New("QRIS bill number must be at most 25 characters")
+)
+
+// Tags of the EMVCo merchant presented QR used when turning a static QRIS
+// into a dynamic one.
+const (
+	qrisTagFormat     = "00"
+	qrisTagInisiasi   = "01"
+	qrisTagJumlah     = "54"
+	qrisTagTambahan   = "62"
+	qrisTagCrc        = "63"
+	qrisSubTagTagihan = "01"
+
+	qrisStatis  = "11"
+	qrisDinamis = "12"
+)
+
+// tlvQris is one tag, length, value data object of a QRIS payload.
+type tlvQris struct {
+	tag   string
+	nilai string
+}
+
+// CrcQris is the CRC-16/CCITT-FALSE checksum (polynomial 0x1021, initial
+// value 0xFFFF) that closes every QRIS payload.
+func CrcQris(data string) uint16 {
+	crc := uint16(0xFFFF)
+	for i := 0; i < len(data); i++ {
+		crc ^= uint16(data[i]) << 8
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+
+	return crc
+}
+
+// parseTlvQris splits a run of data objects; every object is a two digit tag,
+// a two digit length and the value.
+func parseTlvQris(data string) ([]tlvQris, error) {
+	var objek []tlvQris
+	for i := 0; i < len(data); {
+		if i+4 > len(data) {
+			return nil, ErrQrisFormat
+		}
+		panjang, err := strconv.Atoi(data[i+2 : i+4])
+		if err != nil || i+4+panjang > len(data) {
+			return nil, ErrQrisFormat
+		}
+		objek = append(objek, tlvQris{tag: data[i : i+2], nilai: data[i+4 : i+4+panjang]})
+		i += 4 + panjang
+	}
+
+	return objek, nil
+}
+
+func susunTlvQris(objek []tlvQris) string {
+	var b strings.Builder
+	for _, o := range objek {
+		fmt.Fprintf(&b, "%s%02d%s", o.tag, len(o.nilai), o.nilai)
+	}
+
+	return b.String()
+}
+
+// tutupQris appends the CRC object, which is computed over the payload
+// including its own tag and length.
+func tutupQris(payload string) string {
+	payload += qrisTagCrc + "04"
+	return payload + fmt.Sprintf("%04X", CrcQris(payload))
+}
+
+// bacaQris checks a complete QRIS payload and returns its data objects
+// without the CRC.
+func bacaQris(payload string) ([]tlvQris, error) {
+	payload = strings.TrimSpace(payload)
+	if len(payload) < 8 || payload[len(payload)-8:len(payload)-4] != qrisTagCrc+"04" {
+		return nil, ErrQrisFormat
+	}
+
+	objek, err := parseTlvQris(payload[:len(payload)-8])
+	if err != nil {
+		return nil, err
+	}
+	if len(objek) == 0 || objek[0].tag != qrisTagFormat {
+		return nil, ErrQrisFormat
+	}
+
+	crc, err := strconv.ParseUint(payload[len(payload)-4:], 16, 16)
+	if err != nil || uint16(crc) != CrcQris(payload[:len(payload)-4]) {
+		return nil, ErrQrisCrc
+	}
+
+	return objek, nil
+}
+
+// CekQris validates the structure and checksum of a QRIS payload.
+func CekQris(payload string) error {
+	_, err := bacaQris(payload)
+	return err
+}
+
+// QrisDinamis turns the static QRIS of a merchant into the payload to show
+// for one payment. A positive jumlah is embedded as the transaction amount
+// and makes the QR dynamic; referensi, when given, is put in as the bill
+// number. Any amount or bill number already in the static payload is
+// replaced and the CRC is computed again.
+func QrisDinamis(statis string, jumlah int, referensi string) (string, error) {
+	if jumlah < 0 {
+		return "", ErrQrisJumlah
+	}
+	if len(referensi) > 25 {
+		return "", ErrQrisReferensi
+	}
+
+	objek, err := bacaQris(statis)
+	if err != nil {
+		return "", err
+	}
+
+	inisiasi := qrisStatis
+	if jumlah > 0 {
+		inisiasi = qrisDinamis
+	}
+
+	var hasil []tlvQris
+	adaInisiasi, adaTambahan := false, false
+	for _, o := range objek {
+		switch o.tag {
+		case qrisTagInisiasi:
+			adaInisiasi = true
+			o.nilai = inisiasi
+		case qrisTagJumlah:
+			continue
+		case qrisTagTambahan:
+			adaTambahan = true
+			if referensi != "" {
+				if o.nilai, err = isiTagihanQris(o.nilai, referensi); err != nil {
+					return "", err
+				}
+			}
+		}
+		hasil = append(hasil, o)
+	}
+
+	// Tag 01 is optional in a static payload but tells the wallet whether an
+	// embedded amount is fixed
+	if !adaInisiasi {
+		hasil = append(hasil, tlvQris{tag: qrisTagInisiasi, nilai: inisiasi})
+	}
+	if jumlah > 0 {
+		hasil = append(hasil, tlvQris{tag: qrisTagJumlah, nilai: strconv.Itoa(jumlah)})
+	}
+	if !adaTambahan && referensi != "" {
+		hasil = append(hasil, tlvQris{tag: qrisTagTambahan, nilai: susunTlvQris([]tlvQris{{tag: qrisSubTagTagihan, nilai: referensi}})})
+	}
+
+	// Keep the objects in ascending tag order with the payload format first
+	sort.SliceStable(hasil, func(i, j int) bool {
+		return hasil[i].tag < hasil[j].tag
+	})
+
+	return tutupQris(susunTlvQris(hasil)), nil
+}
+
+// isiTagihanQris sets the bill number in the additional data template.
+func isiTagihanQris(tambahan string, referensi string) (string, error) {
+	sub, err := parseTlvQris(tambahan)
+	if err != nil {
+		return "", err
+	}
+
+	ada := false
+	for i := range sub {
+		if sub[i].tag == qrisSubTagTagihan {
+			sub[i].nilai = referensi
+			ada = true
+		}
+	}
+	if !ada {
+		sub = append(sub, tlvQris{tag: qrisSubTagTagihan, nilai: referensi})
+		sort.SliceStable(sub, func(i, j int) bool {
+			return sub[i].tag < sub[j].tag
+		})
+	}
+
+	hasil := susunTlvQris(sub)
+	if len(hasil) > 99 {
+		return "", ErrQrisFormat
+	}
+
+	return hasil, nil
+}
@@ -0,0 +1,137 @@
+package helpers
+
+import (
+	"errors"
+	"testing"
+)
+
+// qrisStatisTest is the static QRIS of a merchant as printed on the counter
+// sticker, and qrisStatisTagihanTest the same merchant with an amount and a
+// bill number already filled in.
+const (
+	qrisStatisTest        = "00020101021126680016ID.CO.TELKOM.WWW011893600898000001234502150001950000123450303UMI51440014ID.CO.QRIS.WWW0215ID10200123456780303UMI5204541153033605802ID5908TOKO YKP6007JAKARTA6105101106304CEE7"
+	qrisStatisTagihanTest = "00020101021126680016ID.CO.TELKOM.WWW011893600898000001234502150001950000123450303UMI51440014ID.CO.QRIS.WWW0215ID10200123456780303UMI520454115303360540450005802ID5908TOKO YKP6007JAKARTA61051011062180104LAMA0706KASIR1630409FD"
+	qrisTanpaInisiasiTest = "00020126680016ID.CO.TELKOM.WWW011893600898000001234502150001950000123450303UMI51440014ID.CO.QRIS.WWW0215ID10200123456780303UMI5204541153033605802ID5908TOKO YKP6007JAKARTA610510110630453C7"
+)
+
+func TestCrcQris(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want uint16
+	}{
+		{name: "vektor standar", data: "123456789", want: 0x29B1},
+		{name: "kosong", data: "", want: 0xFFFF},
+		{name: "payload statis", data: qrisStatisTest[:len(qrisStatisTest)-4], want: 0xCEE7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CrcQris(tt.data); got != tt.want {
+				t.Errorf("CrcQris(%q) = %04X, want %04X", tt.data, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCekQris(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		wantErr error
+	}{
+		{name: "sah", payload: qrisStatisTest},
+		{name: "crc salah", payload: qrisStatisTest[:len(qrisStatisTest)-4] + "CEE8", wantErr: ErrQrisCrc},
+		{name: "isi diubah", payload: "00020101021226" + qrisStatisTest[14:], wantErr: ErrQrisCrc},
+		{name: "tanpa crc", payload: qrisStatisTest[:len(qrisStatisTest)-8], wantErr: ErrQrisFormat},
+		{name: "bukan qris", payload: "bukan qris", wantErr: ErrQrisFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CekQris(tt.payload); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestQrisDinamis(t *testing.T) {
+	tests := []struct {
+		name      string
+		statis    string
+		jumlah    int
+		referensi string
+		want      string
+		wantErr   error
+	}{
+		{
+			name:   "statis jadi dinamis",
+			statis: qrisStatisTest,
+			jumlah: 150000,
+			want:   "00020101021226680016ID.CO.TELKOM.WWW011893600898000001234502150001950000123450303UMI51440014ID.CO.QRIS.WWW0215ID10200123456780303UMI52045411530336054061500005802ID5908TOKO YKP6007JAKARTA6105101106304DC47",
+		},
+		{
+			name:      "jumlah dan nomor tagihan diganti",
+			statis:    qrisStatisTagihanTest,
+			jumlah:    75000,
+			referensi: "FKT-0001",
+			want:      "00020101021226680016ID.CO.TELKOM.WWW011893600898000001234502150001950000123450303UMI51440014ID.CO.QRIS.WWW0215ID10200123456780303UMI5204541153033605405750005802ID5908TOKO YKP6007JAKARTA61051011062220108FKT-00010706KASIR16304A532",
+		},
+		{
+			name:   "tanpa jumlah jumlah lama dibuang",
+			statis: qrisStatisTagihanTest,
+			want:   "00020101021126680016ID.CO.TELKOM.WWW011893600898000001234502150001950000123450303UMI51440014ID.CO.QRIS.WWW0215ID10200123456780303UMI5204541153033605802ID5908TOKO YKP6007JAKARTA61051011062180104LAMA0706KASIR163048192",
+		},
+		{
+			name:      "data tambahan ditambahkan",
+			statis:    qrisStatisTest,
+			referensi: "INV-9",
+			want:      "00020101021126680016ID.CO.TELKOM.WWW011893600898000001234502150001950000123450303UMI51440014ID.CO.QRIS.WWW0215ID10200123456780303UMI5204541153033605802ID5908TOKO YKP6007JAKARTA61051011062090105INV-96304CB5D",
+		},
+		{
+			name:   "tag inisiasi ditambahkan",
+			statis: qrisTanpaInisiasiTest,
+			jumlah: 20000,
+			want:   "00020101021226680016ID.CO.TELKOM.WWW011893600898000001234502150001950000123450303UMI51440014ID.CO.QRIS.WWW0215ID10200123456780303UMI5204541153033605405200005802ID5908TOKO YKP6007JAKARTA610510110630459E0",
+		},
+		{
+			name:    "jumlah negatif",
+			statis:  qrisStatisTest,
+			jumlah:  -1,
+			wantErr: ErrQrisJumlah,
+		},
+		{
+			name:      "referensi terlalu panjang",
+			statis:    qrisStatisTest,
+			jumlah:    1000,
+			referensi: "FKT-0001-0001-0001-0001-01",
+			wantErr:   ErrQrisReferensi,
+		},
+		{
+			name:    "crc statis salah",
+			statis:  qrisStatisTest[:len(qrisStatisTest)-4] + "0000",
+			jumlah:  1000,
+			wantErr: ErrQrisCrc,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := QrisDinamis(tt.statis, tt.jumlah, tt.referensi)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("QrisDinamis() = %q, want %q", got, tt.want)
+			}
+			if err := CekQris(got); err != nil {
+				t.Errorf("CekQris() on the result = %v", err)
+			}
+		})
+	}
+}
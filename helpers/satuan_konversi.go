@@ -0,0 +1,104 @@
+package helpers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const SATUAN_DASAR = "pcs"
+
+// SatuanKonversi is a unit together with the number of base units in it.
+type SatuanKonversi struct {
+	Nama string
+	Isi  int
+}
+
+// JumlahSatuan is a quantity expressed in one unit.
+type JumlahSatuan struct {
+	Nama   string
+	Jumlah int
+}
+
+// UrutkanSatuan sorts units from the largest to the smallest and makes sure
+// the base unit is always present.
+func UrutkanSatuan(satuans []SatuanKonversi) []SatuanKonversi {
+	result := append([]SatuanKonversi(nil), satuans...)
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Isi > result[j].Isi
+	})
+
+	if len(result) == 0 || result[len(result)-1].Isi != 1 {
+		result = append(result, SatuanKonversi{Nama: SATUAN_DASAR, Isi: 1})
+	}
+
+	return result
+}
+
+// CariSatuan looks a unit up by name, ignoring case.
+func CariSatuan(satuans []SatuanKonversi, nama string) (SatuanKonversi, bool) {
+	for _, satuan := range satuans {
+		if strings.EqualFold(satuan.Nama, nama) {
+			return satuan, true
+		}
+	}
+
+	return SatuanKonversi{}, false
+}
+
+// KeSatuanDasar converts quantities entered in any of the units into base
+// units.
+func KeSatuanDasar(satuans []SatuanKonversi, jumlah []JumlahSatuan) (int, error) {
+	total := 0
+	for _, item := range jumlah {
+		satuan, ok := CariSatuan(satuans, item.Nama)
+		if !ok {
+			return 0, fmt.Errorf("unknown unit %s", item.Nama)
+		}
+		total += item.Jumlah * satuan.Isi
+	}
+
+	return total, nil
+}
+
+// UraiJumlah breaks a quantity in base units down over the units, largest
+// first. Units that end up empty are left out.
+func UraiJumlah(satuans []SatuanKonversi, total int) []JumlahSatuan {
+	sisa := total
+	if sisa < 0 {
+		sisa = -sisa
+	}
+
+	var result []JumlahSatuan
+	for _, satuan := range UrutkanSatuan(satuans) {
+		jumlah := sisa / satuan.Isi
+		sisa = sisa % satuan.Isi
+		if jumlah > 0 {
+			result = append(result, JumlahSatuan{Nama: satuan.Nama, Jumlah: jumlah})
+		}
+	}
+
+	return result
+}
+
+// FormatJumlah renders a quantity in base units as, for example,
+// "10 krat 3 lusin 2 pcs".
+func FormatJumlah(satuans []SatuanKonversi, total int) string {
+	urai := UraiJumlah(satuans, total)
+	if len(urai) == 0 {
+		urut := UrutkanSatuan(satuans)
+		return "0 " + urut[len(urut)-1].Nama
+	}
+
+	var parts []string
+	for _, item := range urai {
+		parts = append(parts, fmt.Sprintf("%d %s", item.Jumlah, item.Nama))
+	}
+
+	result := strings.Join(parts, " ")
+	if total < 0 {
+		result = "-" + result
+	}
+
+	return result
+}
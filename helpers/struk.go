@@ -0,0 +1,272 @@
+package helpers
+
+import (
+	"bytes"
+	"strings"
+)
+
+// Struk is a receipt for a thermal printer. Header is centred with the first
+// line printed large. Info, Rincian and Bayar are label and value pairs:
+// Rincian leads up to the bold Total, Bayar follows it with the payment and
+// change. QR is printed as a QR code under the totals when it is set.
+type Struk struct {
+	Header  []string
+	Judul   string
+	Info    [][2]string
+	Baris   []BarisStruk
+	Rincian [][2]string
+	Total   [2]string
+	Bayar   [][2]string
+	Footer  []string
+	QR      string
+}
+
+// BarisStruk is one item on a receipt: its name, a detail line such as the
+// quantity and unit price, and the line amount.
+type BarisStruk struct {
+	Nama    string
+	Rincian string
+	Jumlah  string
+}
+
+// barisCetak is one printed line with its formatting.
+type barisCetak struct {
+	teks   string
+	tengah bool
+	tebal  bool
+	besar  bool
+	qr     bool
+}
+
+// BungkusTeks wraps text on word boundaries to lines of at most lebar
+// characters; words longer than a line are cut.
+func BungkusTeks(teks string, lebar int) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(teks) {
+		for len(word) > lebar {
+			if line != "" {
+				lines = append(lines, line)
+				line = ""
+			}
+			lines = append(lines, word[:lebar])
+			word = word[lebar:]
+		}
+
+		switch {
+		case line == "":
+			line = word
+		case len(line)+1+len(word) <= lebar:
+			line += " " + word
+		default:
+			lines = append(lines, line)
+			line = word
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+// kiriKanan puts a label on the left and a value on the right of one line.
+// A label that does not fit is wrapped, keeping its indent, and the value
+// goes after its last line or on a line of its own.
+func kiriKanan(kiri, kanan string, lebar int) []string {
+	indent := kiri[:len(kiri)-len(strings.TrimLeft(kiri, " "))]
+
+	var lines []string
+	for _, line := range BungkusTeks(kiri, lebar-len(indent)) {
+		lines = append(lines, indent+line)
+	}
+	if len(lines) == 0 {
+		lines = []string{""}
+	}
+
+	last := lines[len(lines)-1]
+	if len(last)+1+len(kanan) <= lebar {
+		lines[len(lines)-1] = last + strings.Repeat(" ", lebar-len(last)-len(kanan)) + kanan
+		return lines
+	}
+
+	for _, value := range BungkusTeks(kanan, lebar) {
+		lines = append(lines, strings.Repeat(" ", lebar-len(value))+value)
+	}
+
+	return lines
+}
+
+// teksStruk drops the characters a thermal printer code page cannot print.
+func teksStruk(teks string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\t' {
+			return ' '
+		}
+		if r < 0x20 || r > 0x7e {
+			return '?'
+		}
+		return r
+	}, teks)
+}
+
+// susun lays the receipt out in lines of the given width.
+func (s Struk) susun(kolom int) []barisCetak {
+	var lines []barisCetak
+	tambah := func(teks []string, format barisCetak) {
+		for _, line := range teks {
+			format.teks = line
+			lines = append(lines, format)
+		}
+	}
+	garis := func() {
+		lines = append(lines, barisCetak{teks: strings.Repeat("-", kolom)})
+	}
+
+	for i, header := range s.Header {
+		if i == 0 {
+			// Double height keeps the width, so the name wraps like any line
+			tambah(BungkusTeks(teksStruk(header), kolom), barisCetak{tengah: true, tebal: true, besar: true})
+			continue
+		}
+		tambah(BungkusTeks(teksStruk(header), kolom), barisCetak{tengah: true})
+	}
+	garis()
+
+	if s.Judul != "" {
+		tambah(BungkusTeks(teksStruk(s.Judul), kolom), barisCetak{tengah: true, tebal: true})
+	}
+	for _, info := range s.Info {
+		tambah(kiriKanan(teksStruk(info[0]), teksStruk(info[1]), kolom), barisCetak{})
+	}
+	garis()
+
+	for _, item := range s.Baris {
+		tambah(BungkusTeks(teksStruk(item.Nama), kolom), barisCetak{})
+		tambah(kiriKanan("  "+teksStruk(item.Rincian), teksStruk(item.Jumlah), kolom), barisCetak{})
+	}
+	garis()
+
+	for _, rincian := range s.Rincian {
+		tambah(kiriKanan(teksStruk(rincian[0]), teksStruk(rincian[1]), kolom), barisCetak{})
+	}
+	tambah(kiriKanan(teksStruk(s.Total[0]), teksStruk(s.Total[1]), kolom), barisCetak{tebal: true})
+	for _, bayar := range s.Bayar {
+		tambah(kiriKanan(teksStruk(bayar[0]), teksStruk(bayar[1]), kolom), barisCetak{})
+	}
+
+	if s.QR != "" {
+		lines = append(lines, barisCetak{}, barisCetak{teks: s.QR, tengah: true, qr: true})
+	}
+
+	if len(s.Footer) > 0 {
+		lines = append(lines, barisCetak{})
+		for _, footer := range s.Footer {
+			tambah(BungkusTeks(teksStruk(footer), kolom), barisCetak{tengah: true})
+		}
+	}
+
+	return lines
+}
+
+// RenderStrukTeks previews a receipt as plain text of the given width, the
+// way it comes out of the printer. The QR code is shown as its content.
+func RenderStrukTeks(struk Struk, kolom int) string {
+	var b strings.Builder
+	for _, line := range struk.susun(kolom) {
+		teks := []string{line.teks}
+		if line.qr {
+			teks = BungkusTeks("[QR] "+teksStruk(line.teks), kolom)
+		}
+
+		for _, t := range teks {
+			if line.tengah && len(t) < kolom {
+				t = strings.Repeat(" ", (kolom-len(t))/2) + t
+			}
+			b.WriteString(strings.TrimRight(t, " "))
+			b.WriteByte('\n')
+		}
+	}
+
+	return b.String()
+}
+
+// ESC/POS commands used on the receipts.
+var (
+	escposInit        = []byte{0x1b, '@'}
+	escposKiri        = []byte{0x1b, 'a', 0}
+	escposTengah      = []byte{0x1b, 'a', 1}
+	escposTebal       = []byte{0x1b, 'E', 1}
+	escposTipis       = []byte{0x1b, 'E', 0}
+	escposBesar       = []byte{0x1d, '!', 0x01}
+	escposNormal      = []byte{0x1d, '!', 0x00}
+	escposFeedPotong  = []byte{0x1b, 'd', 3, 0x1d, 'V', 66, 0}
+	escposQRModel     = []byte{0x1d, '(', 'k', 4, 0, 49, 65, 50, 0}
+	escposQRUkuran    = []byte{0x1d, '(', 'k', 3, 0, 49, 67, 6}
+	escposQRKoreksi   = []byte{0x1d, '(', 'k', 3, 0, 49, 69, 49}
+	escposQRCetak     = []byte{0x1d, '(', 'k', 3, 0, 49, 81, 48}
+	escposQRSimpanFn  = []byte{49, 80, 48}
+	escposBarisKosong = []byte{'\n'}
+)
+
+// escposQRMaksTeks is the most a model 2 QR code can hold.
+const escposQRMaksTeks = 7089
+
+// escposQR prints data as a QR code, model 2 with medium error correction.
+func escposQR(buf *bytes.Buffer, data string) {
+	if len(data) > escposQRMaksTeks {
+		data = data[:escposQRMaksTeks]
+	}
+
+	buf.Write(escposQRModel)
+	buf.Write(escposQRUkuran)
+	buf.Write(escposQRKoreksi)
+
+	size := len(data) + len(escposQRSimpanFn)
+	buf.Write([]byte{0x1d, '(', 'k', byte(size % 256), byte(size / 256)})
+	buf.Write(escposQRSimpanFn)
+	buf.WriteString(data)
+
+	buf.Write(escposQRCetak)
+}
+
+// RenderStrukEscPos renders a receipt as raw ESC/POS bytes for a printer of
+// the given width, ending with a feed and a paper cut.
+func RenderStrukEscPos(struk Struk, kolom int) []byte {
+	var buf bytes.Buffer
+	buf.Write(escposInit)
+
+	for _, line := range struk.susun(kolom) {
+		if line.tengah {
+			buf.Write(escposTengah)
+		} else {
+			buf.Write(escposKiri)
+		}
+
+		if line.qr {
+			escposQR(&buf, line.teks)
+			buf.Write(escposBarisKosong)
+			continue
+		}
+
+		if line.tebal {
+			buf.Write(escposTebal)
+		}
+		if line.besar {
+			buf.Write(escposBesar)
+		}
+		buf.WriteString(strings.TrimRight(line.teks, " "))
+		buf.Write(escposBarisKosong)
+		if line.besar {
+			buf.Write(escposNormal)
+		}
+		if line.tebal {
+			buf.Write(escposTipis)
+		}
+	}
+
+	buf.Write(escposKiri)
+	buf.Write(escposFeedPotong)
+
+	return buf.Bytes()
+}
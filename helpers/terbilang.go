@@ -0,0 +1,78 @@
+package helpers
+
+import "strings"
+
+var angkaSatuan = []string{
+	"", "satu", "dua", "tiga", "empat", "lima", "enam", "tujuh", "delapan", "sembilan",
+	"sepuluh", "sebelas",
+}
+
+// terbilangRatusan spells out 0 to 999; zero gives an empty string.
+func terbilangRatusan(n int) string {
+	var parts []string
+
+	if ratus := n / 100; ratus > 0 {
+		if ratus == 1 {
+			parts = append(parts, "seratus")
+		} else {
+			parts = append(parts, angkaSatuan[ratus], "ratus")
+		}
+		n %= 100
+	}
+
+	switch {
+	case n == 0:
+	case n < 12:
+		parts = append(parts, angkaSatuan[n])
+	case n < 20:
+		parts = append(parts, angkaSatuan[n-10], "belas")
+	default:
+		parts = append(parts, angkaSatuan[n/10], "puluh")
+		if n%10 > 0 {
+			parts = append(parts, angkaSatuan[n%10])
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// Terbilang spells out a whole number in Indonesian, e.g. 1.250.000 becomes
+// "satu juta dua ratus lima puluh ribu". Amounts on documents add "rupiah".
+func Terbilang(n int) string {
+	if n == 0 {
+		return "nol"
+	}
+	if n < 0 {
+		return "minus " + Terbilang(-n)
+	}
+
+	skala := []struct {
+		nilai int
+		nama  string
+	}{
+		{1_000_000_000_000, "triliun"},
+		{1_000_000_000, "miliar"},
+		{1_000_000, "juta"},
+		{1_000, "ribu"},
+	}
+
+	var parts []string
+	for _, s := range skala {
+		if n < s.nilai {
+			continue
+		}
+
+		kelompok := n / s.nilai
+		n %= s.nilai
+		if kelompok == 1 && s.nama == "ribu" {
+			parts = append(parts, "seribu")
+		} else {
+			parts = append(parts, Terbilang(kelompok), s.nama)
+		}
+	}
+	if n > 0 {
+		parts = append(parts, terbilangRatusan(n))
+	}
+
+	return strings.Join(parts, " ")
+}
@@ -61,7 +61,7 @@ func main() {
 		// Repository
 		transaksiRepository repository.TransaksiRepository = repository.NewTransaksiRepository(db)
 		// Service
-		transaksiService service.TransaksiService = service.NewTransaksiService(transaksiRepository, jwtService)
+		transaksiService service.TransaksiService = service.NewTransaksiService(transaksiRepository, loadingRepository, jwtService)
 		// Controller
 		transaksiController controller.TransaksiController = controller.NewTransaksiController(transaksiService)
 
@@ -73,13 +73,102 @@ func main() {
 		// Controller
 		customerController controller.CustomerController = controller.NewCustomerController(customerService)
 
-		// Customer Service
+		// MainSetting Service
 		// Repository
 		mainSettingRepository repository.MainSettingRepository = repository.NewMainSettingRepository(db)
 		// Service
 		mainSettingService service.MainSettingService = service.NewMainSettingService(mainSettingRepository, jwtService)
 		// Controller
 		mainSettingController controller.MainSettingController = controller.NewMainSettingController(mainSettingService)
+
+		// Faktur Service
+		// Repository
+		fakturRepository repository.FakturRepository = repository.NewFakturRepository(db)
+		// Service
+		fakturService service.FakturService = service.NewFakturService(fakturRepository, mainSettingRepository, jwtService)
+		// Controller
+		fakturController controller.FakturController = controller.NewFakturController(fakturService)
+
+		// NomorDokumen Service
+		// Repository
+		nomorDokumenRepository repository.NomorDokumenRepository = repository.NewNomorDokumenRepository(db)
+		// Service
+		nomorDokumenService service.NomorDokumenService = service.NewNomorDokumenService(nomorDokumenRepository, jwtService)
+		// Controller
+		nomorDokumenController controller.NomorDokumenController = controller.NewNomorDokumenController(nomorDokumenService)
+
+		// Setoran Service
+		// Repository
+		setoranRepository repository.SetoranRepository = repository.NewSetoranRepository(db)
+		// Service
+		setoranService service.SetoranService = service.NewSetoranService(setoranRepository, jwtService)
+		// Controller
+		setoranController controller.SetoranController = controller.NewSetoranController(setoranService)
+
+		// Pembayaran Service
+		// Repository
+		pembayaranRepository repository.PembayaranRepository = repository.NewPembayaranRepository(db)
+		// Service
+		pembayaranService service.PembayaranService = service.NewPembayaranService(pembayaranRepository, jwtService)
+		// Controller
+		pembayaranController controller.PembayaranController = controller.NewPembayaranController(pembayaranService)
+
+		// Laporan Service
+		// Repository
+		laporanRepository repository.LaporanRepository = repository.NewLaporanRepository(db)
+		// Service
+		laporanService service.LaporanService = service.NewLaporanService(laporanRepository, jwtService)
+		// Controller
+		laporanController controller.LaporanController = controller.NewLaporanController(laporanService)
+
+		// Retur Service
+		// Repository
+		returRepository repository.ReturRepository = repository.NewReturRepository(db)
+		// Service
+		returService service.ReturService = service.NewReturService(returRepository, jwtService)
+		// Controller
+		returController controller.ReturController = controller.NewReturController(returService)
+
+		// Shift Kasir Service
+		// Repository
+		shiftKasirRepository repository.ShiftKasirRepository = repository.NewShiftKasirRepository(db)
+		// Service
+		shiftKasirService service.ShiftKasirService = service.NewShiftKasirService(shiftKasirRepository, jwtService)
+		// Controller
+		shiftKasirController controller.ShiftKasirController = controller.NewShiftKasirController(shiftKasirService)
+
+		// Penjualan Service
+		// Repository
+		penjualanRepository repository.PenjualanRepository = repository.NewPenjualanRepository(db)
+		// Service
+		penjualanService service.PenjualanService = service.NewPenjualanService(penjualanRepository, mainSettingRepository, jwtService)
+		// Controller
+		penjualanController controller.PenjualanController = controller.NewPenjualanController(penjualanService)
+
+		// Gateway Service
+		paymentGateway service.PaymentGateway = service.NewMidtransGateway()
+		// Repository
+		transaksiGatewayRepository repository.TransaksiGatewayRepository = repository.NewTransaksiGatewayRepository(db)
+		// Service
+		gatewayService service.GatewayService = service.NewGatewayService(transaksiGatewayRepository, paymentGateway, jwtService)
+		// Controller
+		gatewayController controller.GatewayController = controller.NewGatewayController(gatewayService)
+
+		// Supplier Service
+		// Repository
+		supplierRepository repository.SupplierRepository = repository.NewSupplierRepository(db)
+		// Service
+		supplierService service.SupplierService = service.NewSupplierService(supplierRepository, jwtService)
+		// Controller
+		supplierController controller.SupplierController = controller.NewSupplierController(supplierService)
+
+		// Purchase Order Service
+		// Repository
+		purchaseOrderRepository repository.PurchaseOrderRepository = repository.NewPurchaseOrderRepository(db)
+		// Service
+		purchaseOrderService service.PurchaseOrderService = service.NewPurchaseOrderService(purchaseOrderRepository, jwtService)
+		// Controller
+		purchaseOrderController controller.PurchaseOrderController = controller.NewPurchaseOrderController(purchaseOrderService)
 	)
 
 	server := fiber.New()
@@ -94,6 +183,17 @@ func main() {
 	routes.Transaksi(apiGroup, transaksiController, jwtService)
 	routes.Customer(apiGroup, customerController, jwtService)
 	routes.MainSetting(apiGroup, mainSettingController, jwtService)
+	routes.Faktur(apiGroup, fakturController, jwtService)
+	routes.NomorDokumen(apiGroup, nomorDokumenController, jwtService)
+	routes.Setoran(apiGroup, setoranController, jwtService)
+	routes.Pembayaran(apiGroup, pembayaranController, jwtService)
+	routes.Laporan(apiGroup, laporanController, jwtService)
+	routes.Retur(apiGroup, returController, jwtService)
+	routes.ShiftKasir(apiGroup, shiftKasirController, jwtService)
+	routes.Penjualan(apiGroup, penjualanController, jwtService)
+	routes.Gateway(apiGroup, gatewayController, jwtService)
+	routes.Supplier(apiGroup, supplierController, jwtService)
+	routes.PurchaseOrder(apiGroup, purchaseOrderController, jwtService)
 
 	server.Static("/assets", "./assets")
 
@@ -36,8 +36,14 @@ func Authenticate(jwtService service.JWTService) fiber.Handler {
 			response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, err.Error(), nil)
 			return ctx.Status(http.StatusUnauthorized).JSON(response)
 		}
+		role, err := jwtService.GetRoleByToken(authHeader)
+		if err != nil {
+			response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, err.Error(), nil)
+			return ctx.Status(http.StatusUnauthorized).JSON(response)
+		}
 		ctx.Locals("token", authHeader)
 		ctx.Locals("user_id", userId)
+		ctx.Locals("role", role)
 		return ctx.Next()
 	}
 }
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jejevj/ykp_pos/constants"
+	"github.com/jejevj/ykp_pos/dto"
+	"github.com/jejevj/ykp_pos/utils"
+)
+
+// Authorize only lets users with one of the given roles through. It must run
+// after Authenticate, which puts the role of the token in ctx.Locals. The
+// super role is allowed everywhere.
+func Authorize(roles ...string) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		role, _ := ctx.Locals("role").(string)
+		if role == constants.ENUM_ROLE_SU {
+			return ctx.Next()
+		}
+
+		for _, allowed := range roles {
+			if role == allowed {
+				return ctx.Next()
+			}
+		}
+
+		response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, dto.MESSAGE_FAILED_DENIED_ACCESS, nil)
+		return ctx.Status(http.StatusForbidden).JSON(response)
+	}
+}
@@ -3,14 +3,12 @@ package migrations
 import (
 	"log"
 
-	"github.com/jejevj/ykp_pos/entity"
 	"gorm.io/gorm"
 )
 
 func dropAllTables(db *gorm.DB) error {
-	if err := db.Migrator().DropTable(
-		&entity.User{},
-	); err != nil {
+	tables := append([]interface{}{&SchemaMigration{}}, Entities...)
+	if err := db.Migrator().DropTable(tables...); err != nil {
 		return err
 	}
 
@@ -18,13 +16,15 @@ func dropAllTables(db *gorm.DB) error {
 	return nil
 }
 
+// Fresh drops every table and rebuilds the schema from the versioned
+// migrations.
 func Fresh(db *gorm.DB) error {
 	if err := dropAllTables(db); err != nil {
 		log.Printf("Error dropping tables: %v", err)
 		return err
 	}
 
-	if err := Migrate(db); err != nil {
+	if _, err := MigrateUp(db); err != nil {
 		log.Printf("Error during migration: %v", err)
 		return err
 	}
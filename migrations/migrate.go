@@ -7,6 +7,47 @@ import (
 	"gorm.io/gorm"
 )
 
+// Entities lists every table of the application. Migrate creates them with
+// AutoMigrate for development databases and Fresh drops them all.
+var Entities = []interface{}{
+	&entity.User{},
+	&entity.Satuan{},
+	&entity.Barang{},
+	&entity.BarangSatuan{},
+	&entity.Customer{},
+	&entity.Pelanggan{},
+	&entity.MainSetting{},
+	&entity.NomorDokumen{},
+	&entity.Loading{},
+	&entity.Transaksi{},
+	&entity.Faktur{},
+	&entity.TransaksiFaktur{},
+	&entity.StockMovement{},
+	&entity.Setoran{},
+	&entity.SetoranDetail{},
+	&entity.KekuranganDriver{},
+	&entity.Pembayaran{},
+	&entity.KreditCustomer{},
+	&entity.OverrideKredit{},
+	&entity.Retur{},
+	&entity.TransaksiRetur{},
+	&entity.NotaKredit{},
+	&entity.ShiftKasir{},
+	&entity.KasShift{},
+	&entity.Penjualan{},
+	&entity.TransaksiPenjualan{},
+	&entity.PembayaranPenjualan{},
+	&entity.TransaksiGateway{},
+	&entity.Supplier{},
+	&entity.PurchaseOrder{},
+	&entity.TransaksiPurchaseOrder{},
+	&entity.PenerimaanBarang{},
+	&entity.TransaksiPenerimaan{},
+}
+
+// Migrate brings a development database up to date with AutoMigrate. Shared
+// and production databases use the versioned files of MigrateUp instead; the
+// gorm tags of the entities mirror those files, so both give the same columns.
 func Migrate(db *gorm.DB) error {
 	queries := []string{
 		`CREATE EXTENSION IF NOT EXISTS "uuid-ossp";`,
@@ -21,17 +62,19 @@ func Migrate(db *gorm.DB) error {
 		}
 	}
 
-	if err := db.AutoMigrate(
-		// &entity.User{},
-		// &entity.Satuan{},
-		// &entity.Barang{},
-		// &entity.Loading{},
-		&entity.Transaksi{},
-		// &entity.Customer{},
-		// &entity.MainSetting{},
-	); err != nil {
+	if err := db.AutoMigrate(Entities...); err != nil {
 		return err
 	}
 
+	// Loadings approved before the status field existed keep their state
+	if db.Migrator().HasColumn(&entity.Loading{}, "is_approved") {
+		if err := db.Exec(`UPDATE loadings SET status = 'approved' WHERE is_approved = true AND status = 'draft'`).Error; err != nil {
+			return err
+		}
+		if err := db.Migrator().DropColumn(&entity.Loading{}, "is_approved"); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
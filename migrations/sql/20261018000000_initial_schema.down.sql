@@ -0,0 +1,16 @@
+DROP TABLE IF EXISTS kekurangan_drivers;
+DROP TABLE IF EXISTS setoran_details;
+DROP TABLE IF EXISTS setorans;
+DROP TABLE IF EXISTS stock_movements;
+DROP TABLE IF EXISTS transaksi_fakturs;
+DROP TABLE IF EXISTS fakturs;
+DROP TABLE IF EXISTS transaksis;
+DROP TABLE IF EXISTS loadings;
+DROP TABLE IF EXISTS nomor_dokumens;
+DROP TABLE IF EXISTS main_settings;
+DROP TABLE IF EXISTS pelanggans;
+DROP TABLE IF EXISTS customers;
+DROP TABLE IF EXISTS barang_satuans;
+DROP TABLE IF EXISTS barangs;
+DROP TABLE IF EXISTS satuans;
+DROP TABLE IF EXISTS users;
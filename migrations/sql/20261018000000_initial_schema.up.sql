@@ -0,0 +1,255 @@
+-- Baseline schema, identical to what the former AutoMigrate produced, so it
+-- can be applied to an existing database as well as to an empty one.
+
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+CREATE TABLE IF NOT EXISTS users (
+  id uuid DEFAULT uuid_generate_v4(),
+  name text,
+  telp_number text,
+  email text,
+  password text,
+  role text,
+  image_url text,
+  is_verified boolean,
+  created_at timestamp with time zone,
+  updated_at timestamp with time zone,
+  deleted_at timestamptz,
+  PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS satuans (
+  id uuid DEFAULT uuid_generate_v4(),
+  nama_satuan text,
+  value bigint,
+  created_at timestamp with time zone,
+  updated_at timestamp with time zone,
+  deleted_at timestamptz,
+  PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS barangs (
+  id uuid DEFAULT uuid_generate_v4(),
+  nama_barang text,
+  kode_barang text,
+  harga_beli bigint,
+  harga_jual bigint,
+  id_satuan uuid,
+  jumlah_krat bigint,
+  jumlah_satuan bigint,
+  stok bigint,
+  created_at timestamp with time zone,
+  updated_at timestamp with time zone,
+  deleted_at timestamptz,
+  PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS barang_satuans (
+  id uuid DEFAULT uuid_generate_v4(),
+  id_barang uuid,
+  nama_satuan text,
+  isi bigint,
+  urutan bigint,
+  created_at timestamp with time zone,
+  updated_at timestamp with time zone,
+  deleted_at timestamptz,
+  PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_barang_satuans_id_barang ON barang_satuans (id_barang);
+
+CREATE TABLE IF NOT EXISTS customers (
+  id uuid DEFAULT uuid_generate_v4(),
+  nama_toko text,
+  nama_pemilik text,
+  alamat text,
+  hp text,
+  created_at timestamp with time zone,
+  updated_at timestamp with time zone,
+  deleted_at timestamptz,
+  PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS pelanggans (
+  id uuid DEFAULT uuid_generate_v4(),
+  no_pelanggan text,
+  nama_pelanggan text,
+  alamat text,
+  telp text,
+  npwp text,
+  created_at timestamp with time zone,
+  updated_at timestamp with time zone,
+  deleted_at timestamptz,
+  PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS main_settings (
+  id uuid DEFAULT uuid_generate_v4(),
+  nama_usaha text,
+  jenis_usaha text,
+  alamat text,
+  hp text,
+  logo_url text,
+  prefix_faktur text DEFAULT 'INV',
+  prefix_loading text DEFAULT 'LD',
+  prefix_retur text DEFAULT 'RTR',
+  format_nomor text DEFAULT '{PREFIX}/{YYYY}/{MM}/{SEQ}',
+  panjang_nomor bigint DEFAULT 6,
+  reset_nomor text DEFAULT 'bulanan',
+  created_at timestamp with time zone,
+  updated_at timestamp with time zone,
+  deleted_at timestamptz,
+  PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS nomor_dokumens (
+  id uuid DEFAULT uuid_generate_v4(),
+  jenis text,
+  periode text,
+  nomor bigint,
+  created_at timestamp with time zone,
+  updated_at timestamp with time zone,
+  deleted_at timestamptz,
+  PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_nomor_dokumen_jenis_periode ON nomor_dokumens (jenis,periode);
+
+CREATE TABLE IF NOT EXISTS loadings (
+  id uuid DEFAULT uuid_generate_v4(),
+  no_loading text,
+  id_user uuid,
+  status text DEFAULT 'draft',
+  submitted_at timestamptz,
+  submitted_by text,
+  approved_at timestamptz,
+  approved_by text,
+  dispatched_at timestamptz,
+  dispatched_by text,
+  returned_at timestamptz,
+  returned_by text,
+  settled_at timestamptz,
+  settled_by text,
+  created_at timestamp with time zone,
+  updated_at timestamp with time zone,
+  deleted_at timestamptz,
+  PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_loadings_status ON loadings (status);
+
+CREATE TABLE IF NOT EXISTS transaksis (
+  id uuid DEFAULT uuid_generate_v4(),
+  id_loading uuid,
+  id_barang uuid,
+  jumlah bigint,
+  created_at timestamp with time zone,
+  updated_at timestamp with time zone,
+  deleted_at timestamptz,
+  PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS fakturs (
+  id uuid DEFAULT uuid_generate_v4(),
+  no_faktur text,
+  tanggal_faktur timestamptz,
+  tanggal_tempo timestamptz,
+  cara_bayar text,
+  id_customer uuid,
+  id_user uuid,
+  id_loading text,
+  status text DEFAULT 'belum_bayar',
+  total bigint,
+  keterangan text,
+  created_at timestamp with time zone,
+  updated_at timestamp with time zone,
+  deleted_at timestamptz,
+  PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_fakturs_no_faktur ON fakturs (no_faktur);
+
+CREATE TABLE IF NOT EXISTS transaksi_fakturs (
+  id uuid DEFAULT uuid_generate_v4(),
+  id_faktur uuid,
+  id_barang uuid,
+  krat bigint,
+  lusin bigint,
+  satuan bigint,
+  jumlah bigint,
+  jumlah_rp bigint,
+  diskon bigint,
+  diskon_p decimal,
+  ket text,
+  created_at timestamp with time zone,
+  updated_at timestamp with time zone,
+  deleted_at timestamptz,
+  PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS stock_movements (
+  id uuid DEFAULT uuid_generate_v4(),
+  id_barang uuid,
+  tanggal timestamp with time zone,
+  jumlah bigint,
+  alasan text,
+  ref_tipe text,
+  ref_id text,
+  ref_no text,
+  id_user text,
+  saldo bigint,
+  keterangan text,
+  created_at timestamp with time zone,
+  updated_at timestamp with time zone,
+  deleted_at timestamptz,
+  PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_stock_movement_ref ON stock_movements (ref_tipe,ref_id);
+CREATE INDEX IF NOT EXISTS idx_stock_movements_tanggal ON stock_movements (tanggal);
+CREATE INDEX IF NOT EXISTS idx_stock_movements_id_barang ON stock_movements (id_barang);
+
+CREATE TABLE IF NOT EXISTS setorans (
+  id uuid DEFAULT uuid_generate_v4(),
+  id_loading uuid,
+  id_user uuid,
+  id_petugas text,
+  tanggal timestamptz,
+  total_tagihan bigint,
+  total_tunai bigint,
+  total_transfer bigint,
+  selisih_uang bigint,
+  keterangan text,
+  created_at timestamp with time zone,
+  updated_at timestamp with time zone,
+  deleted_at timestamptz,
+  PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_setorans_id_loading ON setorans (id_loading);
+
+CREATE TABLE IF NOT EXISTS setoran_details (
+  id uuid DEFAULT uuid_generate_v4(),
+  id_setoran uuid,
+  id_barang uuid,
+  jumlah_muat bigint,
+  jumlah_terjual bigint,
+  jumlah_kembali bigint,
+  selisih bigint,
+  created_at timestamp with time zone,
+  updated_at timestamp with time zone,
+  deleted_at timestamptz,
+  PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_setoran_details_id_setoran ON setoran_details (id_setoran);
+
+CREATE TABLE IF NOT EXISTS kekurangan_drivers (
+  id uuid DEFAULT uuid_generate_v4(),
+  id_setoran uuid,
+  id_user text,
+  jenis text,
+  id_barang text,
+  jumlah bigint,
+  nilai bigint,
+  keterangan text,
+  created_at timestamp with time zone,
+  updated_at timestamp with time zone,
+  deleted_at timestamptz,
+  PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_kekurangan_drivers_id_user ON kekurangan_drivers (id_user);
+CREATE INDEX IF NOT EXISTS idx_kekurangan_drivers_id_setoran ON kekurangan_drivers (id_setoran);
@@ -0,0 +1,3 @@
+ALTER TABLE fakturs DROP COLUMN IF EXISTS total_bayar;
+DROP TABLE IF EXISTS kredit_customers;
+DROP TABLE IF EXISTS pembayarans;
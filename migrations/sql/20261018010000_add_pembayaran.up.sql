@@ -0,0 +1,33 @@
+-- Payments against a faktur and the customer credit from overpayments.
+
+CREATE TABLE IF NOT EXISTS pembayarans (
+  id uuid DEFAULT uuid_generate_v4(),
+  id_faktur uuid,
+  jumlah bigint,
+  metode text,
+  tanggal_bayar timestamptz,
+  referensi text,
+  id_user uuid,
+  keterangan text,
+  created_at timestamp with time zone,
+  updated_at timestamp with time zone,
+  deleted_at timestamptz,
+  PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_pembayarans_id_faktur ON pembayarans (id_faktur);
+
+CREATE TABLE IF NOT EXISTS kredit_customers (
+  id uuid DEFAULT uuid_generate_v4(),
+  id_customer text,
+  id_pembayaran text,
+  jumlah bigint,
+  keterangan text,
+  created_at timestamp with time zone,
+  updated_at timestamp with time zone,
+  deleted_at timestamptz,
+  PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_kredit_customers_id_customer ON kredit_customers (id_customer);
+CREATE INDEX IF NOT EXISTS idx_kredit_customers_id_pembayaran ON kredit_customers (id_pembayaran);
+
+ALTER TABLE fakturs ADD COLUMN IF NOT EXISTS total_bayar bigint DEFAULT 0;
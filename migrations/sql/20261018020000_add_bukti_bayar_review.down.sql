@@ -0,0 +1,8 @@
+ALTER TABLE pembayarans DROP COLUMN IF EXISTS tanggal_review;
+ALTER TABLE pembayarans DROP COLUMN IF EXISTS id_reviewer;
+ALTER TABLE pembayarans DROP COLUMN IF EXISTS alasan_tolak;
+ALTER TABLE pembayarans DROP COLUMN IF EXISTS status_bukti;
+ALTER TABLE pembayarans DROP COLUMN IF EXISTS bukti_bayar;
+ALTER TABLE pembayarans DROP COLUMN IF EXISTS kelebihan;
+
+ALTER TABLE fakturs DROP COLUMN IF EXISTS bukti_bayar;
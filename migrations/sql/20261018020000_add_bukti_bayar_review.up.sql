@@ -0,0 +1,15 @@
+-- Payment proofs are stored as a file path and reviewed by an admin before a
+-- payment counts toward its faktur.
+
+ALTER TABLE fakturs ADD COLUMN IF NOT EXISTS bukti_bayar text;
+
+ALTER TABLE pembayarans ADD COLUMN IF NOT EXISTS kelebihan bigint DEFAULT 0;
+ALTER TABLE pembayarans ADD COLUMN IF NOT EXISTS bukti_bayar text;
+ALTER TABLE pembayarans ADD COLUMN IF NOT EXISTS status_bukti text;
+ALTER TABLE pembayarans ADD COLUMN IF NOT EXISTS alasan_tolak text;
+ALTER TABLE pembayarans ADD COLUMN IF NOT EXISTS id_reviewer text;
+ALTER TABLE pembayarans ADD COLUMN IF NOT EXISTS tanggal_review timestamptz;
+
+-- Payments recorded before the review step already count toward their faktur
+UPDATE pembayarans SET status_bukti = 'diterima' WHERE status_bukti IS NULL;
+ALTER TABLE pembayarans ALTER COLUMN status_bukti SET DEFAULT 'pending';
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS override_kredits;
+
+ALTER TABLE fakturs DROP COLUMN IF EXISTS id_override_kredit;
+
+ALTER TABLE customers DROP COLUMN IF EXISTS max_hari_tempo;
+ALTER TABLE customers DROP COLUMN IF EXISTS limit_kredit;
@@ -0,0 +1,20 @@
+-- Credit limits per customer and the admin overrides that release a block.
+
+ALTER TABLE customers ADD COLUMN IF NOT EXISTS limit_kredit bigint DEFAULT 0;
+ALTER TABLE customers ADD COLUMN IF NOT EXISTS max_hari_tempo bigint DEFAULT 0;
+
+ALTER TABLE fakturs ADD COLUMN IF NOT EXISTS id_override_kredit text;
+
+CREATE TABLE IF NOT EXISTS override_kredits (
+  id uuid DEFAULT uuid_generate_v4(),
+  id_customer text,
+  alasan text,
+  id_user uuid,
+  berlaku_sampai timestamptz,
+  id_faktur text,
+  created_at timestamp with time zone,
+  updated_at timestamp with time zone,
+  deleted_at timestamptz,
+  PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_override_kredits_id_customer ON override_kredits (id_customer);
@@ -0,0 +1,14 @@
+ALTER TABLE transaksi_fakturs DROP COLUMN IF EXISTS ppn;
+ALTER TABLE transaksi_fakturs DROP COLUMN IF EXISTS dpp;
+
+ALTER TABLE fakturs DROP COLUMN IF EXISTS eceran;
+ALTER TABLE fakturs DROP COLUMN IF EXISTS ppn;
+ALTER TABLE fakturs DROP COLUMN IF EXISTS dpp;
+ALTER TABLE fakturs DROP COLUMN IF EXISTS harga_termasuk_ppn;
+ALTER TABLE fakturs DROP COLUMN IF EXISTS tarif_ppn;
+
+ALTER TABLE customers DROP COLUMN IF EXISTS npwp;
+
+ALTER TABLE main_settings DROP COLUMN IF EXISTS harga_termasuk_ppn;
+ALTER TABLE main_settings DROP COLUMN IF EXISTS tarif_ppn;
+ALTER TABLE main_settings DROP COLUMN IF EXISTS npwp;
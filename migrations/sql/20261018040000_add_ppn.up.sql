@@ -0,0 +1,20 @@
+-- PPN settings and the DPP/PPN breakdown of each faktur and its items.
+
+ALTER TABLE main_settings ADD COLUMN IF NOT EXISTS npwp text;
+ALTER TABLE main_settings ADD COLUMN IF NOT EXISTS tarif_ppn decimal DEFAULT 0;
+ALTER TABLE main_settings ADD COLUMN IF NOT EXISTS harga_termasuk_ppn boolean DEFAULT false;
+
+ALTER TABLE customers ADD COLUMN IF NOT EXISTS npwp text;
+
+ALTER TABLE fakturs ADD COLUMN IF NOT EXISTS tarif_ppn decimal DEFAULT 0;
+ALTER TABLE fakturs ADD COLUMN IF NOT EXISTS harga_termasuk_ppn boolean;
+ALTER TABLE fakturs ADD COLUMN IF NOT EXISTS dpp bigint DEFAULT 0;
+ALTER TABLE fakturs ADD COLUMN IF NOT EXISTS ppn bigint DEFAULT 0;
+ALTER TABLE fakturs ADD COLUMN IF NOT EXISTS eceran boolean DEFAULT false;
+
+ALTER TABLE transaksi_fakturs ADD COLUMN IF NOT EXISTS dpp bigint DEFAULT 0;
+ALTER TABLE transaksi_fakturs ADD COLUMN IF NOT EXISTS ppn bigint DEFAULT 0;
+
+-- Fakturs issued before PPN was recorded carry no tax, their whole total is DPP
+UPDATE fakturs SET dpp = total WHERE dpp = 0 AND ppn = 0;
+UPDATE transaksi_fakturs SET dpp = jumlah_rp WHERE dpp = 0 AND ppn = 0;
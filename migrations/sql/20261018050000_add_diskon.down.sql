@@ -0,0 +1,9 @@
+ALTER TABLE fakturs DROP COLUMN IF EXISTS potongan_diskon;
+ALTER TABLE fakturs DROP COLUMN IF EXISTS diskon_p;
+ALTER TABLE fakturs DROP COLUMN IF EXISTS diskon;
+ALTER TABLE fakturs DROP COLUMN IF EXISTS subtotal;
+
+ALTER TABLE transaksi_fakturs DROP COLUMN IF EXISTS diskon_faktur;
+ALTER TABLE transaksi_fakturs DROP COLUMN IF EXISTS harga;
+
+ALTER TABLE main_settings DROP COLUMN IF EXISTS urutan_diskon;
@@ -0,0 +1,18 @@
+-- Server side pricing: unit price per line, invoice discount and the order in
+-- which discounts are applied.
+
+ALTER TABLE main_settings ADD COLUMN IF NOT EXISTS urutan_diskon text DEFAULT 'persen_nominal';
+
+ALTER TABLE transaksi_fakturs ADD COLUMN IF NOT EXISTS harga bigint DEFAULT 0;
+ALTER TABLE transaksi_fakturs ADD COLUMN IF NOT EXISTS diskon_faktur bigint DEFAULT 0;
+
+ALTER TABLE fakturs ADD COLUMN IF NOT EXISTS subtotal bigint DEFAULT 0;
+ALTER TABLE fakturs ADD COLUMN IF NOT EXISTS diskon bigint DEFAULT 0;
+ALTER TABLE fakturs ADD COLUMN IF NOT EXISTS diskon_p decimal DEFAULT 0;
+ALTER TABLE fakturs ADD COLUMN IF NOT EXISTS potongan_diskon bigint DEFAULT 0;
+
+-- Older fakturs had no invoice discount, their subtotal is the sum of the lines
+UPDATE fakturs SET subtotal = (
+  SELECT COALESCE(SUM(t.jumlah_rp), 0) FROM transaksi_fakturs t
+  WHERE t.id_faktur = fakturs.id AND t.deleted_at IS NULL
+) WHERE subtotal = 0;
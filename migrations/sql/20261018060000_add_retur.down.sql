@@ -0,0 +1,10 @@
+ALTER TABLE main_settings DROP COLUMN IF EXISTS prefix_nota_kredit;
+
+ALTER TABLE barangs DROP COLUMN IF EXISTS stok_rusak;
+
+DROP INDEX IF EXISTS idx_kredit_customers_id_nota_kredit;
+ALTER TABLE kredit_customers DROP COLUMN IF EXISTS id_nota_kredit;
+
+DROP TABLE IF EXISTS nota_kredits;
+DROP TABLE IF EXISTS transaksi_returs;
+DROP TABLE IF EXISTS returs;
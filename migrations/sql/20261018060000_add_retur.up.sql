@@ -0,0 +1,70 @@
+-- Sales returns, their credit notes and the write-off bucket for damaged goods.
+
+CREATE TABLE IF NOT EXISTS returs (
+  id uuid DEFAULT uuid_generate_v4(),
+  no_retur text,
+  tanggal timestamptz,
+  id_faktur uuid,
+  id_customer uuid,
+  id_user uuid,
+  total bigint,
+  keterangan text,
+  created_at timestamp with time zone,
+  updated_at timestamp with time zone,
+  deleted_at timestamptz,
+  PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_returs_no_retur ON returs (no_retur);
+CREATE INDEX IF NOT EXISTS idx_returs_id_faktur ON returs (id_faktur);
+CREATE INDEX IF NOT EXISTS idx_returs_id_customer ON returs (id_customer);
+
+CREATE TABLE IF NOT EXISTS transaksi_returs (
+  id uuid DEFAULT uuid_generate_v4(),
+  id_retur uuid,
+  id_transaksi_faktur text,
+  id_barang uuid,
+  krat bigint,
+  lusin bigint,
+  satuan bigint,
+  jumlah bigint,
+  kondisi text,
+  jumlah_rp bigint,
+  dpp bigint,
+  ppn bigint,
+  ket text,
+  created_at timestamp with time zone,
+  updated_at timestamp with time zone,
+  deleted_at timestamptz,
+  PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_transaksi_returs_id_retur ON transaksi_returs (id_retur);
+CREATE INDEX IF NOT EXISTS idx_transaksi_returs_id_transaksi_faktur ON transaksi_returs (id_transaksi_faktur);
+
+CREATE TABLE IF NOT EXISTS nota_kredits (
+  id uuid DEFAULT uuid_generate_v4(),
+  no_nota_kredit text,
+  tanggal timestamptz,
+  id_retur uuid,
+  id_faktur text,
+  id_customer text,
+  dpp bigint,
+  ppn bigint,
+  total bigint,
+  potong_faktur bigint,
+  kredit bigint,
+  created_at timestamp with time zone,
+  updated_at timestamp with time zone,
+  deleted_at timestamptz,
+  PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_nota_kredits_no_nota_kredit ON nota_kredits (no_nota_kredit);
+CREATE INDEX IF NOT EXISTS idx_nota_kredits_id_retur ON nota_kredits (id_retur);
+CREATE INDEX IF NOT EXISTS idx_nota_kredits_id_faktur ON nota_kredits (id_faktur);
+CREATE INDEX IF NOT EXISTS idx_nota_kredits_id_customer ON nota_kredits (id_customer);
+
+ALTER TABLE kredit_customers ADD COLUMN IF NOT EXISTS id_nota_kredit text;
+CREATE INDEX IF NOT EXISTS idx_kredit_customers_id_nota_kredit ON kredit_customers (id_nota_kredit);
+
+ALTER TABLE barangs ADD COLUMN IF NOT EXISTS stok_rusak bigint DEFAULT 0;
+
+ALTER TABLE main_settings ADD COLUMN IF NOT EXISTS prefix_nota_kredit text DEFAULT 'NK';
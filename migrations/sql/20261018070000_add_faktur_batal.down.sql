@@ -0,0 +1,3 @@
+ALTER TABLE fakturs DROP COLUMN IF EXISTS tanggal_batal;
+ALTER TABLE fakturs DROP COLUMN IF EXISTS id_user_batal;
+ALTER TABLE fakturs DROP COLUMN IF EXISTS alasan_batal;
@@ -0,0 +1,13 @@
+-- Fakturs are voided instead of deleted, so every number stays accounted for.
+
+ALTER TABLE fakturs ADD COLUMN IF NOT EXISTS alasan_batal text;
+ALTER TABLE fakturs ADD COLUMN IF NOT EXISTS id_user_batal text;
+ALTER TABLE fakturs ADD COLUMN IF NOT EXISTS tanggal_batal timestamptz;
+
+-- Bring back fakturs deleted before, their goods were already returned to stock
+UPDATE fakturs SET
+  status = 'batal',
+  alasan_batal = 'dihapus',
+  tanggal_batal = deleted_at,
+  deleted_at = NULL
+WHERE deleted_at IS NOT NULL;
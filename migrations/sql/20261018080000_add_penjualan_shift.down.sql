@@ -0,0 +1,6 @@
+ALTER TABLE main_settings DROP COLUMN IF EXISTS prefix_penjualan;
+
+DROP TABLE IF EXISTS transaksi_penjualans;
+DROP TABLE IF EXISTS penjualans;
+DROP TABLE IF EXISTS kas_shifts;
+DROP TABLE IF EXISTS shift_kasirs;
@@ -0,0 +1,94 @@
+-- Counter sales rung up on cashier shifts.
+
+CREATE TABLE IF NOT EXISTS shift_kasirs (
+  id uuid DEFAULT uuid_generate_v4(),
+  id_user uuid,
+  status text DEFAULT 'buka',
+  waktu_buka timestamp with time zone,
+  waktu_tutup timestamp with time zone,
+  modal_awal bigint,
+  jumlah_penjualan bigint,
+  total_penjualan bigint,
+  total_tunai bigint,
+  kas_masuk bigint,
+  kas_keluar bigint,
+  kas_diharapkan bigint,
+  kas_dihitung bigint,
+  selisih bigint,
+  keterangan text,
+  created_at timestamp with time zone,
+  updated_at timestamp with time zone,
+  deleted_at timestamptz,
+  PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_shift_kasirs_id_user ON shift_kasirs (id_user);
+CREATE INDEX IF NOT EXISTS idx_shift_kasirs_status ON shift_kasirs (status);
+-- A cashier can only have one open shift
+CREATE UNIQUE INDEX IF NOT EXISTS idx_shift_kasirs_id_user_buka ON shift_kasirs (id_user) WHERE status = 'buka' AND deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS kas_shifts (
+  id uuid DEFAULT uuid_generate_v4(),
+  id_shift uuid,
+  jenis text,
+  jumlah bigint,
+  waktu timestamp with time zone,
+  id_user text,
+  keterangan text,
+  created_at timestamp with time zone,
+  updated_at timestamp with time zone,
+  deleted_at timestamptz,
+  PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_kas_shifts_id_shift ON kas_shifts (id_shift);
+
+CREATE TABLE IF NOT EXISTS penjualans (
+  id uuid DEFAULT uuid_generate_v4(),
+  no_penjualan text,
+  tanggal timestamp with time zone,
+  id_shift text,
+  id_user uuid,
+  metode_bayar text,
+  bayar bigint,
+  kembalian bigint,
+  keterangan text,
+  subtotal bigint,
+  diskon bigint,
+  diskon_p decimal,
+  potongan_diskon bigint,
+  tarif_ppn decimal,
+  harga_termasuk_ppn boolean,
+  dpp bigint,
+  ppn bigint,
+  total bigint,
+  created_at timestamp with time zone,
+  updated_at timestamp with time zone,
+  deleted_at timestamptz,
+  PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_penjualans_no_penjualan ON penjualans (no_penjualan);
+CREATE INDEX IF NOT EXISTS idx_penjualans_id_shift ON penjualans (id_shift);
+CREATE INDEX IF NOT EXISTS idx_penjualans_id_user ON penjualans (id_user);
+
+CREATE TABLE IF NOT EXISTS transaksi_penjualans (
+  id uuid DEFAULT uuid_generate_v4(),
+  id_penjualan uuid,
+  id_barang uuid,
+  krat bigint,
+  lusin bigint,
+  satuan bigint,
+  jumlah bigint,
+  harga bigint,
+  diskon bigint,
+  diskon_p decimal,
+  jumlah_rp bigint,
+  diskon_faktur bigint,
+  dpp bigint,
+  ppn bigint,
+  created_at timestamp with time zone,
+  updated_at timestamp with time zone,
+  deleted_at timestamptz,
+  PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_transaksi_penjualans_id_penjualan ON transaksi_penjualans (id_penjualan);
+
+ALTER TABLE main_settings ADD COLUMN IF NOT EXISTS prefix_penjualan text DEFAULT 'POS';
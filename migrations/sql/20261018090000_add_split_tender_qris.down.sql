@@ -0,0 +1,4 @@
+ALTER TABLE main_settings DROP COLUMN IF EXISTS qris_statis;
+ALTER TABLE penjualans DROP COLUMN IF EXISTS tunai;
+
+DROP TABLE IF EXISTS pembayaran_penjualans;
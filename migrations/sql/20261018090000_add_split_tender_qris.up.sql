@@ -0,0 +1,28 @@
+-- Split tender on counter sales and the merchant QRIS.
+
+CREATE TABLE IF NOT EXISTS pembayaran_penjualans (
+  id uuid DEFAULT uuid_generate_v4(),
+  id_penjualan uuid,
+  metode text,
+  jumlah bigint,
+  referensi text,
+  created_at timestamp with time zone,
+  updated_at timestamp with time zone,
+  deleted_at timestamptz,
+  PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_pembayaran_penjualans_id_penjualan ON pembayaran_penjualans (id_penjualan);
+
+ALTER TABLE penjualans ADD COLUMN IF NOT EXISTS tunai bigint;
+
+-- Sales made before split tender were paid with a single tender
+INSERT INTO pembayaran_penjualans (id_penjualan, metode, jumlah, created_at, updated_at)
+SELECT p.id, p.metode_bayar, p.bayar, p.created_at, p.updated_at
+FROM penjualans p
+WHERE NOT EXISTS (SELECT 1 FROM pembayaran_penjualans pp WHERE pp.id_penjualan = p.id);
+
+UPDATE penjualans
+SET tunai = CASE WHEN metode_bayar = 'tunai' THEN total ELSE 0 END
+WHERE tunai IS NULL;
+
+ALTER TABLE main_settings ADD COLUMN IF NOT EXISTS qris_statis text;
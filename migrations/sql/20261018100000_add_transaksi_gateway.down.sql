@@ -0,0 +1 @@
+DROP TABLE IF EXISTS transaksi_gateways;
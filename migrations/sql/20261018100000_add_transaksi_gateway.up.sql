@@ -0,0 +1,23 @@
+-- Faktur payments received through a payment gateway.
+
+CREATE TABLE IF NOT EXISTS transaksi_gateways (
+  id uuid DEFAULT uuid_generate_v4(),
+  gateway text,
+  order_id text,
+  id_transaksi text,
+  id_faktur uuid,
+  status text,
+  status_gateway text,
+  metode text,
+  jumlah bigint,
+  waktu_bayar timestamp with time zone,
+  id_pembayaran text,
+  payload text,
+  created_at timestamp with time zone,
+  updated_at timestamp with time zone,
+  deleted_at timestamptz,
+  PRIMARY KEY (id)
+);
+-- A gateway notifies about the same order as often as it likes
+CREATE UNIQUE INDEX IF NOT EXISTS idx_transaksi_gateways_gateway_order_id ON transaksi_gateways (gateway, order_id);
+CREATE INDEX IF NOT EXISTS idx_transaksi_gateways_id_faktur ON transaksi_gateways (id_faktur);
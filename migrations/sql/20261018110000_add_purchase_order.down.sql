@@ -0,0 +1,8 @@
+ALTER TABLE main_settings DROP COLUMN IF EXISTS prefix_penerimaan;
+ALTER TABLE main_settings DROP COLUMN IF EXISTS prefix_po;
+
+DROP TABLE IF EXISTS transaksi_penerimaans;
+DROP TABLE IF EXISTS penerimaan_barangs;
+DROP TABLE IF EXISTS transaksi_purchase_orders;
+DROP TABLE IF EXISTS purchase_orders;
+DROP TABLE IF EXISTS suppliers;
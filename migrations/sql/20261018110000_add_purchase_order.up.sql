@@ -0,0 +1,88 @@
+-- Suppliers, purchase orders and the goods received against them.
+
+CREATE TABLE IF NOT EXISTS suppliers (
+  id uuid DEFAULT uuid_generate_v4(),
+  nama_supplier text,
+  nama_kontak text,
+  alamat text,
+  hp text,
+  npwp text,
+  created_at timestamp with time zone,
+  updated_at timestamp with time zone,
+  deleted_at timestamptz,
+  PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS purchase_orders (
+  id uuid DEFAULT uuid_generate_v4(),
+  no_po text,
+  tanggal timestamptz,
+  id_supplier uuid,
+  id_user uuid,
+  status text DEFAULT 'open',
+  total bigint,
+  keterangan text,
+  alasan_tutup text,
+  created_at timestamp with time zone,
+  updated_at timestamp with time zone,
+  deleted_at timestamptz,
+  PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_purchase_orders_no_po ON purchase_orders (no_po);
+CREATE INDEX IF NOT EXISTS idx_purchase_orders_id_supplier ON purchase_orders (id_supplier);
+
+CREATE TABLE IF NOT EXISTS transaksi_purchase_orders (
+  id uuid DEFAULT uuid_generate_v4(),
+  id_purchase_order uuid,
+  id_barang uuid,
+  krat bigint,
+  lusin bigint,
+  satuan bigint,
+  jumlah bigint,
+  harga_beli bigint,
+  jumlah_rp bigint,
+  diterima bigint DEFAULT 0,
+  created_at timestamp with time zone,
+  updated_at timestamp with time zone,
+  deleted_at timestamptz,
+  PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_transaksi_purchase_orders_id_purchase_order ON transaksi_purchase_orders (id_purchase_order);
+
+CREATE TABLE IF NOT EXISTS penerimaan_barangs (
+  id uuid DEFAULT uuid_generate_v4(),
+  no_penerimaan text,
+  tanggal timestamptz,
+  id_purchase_order uuid,
+  id_user uuid,
+  total bigint,
+  keterangan text,
+  created_at timestamp with time zone,
+  updated_at timestamp with time zone,
+  deleted_at timestamptz,
+  PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_penerimaan_barangs_no_penerimaan ON penerimaan_barangs (no_penerimaan);
+CREATE INDEX IF NOT EXISTS idx_penerimaan_barangs_id_purchase_order ON penerimaan_barangs (id_purchase_order);
+
+CREATE TABLE IF NOT EXISTS transaksi_penerimaans (
+  id uuid DEFAULT uuid_generate_v4(),
+  id_penerimaan uuid,
+  id_transaksi_po uuid,
+  id_barang uuid,
+  krat bigint,
+  lusin bigint,
+  satuan bigint,
+  jumlah bigint,
+  harga_beli bigint,
+  jumlah_rp bigint,
+  created_at timestamp with time zone,
+  updated_at timestamp with time zone,
+  deleted_at timestamptz,
+  PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_transaksi_penerimaans_id_penerimaan ON transaksi_penerimaans (id_penerimaan);
+CREATE INDEX IF NOT EXISTS idx_transaksi_penerimaans_id_transaksi_po ON transaksi_penerimaans (id_transaksi_po);
+
+ALTER TABLE main_settings ADD COLUMN IF NOT EXISTS prefix_po text DEFAULT 'PO';
+ALTER TABLE main_settings ADD COLUMN IF NOT EXISTS prefix_penerimaan text DEFAULT 'GRN';
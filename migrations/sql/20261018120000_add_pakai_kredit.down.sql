@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_kredit_customers_id_faktur;
+ALTER TABLE kredit_customers DROP COLUMN IF EXISTS id_faktur;
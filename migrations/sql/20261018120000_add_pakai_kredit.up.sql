@@ -0,0 +1,4 @@
+-- Customer credit applied to a faktur is booked against that faktur.
+
+ALTER TABLE kredit_customers ADD COLUMN IF NOT EXISTS id_faktur text;
+CREATE INDEX IF NOT EXISTS idx_kredit_customers_id_faktur ON kredit_customers (id_faktur);
@@ -0,0 +1,12 @@
+ALTER TABLE pembayarans ALTER COLUMN id_user TYPE uuid USING NULLIF(id_user, '')::uuid;
+
+ALTER TABLE transaksi_returs ALTER COLUMN id_transaksi_faktur TYPE text USING id_transaksi_faktur::text;
+ALTER TABLE nota_kredits ALTER COLUMN id_customer TYPE text USING id_customer::text;
+ALTER TABLE nota_kredits ALTER COLUMN id_faktur TYPE text USING id_faktur::text;
+ALTER TABLE kredit_customers ALTER COLUMN id_customer TYPE text USING id_customer::text;
+ALTER TABLE override_kredits ALTER COLUMN id_customer TYPE text USING id_customer::text;
+ALTER TABLE penjualans ALTER COLUMN id_shift TYPE text USING id_shift::text;
+ALTER TABLE kas_shifts ALTER COLUMN id_user TYPE text USING id_user::text;
+ALTER TABLE kekurangan_drivers ALTER COLUMN id_user TYPE text USING id_user::text;
+ALTER TABLE setorans ALTER COLUMN id_petugas TYPE text USING id_petugas::text;
+ALTER TABLE stock_movements ALTER COLUMN id_user TYPE text USING id_user::text;
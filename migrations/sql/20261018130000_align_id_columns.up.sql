@@ -0,0 +1,20 @@
+-- Columns holding the id of a row are uuid, like the entities declare them.
+-- Only optional references stay text, since they are left empty rather than
+-- NULL: fakturs.id_loading, id_override_kredit, id_user_batal, the *_by
+-- columns of loadings, stock_movements.ref_id, kekurangan_drivers.id_barang,
+-- pembayarans.id_user and id_reviewer, the references of kredit_customers,
+-- override_kredits.id_faktur and transaksi_gateways.id_pembayaran.
+
+ALTER TABLE stock_movements ALTER COLUMN id_user TYPE uuid USING NULLIF(id_user, '')::uuid;
+ALTER TABLE setorans ALTER COLUMN id_petugas TYPE uuid USING NULLIF(id_petugas, '')::uuid;
+ALTER TABLE kekurangan_drivers ALTER COLUMN id_user TYPE uuid USING NULLIF(id_user, '')::uuid;
+ALTER TABLE kas_shifts ALTER COLUMN id_user TYPE uuid USING NULLIF(id_user, '')::uuid;
+ALTER TABLE penjualans ALTER COLUMN id_shift TYPE uuid USING NULLIF(id_shift, '')::uuid;
+ALTER TABLE override_kredits ALTER COLUMN id_customer TYPE uuid USING NULLIF(id_customer, '')::uuid;
+ALTER TABLE kredit_customers ALTER COLUMN id_customer TYPE uuid USING NULLIF(id_customer, '')::uuid;
+ALTER TABLE nota_kredits ALTER COLUMN id_faktur TYPE uuid USING NULLIF(id_faktur, '')::uuid;
+ALTER TABLE nota_kredits ALTER COLUMN id_customer TYPE uuid USING NULLIF(id_customer, '')::uuid;
+ALTER TABLE transaksi_returs ALTER COLUMN id_transaksi_faktur TYPE uuid USING NULLIF(id_transaksi_faktur, '')::uuid;
+
+-- Gateway payments have no collector
+ALTER TABLE pembayarans ALTER COLUMN id_user TYPE text USING id_user::text;
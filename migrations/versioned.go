@@ -0,0 +1,266 @@
+package migrations
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MIGRATION_DIR holds the versioned migrations, one <version>_<name>.up.sql
+// and <version>_<name>.down.sql pair per change.
+const MIGRATION_DIR = "./migrations/sql"
+
+// SchemaMigration records a migration that has been applied to the database.
+type SchemaMigration struct {
+	Version   string    `gorm:"primaryKey"`
+	Name      string    `gorm:"not null"`
+	AppliedAt time.Time `gorm:"type:timestamp with time zone;not null"`
+}
+
+func (SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// MigrationFile is a migration found in MIGRATION_DIR.
+type MigrationFile struct {
+	Version  string
+	Name     string
+	UpPath   string
+	DownPath string
+}
+
+// MigrationStatus tells whether a migration file has been applied.
+type MigrationStatus struct {
+	MigrationFile
+	AppliedAt *time.Time
+}
+
+var migrationFileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// loadMigrationFiles lists the migrations in MIGRATION_DIR ordered by version.
+// Every migration needs both an up and a down file.
+func loadMigrationFiles() ([]MigrationFile, error) {
+	entries, err := os.ReadDir(MIGRATION_DIR)
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string]*MigrationFile{}
+	for _, entry := range entries {
+		match := migrationFileName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+
+		file, ok := files[match[1]]
+		if !ok {
+			file = &MigrationFile{Version: match[1], Name: match[2]}
+			files[match[1]] = file
+		}
+		if file.Name != match[2] {
+			return nil, fmt.Errorf("migration %s has files with different names", match[1])
+		}
+
+		path := filepath.Join(MIGRATION_DIR, entry.Name())
+		if match[3] == "up" {
+			file.UpPath = path
+		} else {
+			file.DownPath = path
+		}
+	}
+
+	var migrations []MigrationFile
+	for _, file := range files {
+		if file.UpPath == "" || file.DownPath == "" {
+			return nil, fmt.Errorf("migration %s_%s needs both an up and a down file", file.Version, file.Name)
+		}
+		migrations = append(migrations, *file)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+func appliedMigrations(db *gorm.DB) (map[string]SchemaMigration, error) {
+	if err := db.AutoMigrate(&SchemaMigration{}); err != nil {
+		return nil, err
+	}
+
+	var rows []SchemaMigration
+	if err := db.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	applied := map[string]SchemaMigration{}
+	for _, row := range rows {
+		applied[row.Version] = row
+	}
+
+	return applied, nil
+}
+
+// runMigrationFile executes one SQL file and books the result in
+// schema_migrations inside a single transaction, so a failing migration leaves
+// neither half a schema change nor a wrong record behind.
+func runMigrationFile(db *gorm.DB, path string, record func(tx *gorm.DB) error) error {
+	query, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if strings.TrimSpace(string(query)) != "" {
+			if err := tx.Exec(string(query)).Error; err != nil {
+				return fmt.Errorf("%s: %v", filepath.Base(path), err)
+			}
+		}
+
+		return record(tx)
+	})
+}
+
+// MigrateUp applies every migration that has not been applied yet, oldest
+// first. It returns the migrations it applied.
+func MigrateUp(db *gorm.DB) ([]MigrationFile, error) {
+	files, err := loadMigrationFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []MigrationFile
+	for _, file := range files {
+		if _, ok := applied[file.Version]; ok {
+			continue
+		}
+
+		file := file
+		if err := runMigrationFile(db, file.UpPath, func(tx *gorm.DB) error {
+			return tx.Create(&SchemaMigration{
+				Version:   file.Version,
+				Name:      file.Name,
+				AppliedAt: time.Now(),
+			}).Error
+		}); err != nil {
+			return done, err
+		}
+		done = append(done, file)
+	}
+
+	return done, nil
+}
+
+// MigrateDown rolls back the last n applied migrations, newest first. It
+// returns the migrations it rolled back.
+func MigrateDown(db *gorm.DB, n int) ([]MigrationFile, error) {
+	files, err := loadMigrationFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[string]MigrationFile{}
+	for _, file := range files {
+		byVersion[file.Version] = file
+	}
+
+	var versions []string
+	for version := range applied {
+		versions = append(versions, version)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(versions)))
+
+	var done []MigrationFile
+	for _, version := range versions {
+		if len(done) == n {
+			break
+		}
+
+		file, ok := byVersion[version]
+		if !ok {
+			return done, fmt.Errorf("migration %s_%s is applied but its files are missing", version, applied[version].Name)
+		}
+
+		if err := runMigrationFile(db, file.DownPath, func(tx *gorm.DB) error {
+			return tx.Where("version = ?", file.Version).Delete(&SchemaMigration{}).Error
+		}); err != nil {
+			return done, err
+		}
+		done = append(done, file)
+	}
+
+	return done, nil
+}
+
+// GetMigrationStatus lists every migration file and when it was applied.
+func GetMigrationStatus(db *gorm.DB) ([]MigrationStatus, error) {
+	files, err := loadMigrationFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []MigrationStatus
+	for _, file := range files {
+		status := MigrationStatus{MigrationFile: file}
+		if row, ok := applied[file.Version]; ok {
+			appliedAt := row.AppliedAt
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// MakeMigration creates an empty up and down file for a new migration,
+// versioned by the current time.
+func MakeMigration(name string) (MigrationFile, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = regexp.MustCompile(`[^a-z0-9]+`).ReplaceAllString(name, "_")
+	name = strings.Trim(name, "_")
+	if name == "" {
+		return MigrationFile{}, fmt.Errorf("migration name is empty")
+	}
+
+	if err := os.MkdirAll(MIGRATION_DIR, 0o755); err != nil {
+		return MigrationFile{}, err
+	}
+
+	version := time.Now().Format("20060102150405")
+	file := MigrationFile{
+		Version:  version,
+		Name:     name,
+		UpPath:   filepath.Join(MIGRATION_DIR, version+"_"+name+".up.sql"),
+		DownPath: filepath.Join(MIGRATION_DIR, version+"_"+name+".down.sql"),
+	}
+
+	if err := os.WriteFile(file.UpPath, []byte("-- "+name+"\n"), 0o644); err != nil {
+		return MigrationFile{}, err
+	}
+	if err := os.WriteFile(file.DownPath, []byte("-- revert "+name+"\n"), 0o644); err != nil {
+		return MigrationFile{}, err
+	}
+
+	return file, nil
+}
@@ -3,21 +3,26 @@ package repository
 import (
 	"context"
 	"fmt"
-	"math"
+	"time"
 
+	"github.com/jejevj/ykp_pos/constants"
 	"github.com/jejevj/ykp_pos/dto"
 	"github.com/jejevj/ykp_pos/entity"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type (
 	BarangRepository interface {
-		AddBarang(ctx context.Context, barang entity.Barang) (entity.Barang, error)
-		GetAllBarangWithPagination(ctx context.Context) (dto.GetAllBarangRepositoryResponse, error)
+		AddBarang(ctx context.Context, barang entity.Barang, userId string) (entity.Barang, error)
+		GetAllBarangWithPagination(ctx context.Context, req dto.PaginationRequest) (dto.GetAllBarangRepositoryResponse, error)
 		GetBarangById(ctx context.Context, barangId string) (entity.Barang, error)
+		GetBarangByKode(ctx context.Context, kodeBarang string) (entity.Barang, error)
 		UpdateBarang(ctx context.Context, barang entity.Barang) (entity.Barang, error)
-		UpdateStokBarang(ctx context.Context, barang entity.Barang) (entity.Barang, error)
+		UpdateStokBarang(ctx context.Context, barang entity.Barang, userId string) (entity.Barang, error)
+		OpnameBarang(ctx context.Context, barang entity.Barang, keterangan string, userId string) (entity.Barang, error)
 		DeleteBarang(ctx context.Context, barangId string) error
+		GetKartuStok(ctx context.Context, barangId string, tanggalMulai time.Time, tanggalAkhir time.Time) (dto.GetKartuStokRepositoryResponse, error)
 	}
 	barangRepository struct {
 		db *gorm.DB
@@ -30,129 +35,257 @@ func NewBarangRepository(db *gorm.DB) BarangRepository {
 	}
 }
 
-func (r *barangRepository) AddBarang(ctx context.Context, barang entity.Barang) (entity.Barang, error) {
-	tx := r.db
+func (r *barangRepository) AddBarang(ctx context.Context, barang entity.Barang, userId string) (entity.Barang, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Fetch Satuan entity based on IdSatuan (assuming you have a related Satuan table/entity)
+		var satuan entity.Satuan
+		if err := tx.Where("id = ?", barang.IdSatuan).First(&satuan).Error; err != nil {
+			// Return an error if the Satuan entity can't be found
+			return err
+		}
 
-	// Fetch Satuan entity based on IdSatuan (assuming you have a related Satuan table/entity)
-	var satuan entity.Satuan
-	if err := tx.WithContext(ctx).Where("id = ?", barang.IdSatuan).First(&satuan).Error; err != nil {
-		// Return an error if the Satuan entity can't be found
-		return entity.Barang{}, err
-	}
+		barang.Satuan = satuan
 
-	// Assign the retrieved Satuan to the Barang entity
-	barang.Satuan = satuan
+		// The opening stock is booked through the kartu stok, so the barang starts empty
+		stokAwal, err := jumlahDasar(barang, barang.JumlahKrat, 0, barang.JumlahSatuan, barang.Rincian)
+		if err != nil {
+			return err
+		}
+		barang.JumlahKrat = 0
+		barang.JumlahSatuan = 0
+		barang.Stok = 0
 
-	// Calculate the Stok based on JumlahKrat, JumlahSatuan, and Satuan.Value
-	barang.Stok = barang.JumlahKrat*satuan.Value + barang.JumlahSatuan
+		if err := tx.Omit(clause.Associations).Create(&barang).Error; err != nil {
+			return err
+		}
+		if err := saveBarangSatuans(tx, barang.ID.String(), barang.Satuans); err != nil {
+			return err
+		}
+
+		if stokAwal == 0 {
+			return nil
+		}
 
-	// I want to make barang.Stok as
-	// barang.Stok = JumlahKrat * barang.Satuan.Value + JumlahSatuan
-	if err := tx.WithContext(ctx).Create(&barang).Error; err != nil {
+		_, err = moveStock(tx, entity.StockMovement{
+			IdBarang:   barang.ID.String(),
+			Jumlah:     stokAwal,
+			Alasan:     constants.ENUM_STOK_ADJUSTMENT,
+			IdUser:     userId,
+			Keterangan: "stok awal",
+		})
+		return err
+	})
+	if err != nil {
 		return entity.Barang{}, err
 	}
-	return barang, nil
+
+	return r.GetBarangById(ctx, barang.ID.String())
+}
+
+// saveBarangSatuans stores the unit list of a barang in the order given.
+func saveBarangSatuans(tx *gorm.DB, barangId string, satuans []entity.BarangSatuan) error {
+	for i, satuan := range satuans {
+		satuan.IdBarang = barangId
+		satuan.Urutan = i + 1
+		if err := tx.Create(&satuan).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
-func (r *barangRepository) GetAllBarangWithPagination(ctx context.Context) (dto.GetAllBarangRepositoryResponse, error) {
+// barangListOptions are the searchable and sortable columns of the barang list.
+var barangListOptions = ListOptions{
+	Searchable: []string{"nama_barang", "kode_barang"},
+	Sortable: map[string]string{
+		"nama_barang": "nama_barang",
+		"kode_barang": "kode_barang",
+		"harga_beli":  "harga_beli",
+		"harga_jual":  "harga_jual",
+		"stok":        "stok",
+		"created_at":  "created_at",
+	},
+	DefaultSort: "nama_barang ASC, id ASC",
+}
+
+func (r *barangRepository) GetAllBarangWithPagination(ctx context.Context, req dto.PaginationRequest) (dto.GetAllBarangRepositoryResponse, error) {
 	tx := r.db
 
 	var barangs []entity.Barang
-	var err error
-	var count int64
+	var count, total int64
+
+	NormalizePagination(&req)
+	order, err := barangListOptions.Order(req.Sort, req.Order)
+	if err != nil {
+		return dto.GetAllBarangRepositoryResponse{}, err
+	}
 
-	if err := tx.WithContext(ctx).Model(&entity.Barang{}).Count(&count).Error; err != nil {
+	if err := tx.WithContext(ctx).Model(&entity.Barang{}).Count(&total).Error; err != nil {
+		return dto.GetAllBarangRepositoryResponse{}, err
+	}
+
+	if err := tx.WithContext(ctx).Model(&entity.Barang{}).Scopes(barangListOptions.Search(req.Search)).Count(&count).Error; err != nil {
 		return dto.GetAllBarangRepositoryResponse{}, err
 	}
 
 	if err := tx.WithContext(ctx).
 		Preload("Satuan").
-		Scopes(Paginate(1, 10)).
+		Preload("Satuans", OrderSatuan).
+		Scopes(barangListOptions.Search(req.Search)).
+		Order(order).
+		Scopes(Paginate(req.Page, req.PerPage)).
 		Find(&barangs).Error; err != nil {
 		return dto.GetAllBarangRepositoryResponse{}, err
 	}
-	totalPage := int64(math.Ceil(float64(count) / float64(10)))
 
 	return dto.GetAllBarangRepositoryResponse{
-		Barangs: barangs,
-		PaginationResponse: dto.PaginationResponse{
-			Page:    1,
-			PerPage: 10,
-			Count:   count,
-			MaxPage: totalPage,
-		},
-	}, err
+		Barangs:            barangs,
+		Total:              total,
+		PaginationResponse: NewPaginationResponse(req, count),
+	}, nil
 }
 func (r *barangRepository) GetBarangById(ctx context.Context, barangId string) (entity.Barang, error) {
 	tx := r.db
 
 	var barang entity.Barang
 	// Preload the Satuan data based on the foreign key IdSatuan
-	if err := tx.WithContext(ctx).Preload("Satuan").Where("id = ?", barangId).Take(&barang).Error; err != nil {
+	if err := tx.WithContext(ctx).Preload("Satuan").Preload("Satuans", OrderSatuan).Where("id = ?", barangId).Take(&barang).Error; err != nil {
 		return entity.Barang{}, err
 	}
 
 	return barang, nil
 }
-func (r *barangRepository) UpdateBarang(ctx context.Context, barang entity.Barang) (entity.Barang, error) {
+
+func (r *barangRepository) GetBarangByKode(ctx context.Context, kodeBarang string) (entity.Barang, error) {
 	tx := r.db
 
-	var existingBarang entity.Barang
-	if err := tx.WithContext(ctx).Where("id = ?", barang.ID).Take(&existingBarang).Error; err != nil {
+	var barang entity.Barang
+	if err := tx.WithContext(ctx).Preload("Satuan").Preload("Satuans", OrderSatuan).Where("kode_barang = ?", kodeBarang).Take(&barang).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return entity.Barang{}, fmt.Errorf("Barang with ID %s not found", barang.ID)
+			return entity.Barang{}, fmt.Errorf("Barang with kode %s not found", kodeBarang)
 		}
 		return entity.Barang{}, err
 	}
 
-	if err := tx.WithContext(ctx).Model(&existingBarang).Updates(barang).Error; err != nil {
-		return entity.Barang{}, err
-	}
-
-	return existingBarang, nil
+	return barang, nil
 }
 
-func (r *barangRepository) UpdateStokBarang(ctx context.Context, barang entity.Barang) (entity.Barang, error) {
-	tx := r.db
+func (r *barangRepository) UpdateBarang(ctx context.Context, barang entity.Barang) (entity.Barang, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existingBarang entity.Barang
+		if err := tx.Where("id = ?", barang.ID).Take(&existingBarang).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return fmt.Errorf("Barang with ID %s not found", barang.ID)
+			}
+			return err
+		}
 
-	// Fetch the existing Barang from the database based on ID
-	var existingBarang entity.Barang
-	if err := tx.WithContext(ctx).Where("id = ?", barang.ID).Take(&existingBarang).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return entity.Barang{}, fmt.Errorf("Barang with ID %s not found", barang.ID)
+		// Stock is never overwritten directly, it goes through the kartu stok below
+		if err := tx.Model(&existingBarang).Omit("stok", "jumlah_krat", "jumlah_satuan", clause.Associations).Updates(barang).Error; err != nil {
+			return err
+		}
+
+		// The unit list is replaced as a whole when it is sent
+		if len(barang.Satuans) > 0 {
+			if err := tx.Where("id_barang = ?", existingBarang.ID.String()).Delete(&entity.BarangSatuan{}).Error; err != nil {
+				return err
+			}
+			if err := saveBarangSatuans(tx, existingBarang.ID.String(), barang.Satuans); err != nil {
+				return err
+			}
 		}
-		return entity.Barang{}, err
-	}
 
-	// Fetch the associated Satuan based on the IdSatuan of the existing Barang
-	var satuan entity.Satuan
-	if err := tx.WithContext(ctx).Where("id = ?", existingBarang.IdSatuan).First(&satuan).Error; err != nil {
+		return nil
+	})
+	if err != nil {
 		return entity.Barang{}, err
 	}
 
-	// Update Satuan in existingBarang to make sure it has the most up-to-date value
-	existingBarang.Satuan = satuan
+	return r.GetBarangById(ctx, barang.ID.String())
+}
+
+func (r *barangRepository) UpdateStokBarang(ctx context.Context, barang entity.Barang, userId string) (entity.Barang, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Fetch the existing Barang from the database based on ID
+		existingBarang, err := findBarangSatuan(tx, barang.ID.String())
+		if err != nil {
+			return err
+		}
 
-	// Calculate the updated Stok
+		// The request carries the quantity received, not the new balance
+		jumlah, err := jumlahDasar(existingBarang, barang.JumlahKrat, 0, barang.JumlahSatuan, barang.Rincian)
+		if err != nil {
+			return err
+		}
+		if jumlah == 0 {
+			return nil
+		}
 
-	barang.Stok = barang.JumlahKrat*satuan.Value + barang.JumlahSatuan
-	barang.Stok += existingBarang.Stok
-	barang.JumlahKrat += existingBarang.JumlahKrat
-	barang.JumlahSatuan += existingBarang.JumlahSatuan
+		alasan := constants.ENUM_STOK_RECEIPT
+		if jumlah < 0 {
+			alasan = constants.ENUM_STOK_ADJUSTMENT
+		}
 
-	// If you want to update other fields of existingBarang, make sure to set those values in the `barang` parameter
-	// You can now update the existing Barang, passing only the necessary fields
-	if err := tx.WithContext(ctx).Model(&existingBarang).Updates(barang).Error; err != nil {
+		_, err = moveStock(tx, entity.StockMovement{
+			IdBarang: existingBarang.ID.String(),
+			Jumlah:   jumlah,
+			Alasan:   alasan,
+			IdUser:   userId,
+		})
+		return err
+	})
+	if err != nil {
 		return entity.Barang{}, err
 	}
 
-	// After updating other fields, explicitly set the Stok again if it's not being passed in `barang`
-	if err := tx.WithContext(ctx).Model(&existingBarang).Update("stok", existingBarang.Stok).Update("jumlah_krat", existingBarang.JumlahKrat).Update("jumlah_satuan", existingBarang.JumlahSatuan).Error; err != nil {
+	// Return the updated Barang
+	return r.GetBarangById(ctx, barang.ID.String())
+}
+
+// OpnameBarang sets the stock of a barang to the quantity counted on the
+// shelf. The difference with the stock on record is booked on the kartu stok,
+// so a count of zero clears the stock.
+func (r *barangRepository) OpnameBarang(ctx context.Context, barang entity.Barang, keterangan string, userId string) (entity.Barang, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock first so movements booked meanwhile are part of the difference
+		var existingBarang entity.Barang
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", barang.ID).Take(&existingBarang).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return fmt.Errorf("Barang with ID %s not found", barang.ID)
+			}
+			return err
+		}
+
+		satuanBarang, err := findBarangSatuan(tx, barang.ID.String())
+		if err != nil {
+			return err
+		}
+		dihitung, err := jumlahDasar(satuanBarang, barang.JumlahKrat, 0, barang.JumlahSatuan, barang.Rincian)
+		if err != nil {
+			return err
+		}
+		if dihitung < 0 {
+			return dto.ErrOpnameInvalid
+		}
+		if dihitung == existingBarang.Stok {
+			return nil
+		}
+
+		_, err = moveStock(tx, entity.StockMovement{
+			IdBarang:   existingBarang.ID.String(),
+			Jumlah:     dihitung - existingBarang.Stok,
+			Alasan:     constants.ENUM_STOK_OPNAME,
+			IdUser:     userId,
+			Keterangan: keterangan,
+		})
+		return err
+	})
+	if err != nil {
 		return entity.Barang{}, err
 	}
 
-	// Return the updated Barang
-	return existingBarang, nil
+	return r.GetBarangById(ctx, barang.ID.String())
 }
 
 func (r *barangRepository) DeleteBarang(ctx context.Context, barangId string) error {
@@ -164,3 +297,36 @@ func (r *barangRepository) DeleteBarang(ctx context.Context, barangId string) er
 
 	return nil
 }
+
+func (r *barangRepository) GetKartuStok(ctx context.Context, barangId string, tanggalMulai time.Time, tanggalAkhir time.Time) (dto.GetKartuStokRepositoryResponse, error) {
+	tx := r.db
+
+	barang, err := r.GetBarangById(ctx, barangId)
+	if err != nil {
+		return dto.GetKartuStokRepositoryResponse{}, err
+	}
+
+	// The opening balance is derived backwards from the current stock, so
+	// barang that existed before the kartu stok still get a correct figure
+	var mutasiSejak int64
+	if err := tx.WithContext(ctx).Model(&entity.StockMovement{}).
+		Where("id_barang = ? AND tanggal >= ?", barangId, tanggalMulai).
+		Select("COALESCE(SUM(jumlah), 0)").
+		Scan(&mutasiSejak).Error; err != nil {
+		return dto.GetKartuStokRepositoryResponse{}, err
+	}
+
+	var movements []entity.StockMovement
+	if err := tx.WithContext(ctx).
+		Where("id_barang = ? AND tanggal >= ? AND tanggal < ?", barangId, tanggalMulai, tanggalAkhir).
+		Order("tanggal ASC, created_at ASC").
+		Find(&movements).Error; err != nil {
+		return dto.GetKartuStokRepositoryResponse{}, err
+	}
+
+	return dto.GetKartuStokRepositoryResponse{
+		Barang:    barang,
+		SaldoAwal: barang.Stok - int(mutasiSejak),
+		Movements: movements,
+	}, nil
+}
@@ -1,6 +1,23 @@
 package repository
 
-import "gorm.io/gorm"
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/jejevj/ykp_pos/constants"
+	"github.com/jejevj/ykp_pos/dto"
+	"gorm.io/gorm"
+)
+
+// ListOptions whitelists the columns a list endpoint may be searched in and
+// sorted by. Sortable maps the sort key accepted from the request to the
+// column; DefaultSort is used when the request does not ask for an order.
+type ListOptions struct {
+	Searchable  []string
+	Sortable    map[string]string
+	DefaultSort string
+}
 
 func Paginate(page, perPage int) func(db *gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
@@ -8,3 +25,76 @@ func Paginate(page, perPage int) func(db *gorm.DB) *gorm.DB {
 		return db.Offset(offset).Limit(perPage)
 	}
 }
+
+// NormalizePagination fills in the default page and page size and caps the
+// page size so a single request cannot load a whole table.
+func NormalizePagination(req *dto.PaginationRequest) {
+	if req.Page <= 0 {
+		req.Page = constants.ENUM_PAGINATION_PAGE
+	}
+	if req.PerPage <= 0 {
+		req.PerPage = constants.ENUM_PAGINATION_LIMIT
+	}
+	if req.PerPage > constants.ENUM_PAGINATION_MAX_LIMIT {
+		req.PerPage = constants.ENUM_PAGINATION_MAX_LIMIT
+	}
+}
+
+// Search matches the search term case-insensitively against every searchable
+// column of the list.
+func (o ListOptions) Search(search string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		search = strings.TrimSpace(search)
+		if search == "" || len(o.Searchable) == 0 {
+			return db
+		}
+
+		var conditions []string
+		var args []interface{}
+		for _, column := range o.Searchable {
+			conditions = append(conditions, column+" ILIKE ?")
+			args = append(args, "%"+search+"%")
+		}
+
+		return db.Where("("+strings.Join(conditions, " OR ")+")", args...)
+	}
+}
+
+// Order builds the ORDER BY clause for the requested sort key and direction.
+// Only whitelisted keys are accepted so the request can never inject SQL; the
+// id breaks ties so rows do not jump between pages.
+func (o ListOptions) Order(sort string, order string) (string, error) {
+	if sort == "" {
+		return o.DefaultSort, nil
+	}
+
+	column, ok := o.Sortable[sort]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", dto.ErrInvalidSort, sort)
+	}
+
+	switch strings.ToLower(order) {
+	case "", "asc":
+		return column + " ASC, id ASC", nil
+	case "desc":
+		return column + " DESC, id ASC", nil
+	default:
+		return "", fmt.Errorf("%w: %s", dto.ErrInvalidSortOrder, order)
+	}
+}
+
+// NewPaginationResponse builds the pagination metadata of a list page.
+func NewPaginationResponse(req dto.PaginationRequest, count int64) dto.PaginationResponse {
+	return dto.PaginationResponse{
+		Page:    req.Page,
+		PerPage: req.PerPage,
+		Count:   count,
+		MaxPage: int64(math.Ceil(float64(count) / float64(req.PerPage))),
+	}
+}
+
+// OrderSatuan sorts the units of a barang from the largest to the base unit
+// when they are preloaded.
+func OrderSatuan(db *gorm.DB) *gorm.DB {
+	return db.Order("isi DESC")
+}
@@ -3,20 +3,24 @@ package repository
 import (
 	"context"
 	"fmt"
-	"math"
+	"time"
 
 	"github.com/jejevj/ykp_pos/dto"
 	"github.com/jejevj/ykp_pos/entity"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type (
 	CustomerRepository interface {
 		AddCustomer(ctx context.Context, customer entity.Customer) (entity.Customer, error)
-		GetAllCustomerWithPagination(ctx context.Context) (dto.GetAllCustomerRepositoryResponse, error)
+		GetAllCustomerWithPagination(ctx context.Context, req dto.PaginationRequest) (dto.GetAllCustomerRepositoryResponse, error)
 		GetCustomerById(ctx context.Context, customerId string) (entity.Customer, error)
 		UpdateCustomer(ctx context.Context, customer entity.Customer) (entity.Customer, error)
 		DeleteCustomer(ctx context.Context, customerId string) error
+		UpdateKreditCustomer(ctx context.Context, customer entity.Customer) (entity.Customer, error)
+		AddOverrideKredit(ctx context.Context, override entity.OverrideKredit) (entity.OverrideKredit, error)
+		GetOverrideKredit(ctx context.Context, customerId string) ([]entity.OverrideKredit, error)
 	}
 	customerRepository struct {
 		db *gorm.DB
@@ -38,32 +42,45 @@ func (r *customerRepository) AddCustomer(ctx context.Context, customer entity.Cu
 	return customer, nil
 }
 
-func (r *customerRepository) GetAllCustomerWithPagination(ctx context.Context) (dto.GetAllCustomerRepositoryResponse, error) {
+// customerListOptions are the searchable and sortable columns of the customer list.
+var customerListOptions = ListOptions{
+	Searchable: []string{"nama_toko", "nama_pemilik", "alamat", "hp"},
+	Sortable: map[string]string{
+		"nama_toko":    "nama_toko",
+		"nama_pemilik": "nama_pemilik",
+		"created_at":   "created_at",
+	},
+	DefaultSort: "nama_toko ASC, id ASC",
+}
+
+func (r *customerRepository) GetAllCustomerWithPagination(ctx context.Context, req dto.PaginationRequest) (dto.GetAllCustomerRepositoryResponse, error) {
 	tx := r.db
 
 	var customers []entity.Customer
-	var err error
 	var count int64
 
-	if err := tx.WithContext(ctx).Model(&entity.Customer{}).Count(&count).Error; err != nil {
+	NormalizePagination(&req)
+	order, err := customerListOptions.Order(req.Sort, req.Order)
+	if err != nil {
 		return dto.GetAllCustomerRepositoryResponse{}, err
 	}
 
-	if err := tx.WithContext(ctx).Scopes(Paginate(1, 10)).Find(&customers).Error; err != nil {
+	if err := tx.WithContext(ctx).Model(&entity.Customer{}).Scopes(customerListOptions.Search(req.Search)).Count(&count).Error; err != nil {
 		return dto.GetAllCustomerRepositoryResponse{}, err
 	}
 
-	totalPage := int64(math.Ceil(float64(count) / float64(10)))
+	if err := tx.WithContext(ctx).
+		Scopes(customerListOptions.Search(req.Search)).
+		Order(order).
+		Scopes(Paginate(req.Page, req.PerPage)).
+		Find(&customers).Error; err != nil {
+		return dto.GetAllCustomerRepositoryResponse{}, err
+	}
 
 	return dto.GetAllCustomerRepositoryResponse{
-		Customers: customers,
-		PaginationResponse: dto.PaginationResponse{
-			Page:    1,
-			PerPage: 10,
-			Count:   count,
-			MaxPage: totalPage,
-		},
-	}, err
+		Customers:          customers,
+		PaginationResponse: NewPaginationResponse(req, count),
+	}, nil
 }
 func (r *customerRepository) GetCustomerById(ctx context.Context, customerId string) (entity.Customer, error) {
 	tx := r.db
@@ -101,3 +118,57 @@ func (r *customerRepository) DeleteCustomer(ctx context.Context, customerId stri
 
 	return nil
 }
+
+// UpdateKreditCustomer writes the credit settings of a customer, zero values
+// included, since 0 switches a check off.
+func (r *customerRepository) UpdateKreditCustomer(ctx context.Context, customer entity.Customer) (entity.Customer, error) {
+	tx := r.db
+
+	result := tx.WithContext(ctx).Model(&entity.Customer{}).
+		Where("id = ?", customer.ID).
+		Select("limit_kredit", "max_hari_tempo").
+		Updates(customer)
+	if result.Error != nil {
+		return entity.Customer{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entity.Customer{}, fmt.Errorf("Customer with ID %s not found", customer.ID)
+	}
+
+	return r.GetCustomerById(ctx, customer.ID.String())
+}
+
+func (r *customerRepository) AddOverrideKredit(ctx context.Context, override entity.OverrideKredit) (entity.OverrideKredit, error) {
+	tx := r.db
+
+	if _, err := r.GetCustomerById(ctx, override.IdCustomer); err != nil {
+		return entity.OverrideKredit{}, fmt.Errorf("Customer with ID %s not found", override.IdCustomer)
+	}
+
+	// An override is meant for the faktur the driver is issuing right now, so
+	// it lapses at the end of the day
+	now := time.Now()
+	berlakuSampai := time.Date(now.Year(), now.Month(), now.Day(), 23, 59, 59, 0, now.Location())
+	override.BerlakuSampai = &berlakuSampai
+
+	if err := tx.WithContext(ctx).Omit(clause.Associations).Create(&override).Error; err != nil {
+		return entity.OverrideKredit{}, err
+	}
+
+	if err := tx.WithContext(ctx).Preload("Admin").Where("id = ?", override.ID).Take(&override).Error; err != nil {
+		return entity.OverrideKredit{}, err
+	}
+
+	return override, nil
+}
+
+func (r *customerRepository) GetOverrideKredit(ctx context.Context, customerId string) ([]entity.OverrideKredit, error) {
+	tx := r.db
+
+	var overrides []entity.OverrideKredit
+	if err := tx.WithContext(ctx).Preload("Admin").Where("id_customer = ?", customerId).Order("created_at DESC").Find(&overrides).Error; err != nil {
+		return nil, err
+	}
+
+	return overrides, nil
+}
@@ -0,0 +1,667 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/jejevj/ykp_pos/constants"
+	"github.com/jejevj/ykp_pos/dto"
+	"github.com/jejevj/ykp_pos/entity"
+	"github.com/jejevj/ykp_pos/helpers"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	FakturRepository interface {
+		AddFaktur(ctx context.Context, faktur entity.Faktur, pakaiKreditCustomer bool, ownerId string) (entity.Faktur, error)
+		GetAllFakturWithPagination(ctx context.Context, req dto.FakturFilterRequest) (dto.GetAllFakturRepositoryResponse, error)
+		GetFakturById(ctx context.Context, fakturId string) (entity.Faktur, error)
+		UpdateFaktur(ctx context.Context, faktur entity.Faktur, userId string) (entity.Faktur, error)
+		CancelFaktur(ctx context.Context, fakturId string, alasan string, userId string) (entity.Faktur, error)
+	}
+	fakturRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewFakturRepository(db *gorm.DB) FakturRepository {
+	return &fakturRepository{
+		db: db,
+	}
+}
+
+// preloadFaktur loads every relation needed to render a faktur with its lines.
+func preloadFaktur(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Customer").
+		Preload("Driver").
+		Preload("Items.Barang.Satuan").
+		Preload("Items.Barang.Satuans", OrderSatuan)
+}
+
+// mainSettingPajak reads the tax and pricing configuration of the business.
+func mainSettingPajak(tx *gorm.DB) (entity.MainSetting, error) {
+	var setting entity.MainSetting
+	if err := tx.Order("created_at ASC").Take(&setting).Error; err != nil && err != gorm.ErrRecordNotFound {
+		return entity.MainSetting{}, err
+	}
+
+	return setting, nil
+}
+
+// isEceran tells whether a faktur to the customer is a retail tax invoice,
+// which is the case for every customer without NPWP.
+func isEceran(tx *gorm.DB, customerId string) (bool, error) {
+	var customer entity.Customer
+	if err := tx.Select("npwp").Where("id = ?", customerId).Take(&customer).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return false, fmt.Errorf("Customer with ID %s not found", customerId)
+		}
+		return false, err
+	}
+
+	return helpers.NormalisasiNpwp(customer.Npwp) == "", nil
+}
+
+// persenDiskon reads a stored discount percentage, dropping the float32 noise
+// so 12.3 stays 12.3 when the discount is calculated.
+func persenDiskon(persen float32) float64 {
+	return math.Round(float64(persen)*10000) / 10000
+}
+
+// barisHarga is a line as it is priced: quantity in pieces times the unit
+// price, with its own discounts.
+func barisHarga(item entity.TransaksiFaktur, nominalDulu bool) helpers.BarisHarga {
+	return helpers.BarisHarga{
+		Harga:  item.Harga,
+		Jumlah: item.Jumlah,
+		Diskon: helpers.Diskon{Persen: persenDiskon(item.DiskonP), Nominal: item.Diskon, NominalDulu: nominalDulu},
+	}
+}
+
+// hargaFaktur prices the lines of a faktur, spreads the invoice discount over
+// them and splits every line into DPP and PPN. The amounts are written to the
+// lines and the header; Total always comes out as Dpp + Ppn.
+func hargaFaktur(faktur *entity.Faktur, items []entity.TransaksiFaktur, baris []helpers.BarisHarga, nominalDulu bool) error {
+	harga, err := helpers.HitungHarga(baris, helpers.Diskon{
+		Persen:      persenDiskon(faktur.DiskonP),
+		Nominal:     faktur.Diskon,
+		NominalDulu: nominalDulu,
+	})
+	if err != nil {
+		return err
+	}
+
+	termasukPpn := faktur.HargaTermasukPpn != nil && *faktur.HargaTermasukPpn
+	faktur.Subtotal = harga.Subtotal
+	faktur.PotonganDiskon = harga.PotonganFaktur.Potongan()
+	faktur.Dpp, faktur.Ppn = 0, 0
+	for i := range items {
+		items[i].JumlahRP = harga.Baris[i].Neto
+		items[i].DiskonFaktur = harga.Baris[i].PotonganFaktur
+		items[i].Dpp, items[i].Ppn = helpers.HitungPpn(harga.Baris[i].Bersih, faktur.TarifPpn, termasukPpn)
+		faktur.Dpp += items[i].Dpp
+		faktur.Ppn += items[i].Ppn
+	}
+	faktur.Total = faktur.Dpp + faktur.Ppn
+
+	return nil
+}
+
+// lockLoadingFaktur locks the loading a faktur sells from. Goods can only be
+// invoiced from a loading out for delivery, and a sales user only from their
+// own (ownerId is empty for roles that see everything).
+func lockLoadingFaktur(tx *gorm.DB, loadingId string, ownerId string) error {
+	var loading entity.Loading
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", loadingId).Take(&loading).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return fmt.Errorf("Loading with ID %s not found", loadingId)
+		}
+		return err
+	}
+
+	if ownerId != "" && loading.IdUser != ownerId {
+		return dto.ErrLoadingNotOwned
+	}
+	if loading.Status != constants.ENUM_LOADING_DISPATCHED {
+		return fmt.Errorf("%w: loading has status %s", dto.ErrFakturLoadingStatus, loading.Status)
+	}
+
+	return nil
+}
+
+// checkSisaMuatan refuses lines for more of a barang than is left on the
+// loading: what was loaded less what the standing fakturs on it sold.
+func checkSisaMuatan(tx *gorm.DB, loadingId string, items []entity.TransaksiFaktur) error {
+	dimuat, err := jumlahDimuat(tx, loadingId)
+	if err != nil {
+		return err
+	}
+	terjual, err := jumlahTerjual(tx, loadingId)
+	if err != nil {
+		return err
+	}
+
+	sisa := map[string]int{}
+	for _, row := range dimuat {
+		sisa[row.IdBarang] += row.Jumlah
+	}
+	for _, row := range terjual {
+		sisa[row.IdBarang] -= row.Jumlah
+	}
+
+	diminta := map[string]int{}
+	for _, item := range items {
+		diminta[item.IdBarang] += item.Jumlah
+		if diminta[item.IdBarang] > sisa[item.IdBarang] {
+			return fmt.Errorf("%w: barang %s has %d left", dto.ErrFakturMelebihiMuat, item.IdBarang, max(sisa[item.IdBarang], 0))
+		}
+	}
+
+	return nil
+}
+
+// saveFakturItems calculates the quantity of every line in pieces, prices the
+// lines at the current selling price and inserts them for the given faktur.
+// Amounts sent by the client are never used. Invoices sold straight from the
+// warehouse (without a loading) take the goods out of stock here; invoices on
+// a loading may not sell more than is left on it.
+func saveFakturItems(tx *gorm.DB, faktur *entity.Faktur, items []entity.TransaksiFaktur, nominalDulu bool) error {
+	baris := make([]helpers.BarisHarga, len(items))
+	for i := range items {
+		barang, err := findBarangSatuan(tx, items[i].IdBarang)
+		if err != nil {
+			return err
+		}
+
+		items[i].IdFaktur = faktur.ID.String()
+		if items[i].Jumlah, err = jumlahDasar(barang, items[i].Krat, items[i].Lusin, items[i].Satuan, items[i].Rincian); err != nil {
+			return err
+		}
+		items[i].Harga = barang.HargaJual
+		baris[i] = barisHarga(items[i], nominalDulu)
+	}
+
+	if faktur.IdLoading != "" {
+		if err := checkSisaMuatan(tx, faktur.IdLoading, items); err != nil {
+			return err
+		}
+	}
+
+	if err := hargaFaktur(faktur, items, baris, nominalDulu); err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		if err := tx.Omit(clause.Associations).Create(&item).Error; err != nil {
+			return err
+		}
+
+		// Goods on a loading already left the warehouse when the loading did
+		if faktur.IdLoading == "" {
+			if _, err := moveStock(tx, entity.StockMovement{
+				IdBarang: item.IdBarang,
+				Jumlah:   -item.Jumlah,
+				Alasan:   constants.ENUM_STOK_SALE,
+				RefTipe:  constants.ENUM_DOKUMEN_FAKTUR,
+				RefId:    faktur.ID.String(),
+				RefNo:    faktur.NoFaktur,
+				IdUser:   faktur.IdUser,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// hargaUlangFaktur spreads the invoice discount and the PPN again over the
+// stored lines of a faktur whose header changed. The line totals are kept as
+// they were priced.
+func hargaUlangFaktur(tx *gorm.DB, faktur *entity.Faktur, nominalDulu bool) error {
+	var items []entity.TransaksiFaktur
+	if err := tx.Where("id_faktur = ?", faktur.ID.String()).Order("created_at ASC, id ASC").Find(&items).Error; err != nil {
+		return err
+	}
+
+	baris := make([]helpers.BarisHarga, len(items))
+	for i, item := range items {
+		baris[i] = helpers.BarisHarga{Harga: item.JumlahRP, Jumlah: 1}
+	}
+
+	if err := hargaFaktur(faktur, items, baris, nominalDulu); err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		if err := tx.Model(&item).Updates(map[string]interface{}{
+			"jumlah_rp":     item.JumlahRP,
+			"diskon_faktur": item.DiskonFaktur,
+			"dpp":           item.Dpp,
+			"ppn":           item.Ppn,
+		}).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkKreditCustomer refuses a credit faktur when the customer would go over
+// its credit limit with it, or already has an invoice overdue for longer than
+// allowed. A pending admin override for the customer lets the faktur through
+// and is used up by it.
+func checkKreditCustomer(tx *gorm.DB, faktur entity.Faktur, total int) (string, error) {
+	// Lock the customer so two fakturs cannot both fit under the same limit
+	var customer entity.Customer
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", faktur.IdCustomer).Take(&customer).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return "", fmt.Errorf("Customer with ID %s not found", faktur.IdCustomer)
+		}
+		return "", err
+	}
+
+	var blokir error
+	if customer.LimitKredit > 0 {
+		var outstanding int64
+		if err := tx.Model(&entity.Faktur{}).
+			Where("id_customer = ? AND id <> ? AND status IN ?", faktur.IdCustomer, faktur.ID,
+				[]string{constants.ENUM_FAKTUR_BELUM_BAYAR, constants.ENUM_FAKTUR_SEBAGIAN}).
+			Select("COALESCE(SUM(total - total_bayar), 0)").
+			Scan(&outstanding).Error; err != nil {
+			return "", err
+		}
+
+		if int(outstanding)+total > customer.LimitKredit {
+			blokir = fmt.Errorf("%w: outstanding %d plus %d is over the limit of %d", dto.ErrLimitKredit, outstanding, total, customer.LimitKredit)
+		}
+	}
+
+	if blokir == nil && customer.MaxHariTempo > 0 {
+		var overdue entity.Faktur
+		err := tx.
+			Where("id_customer = ? AND id <> ? AND status IN ?", faktur.IdCustomer, faktur.ID,
+				[]string{constants.ENUM_FAKTUR_BELUM_BAYAR, constants.ENUM_FAKTUR_SEBAGIAN}).
+			Where("tanggal_tempo < ?", time.Now().AddDate(0, 0, -customer.MaxHariTempo)).
+			Order("tanggal_tempo ASC").
+			Take(&overdue).Error
+		if err == nil {
+			blokir = fmt.Errorf("%w: %s is due since %s", dto.ErrTempoTerlampaui, overdue.NoFaktur, overdue.TanggalTempo.Format(constants.ENUM_DATE_FORMAT))
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", err
+		}
+	}
+
+	if blokir == nil {
+		return "", nil
+	}
+
+	var override entity.OverrideKredit
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id_customer = ? AND id_faktur = '' AND berlaku_sampai >= ?", faktur.IdCustomer, time.Now()).
+		Order("created_at ASC").
+		Take(&override).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", blokir
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if err := tx.Model(&override).Update("id_faktur", faktur.ID.String()).Error; err != nil {
+		return "", err
+	}
+
+	return override.ID.String(), nil
+}
+
+// filterFaktur applies the optional list filters of a faktur query.
+func filterFaktur(req dto.FakturFilterRequest) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if req.IdCustomer != "" {
+			db = db.Where("id_customer = ?", req.IdCustomer)
+		}
+		if req.IdUser != "" {
+			db = db.Where("id_user = ?", req.IdUser)
+		}
+		if req.IdLoading != "" {
+			db = db.Where("id_loading = ?", req.IdLoading)
+		}
+		if req.Status != "" {
+			db = db.Where("status = ?", req.Status)
+		}
+		if req.CaraBayar != "" {
+			db = db.Where("cara_bayar = ?", req.CaraBayar)
+		}
+		if req.TanggalMulai != "" {
+			db = db.Where("tanggal_faktur >= ?", req.TanggalMulai)
+		}
+		if req.TanggalAkhir != "" {
+			db = db.Where("tanggal_faktur < (?::date + 1)", req.TanggalAkhir)
+		}
+		return db.Scopes(fakturListOptions.Search(req.Search))
+	}
+}
+
+// fakturListOptions are the searchable and sortable columns of the faktur list.
+var fakturListOptions = ListOptions{
+	Searchable: []string{"no_faktur", "keterangan"},
+	Sortable: map[string]string{
+		"no_faktur":      "no_faktur",
+		"tanggal_faktur": "tanggal_faktur",
+		"tanggal_tempo":  "tanggal_tempo",
+		"total":          "total",
+		"status":         "status",
+		"created_at":     "created_at",
+	},
+	DefaultSort: "tanggal_faktur DESC, created_at DESC, id ASC",
+}
+
+func (r *fakturRepository) AddFaktur(ctx context.Context, faktur entity.Faktur, pakaiKreditCustomer bool, ownerId string) (entity.Faktur, error) {
+	items := faktur.Items
+	faktur.Items = nil
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Hold the loading until the lines are booked, so two fakturs cannot
+		// both sell the last of it
+		if faktur.IdLoading != "" {
+			if err := lockLoadingFaktur(tx, faktur.IdLoading, ownerId); err != nil {
+				return err
+			}
+		}
+
+		// Take the invoice number in the same transaction to keep the sequence gap-free
+		noFaktur, err := nextNomorDokumen(tx, constants.ENUM_DOKUMEN_FAKTUR, *faktur.TanggalFaktur)
+		if err != nil {
+			return err
+		}
+		faktur.NoFaktur = noFaktur
+
+		// The PPN rate and pricing in force today stay with the faktur
+		setting, err := mainSettingPajak(tx)
+		if err != nil {
+			return err
+		}
+		faktur.TarifPpn = setting.TarifPpn
+		if faktur.HargaTermasukPpn == nil {
+			faktur.HargaTermasukPpn = &setting.HargaTermasukPpn
+		}
+		if faktur.Eceran, err = isEceran(tx, faktur.IdCustomer); err != nil {
+			return err
+		}
+
+		// Create the faktur header first so the lines can reference it
+		if err := tx.Omit(clause.Associations).Create(&faktur).Error; err != nil {
+			return err
+		}
+
+		nominalDulu := setting.UrutanDiskon == constants.ENUM_URUTAN_DISKON_NOMINAL_PERSEN
+		if err := saveFakturItems(tx, &faktur, items, nominalDulu); err != nil {
+			return err
+		}
+
+		// Credit of the customer is taken off first, so only the rest has to
+		// fit under the credit limit
+		dipakai := 0
+		if pakaiKreditCustomer && faktur.Total > 0 {
+			if dipakai, err = pakaiKredit(tx, faktur, 0); err != nil {
+				return err
+			}
+		}
+
+		if faktur.CaraBayar == constants.ENUM_CARA_BAYAR_KREDIT && faktur.Total > dipakai {
+			if faktur.IdOverrideKredit, err = checkKreditCustomer(tx, faktur, faktur.Total-dipakai); err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&faktur).Updates(map[string]interface{}{
+			"subtotal":           faktur.Subtotal,
+			"potongan_diskon":    faktur.PotonganDiskon,
+			"total":              faktur.Total,
+			"dpp":                faktur.Dpp,
+			"ppn":                faktur.Ppn,
+			"id_override_kredit": faktur.IdOverrideKredit,
+		}).Error
+	})
+	if err != nil {
+		return entity.Faktur{}, err
+	}
+
+	return r.GetFakturById(ctx, faktur.ID.String())
+}
+
+func (r *fakturRepository) GetAllFakturWithPagination(ctx context.Context, req dto.FakturFilterRequest) (dto.GetAllFakturRepositoryResponse, error) {
+	tx := r.db
+
+	var fakturs []entity.Faktur
+	var count int64
+
+	NormalizePagination(&req.PaginationRequest)
+	order, err := fakturListOptions.Order(req.Sort, req.Order)
+	if err != nil {
+		return dto.GetAllFakturRepositoryResponse{}, err
+	}
+
+	if err := tx.WithContext(ctx).Model(&entity.Faktur{}).Scopes(filterFaktur(req)).Count(&count).Error; err != nil {
+		return dto.GetAllFakturRepositoryResponse{}, err
+	}
+
+	if err := preloadFaktur(tx.WithContext(ctx)).
+		Scopes(filterFaktur(req)).
+		Order(order).
+		Scopes(Paginate(req.Page, req.PerPage)).
+		Find(&fakturs).Error; err != nil {
+		return dto.GetAllFakturRepositoryResponse{}, err
+	}
+
+	return dto.GetAllFakturRepositoryResponse{
+		Fakturs:            fakturs,
+		PaginationResponse: NewPaginationResponse(req.PaginationRequest, count),
+	}, nil
+}
+
+func (r *fakturRepository) GetFakturById(ctx context.Context, fakturId string) (entity.Faktur, error) {
+	tx := r.db
+
+	var faktur entity.Faktur
+	if err := preloadFaktur(tx.WithContext(ctx)).Where("id = ?", fakturId).Take(&faktur).Error; err != nil {
+		return entity.Faktur{}, err
+	}
+
+	return faktur, nil
+}
+
+func (r *fakturRepository) UpdateFaktur(ctx context.Context, faktur entity.Faktur, userId string) (entity.Faktur, error) {
+	items := faktur.Items
+	faktur.Items = nil
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock first so a payment accepted meanwhile is seen in the status
+		existingFaktur, err := lockFaktur(tx, faktur.ID.String())
+		if err != nil {
+			return err
+		}
+
+		// Only invoices that have not been paid or cancelled may be changed
+		if existingFaktur.Status != constants.ENUM_FAKTUR_BELUM_BAYAR {
+			return dto.ErrFakturNotEditable
+		}
+
+		// Returned goods refer to the lines of the faktur
+		if retur, err := adaRetur(tx, faktur.ID.String()); err != nil {
+			return err
+		} else if retur {
+			return dto.ErrFakturAdaRetur
+		}
+
+		// Proceed with updating the header
+		if err := tx.Model(&existingFaktur).Omit(clause.Associations).Updates(faktur).Error; err != nil {
+			return err
+		}
+
+		// Read the header back so the credit check sees the changed customer
+		// and cara bayar
+		if err := tx.Where("id = ?", faktur.ID).Take(&existingFaktur).Error; err != nil {
+			return err
+		}
+		// The discount is written as sent, so it can also be taken off
+		existingFaktur.Diskon, existingFaktur.DiskonP = faktur.Diskon, faktur.DiskonP
+
+		if existingFaktur.Eceran, err = isEceran(tx, existingFaktur.IdCustomer); err != nil {
+			return err
+		}
+		setting, err := mainSettingPajak(tx)
+		if err != nil {
+			return err
+		}
+		nominalDulu := setting.UrutanDiskon == constants.ENUM_URUTAN_DISKON_NOMINAL_PERSEN
+
+		// Lines are replaced as a whole when they are sent
+		if len(items) > 0 {
+			if existingFaktur.IdLoading != "" {
+				if err := lockLoadingFaktur(tx, existingFaktur.IdLoading, ""); err != nil {
+					return err
+				}
+			}
+			if err := reverseStockMovements(tx, constants.ENUM_DOKUMEN_FAKTUR, faktur.ID.String(), userId, "perubahan faktur"); err != nil {
+				return err
+			}
+			if err := tx.Where("id_faktur = ?", faktur.ID.String()).Delete(&entity.TransaksiFaktur{}).Error; err != nil {
+				return err
+			}
+
+			// The new lines are booked under the user who changed the faktur
+			posting := existingFaktur
+			posting.IdUser = userId
+			if err := saveFakturItems(tx, &posting, items, nominalDulu); err != nil {
+				return err
+			}
+			existingFaktur.Subtotal, existingFaktur.PotonganDiskon = posting.Subtotal, posting.PotonganDiskon
+			existingFaktur.Dpp, existingFaktur.Ppn, existingFaktur.Total = posting.Dpp, posting.Ppn, posting.Total
+		} else if err := hargaUlangFaktur(tx, &existingFaktur, nominalDulu); err != nil {
+			return err
+		}
+
+		// A faktur that already went through with an override keeps it
+		if existingFaktur.CaraBayar == constants.ENUM_CARA_BAYAR_KREDIT && existingFaktur.IdOverrideKredit == "" {
+			override, err := checkKreditCustomer(tx, existingFaktur, existingFaktur.Total)
+			if err != nil {
+				return err
+			}
+			existingFaktur.IdOverrideKredit = override
+		}
+
+		if err := tx.Model(&existingFaktur).Updates(map[string]interface{}{
+			"subtotal":           existingFaktur.Subtotal,
+			"diskon":             existingFaktur.Diskon,
+			"diskon_p":           existingFaktur.DiskonP,
+			"potongan_diskon":    existingFaktur.PotonganDiskon,
+			"total":              existingFaktur.Total,
+			"dpp":                existingFaktur.Dpp,
+			"ppn":                existingFaktur.Ppn,
+			"eceran":             existingFaktur.Eceran,
+			"id_override_kredit": existingFaktur.IdOverrideKredit,
+		}).Error; err != nil {
+			return err
+		}
+
+		// The amount paid stays as it was, the status follows the new total
+		return updateTotalBayar(tx, existingFaktur)
+	})
+	if err != nil {
+		return entity.Faktur{}, err
+	}
+
+	return r.GetFakturById(ctx, faktur.ID.String())
+}
+
+// CancelFaktur voids a faktur. The faktur and its number stay with status
+// batal, its goods go back into stock and the payments accepted on it are
+// voided; the money received and the credit applied to it stay with the
+// customer as credit.
+func (r *fakturRepository) CancelFaktur(ctx context.Context, fakturId string, alasan string, userId string) (entity.Faktur, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		faktur, err := lockFaktur(tx, fakturId)
+		if err != nil {
+			return err
+		}
+		if faktur.Status == constants.ENUM_FAKTUR_BATAL {
+			return dto.ErrFakturBatal
+		}
+
+		// Returned goods refer to the lines of the faktur
+		if retur, err := adaRetur(tx, fakturId); err != nil {
+			return err
+		} else if retur {
+			return dto.ErrFakturAdaRetur
+		}
+
+		// Goods of a cancelled faktur go back into stock
+		if err := reverseStockMovements(tx, constants.ENUM_DOKUMEN_FAKTUR, fakturId, userId, "faktur dibatalkan"); err != nil {
+			return err
+		}
+
+		var pembayarans []entity.Pembayaran
+		if err := tx.Where("id_faktur = ? AND status_bukti IN ?", fakturId, []string{constants.ENUM_BUKTI_PENDING, constants.ENUM_BUKTI_DITERIMA}).
+			Find(&pembayarans).Error; err != nil {
+			return err
+		}
+
+		for _, pembayaran := range pembayarans {
+			// A proof still waiting for review has to be decided on first
+			if pembayaran.StatusBukti == constants.ENUM_BUKTI_PENDING {
+				return dto.ErrPembayaranPending
+			}
+		}
+
+		for _, pembayaran := range pembayarans {
+			if err := tx.Create(&entity.KreditCustomer{
+				IdCustomer:   faktur.IdCustomer,
+				IdPembayaran: pembayaran.ID.String(),
+				Jumlah:       pembayaran.Jumlah,
+				Keterangan:   "pembatalan " + faktur.NoFaktur,
+			}).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&pembayaran).Update("status_bukti", constants.ENUM_BUKTI_BATAL).Error; err != nil {
+				return err
+			}
+		}
+
+		// Credit applied to the faktur goes back to the customer
+		dipakai, err := jumlahKreditDipakai(tx, fakturId)
+		if err != nil {
+			return err
+		}
+		if dipakai > 0 {
+			if err := tx.Create(&entity.KreditCustomer{
+				IdCustomer: faktur.IdCustomer,
+				IdFaktur:   fakturId,
+				Jumlah:     dipakai,
+				Keterangan: "pembatalan " + faktur.NoFaktur,
+			}).Error; err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		return tx.Model(&faktur).Updates(map[string]interface{}{
+			"status":        constants.ENUM_FAKTUR_BATAL,
+			"total_bayar":   0,
+			"alasan_batal":  alasan,
+			"id_user_batal": userId,
+			"tanggal_batal": &now,
+		}).Error
+	})
+	if err != nil {
+		return entity.Faktur{}, err
+	}
+
+	return r.GetFakturById(ctx, fakturId)
+}
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jejevj/ykp_pos/constants"
+	"github.com/jejevj/ykp_pos/dto"
+	"github.com/jejevj/ykp_pos/entity"
+	"gorm.io/gorm"
+)
+
+type (
+	LaporanRepository interface {
+		GetPiutang(ctx context.Context, tanggal time.Time, idCustomer string, idUser string) (dto.GetPiutangRepositoryResponse, error)
+		GetFakturPajak(ctx context.Context, mulai time.Time, akhir time.Time) ([]entity.Faktur, error)
+	}
+	laporanRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewLaporanRepository(db *gorm.DB) LaporanRepository {
+	return &laporanRepository{
+		db: db,
+	}
+}
+
+// filterPiutang selects the fakturs issued up to the report date that can
+// still carry a balance. A voided faktur counts as long as it was voided after
+// the report date.
+func filterPiutang(batas time.Time, idCustomer string, idUser string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		db = db.Where("tanggal_faktur < ?", batas).
+			Where("status <> ? OR tanggal_batal >= ?", constants.ENUM_FAKTUR_BATAL, batas)
+		if idCustomer != "" {
+			db = db.Where("id_customer = ?", idCustomer)
+		}
+		if idUser != "" {
+			db = db.Where("id_user = ?", idUser)
+		}
+		return db
+	}
+}
+
+// GetPiutang returns the fakturs issued up to and including tanggal together
+// with what was paid or credited on them by that day, so the balance can be
+// rebuilt for any past date.
+func (r *laporanRepository) GetPiutang(ctx context.Context, tanggal time.Time, idCustomer string, idUser string) (dto.GetPiutangRepositoryResponse, error) {
+	tx := r.db
+	batas := tanggal.AddDate(0, 0, 1)
+
+	var fakturs []entity.Faktur
+	if err := tx.WithContext(ctx).
+		Preload("Customer").
+		Preload("Driver").
+		Scopes(filterPiutang(batas, idCustomer, idUser)).
+		Order("tanggal_faktur ASC, no_faktur ASC").
+		Find(&fakturs).Error; err != nil {
+		return dto.GetPiutangRepositoryResponse{}, err
+	}
+
+	var rows []struct {
+		IdFaktur string
+		Jumlah   int
+	}
+	// Voiding a faktur flips its accepted payments to batal; on a faktur voided
+	// after the report date they were still paid on that day
+	if err := tx.WithContext(ctx).Model(&entity.Pembayaran{}).
+		Select("id_faktur, SUM(jumlah) AS jumlah").
+		Where("status_bukti IN ? AND tanggal_bayar < ?", []string{constants.ENUM_BUKTI_DITERIMA, constants.ENUM_BUKTI_BATAL}, batas).
+		Where("id_faktur IN (?)", tx.Model(&entity.Faktur{}).Select("id").Scopes(filterPiutang(batas, idCustomer, idUser))).
+		Group("id_faktur").
+		Scan(&rows).Error; err != nil {
+		return dto.GetPiutangRepositoryResponse{}, err
+	}
+
+	dibayar := map[string]int{}
+	for _, row := range rows {
+		dibayar[row.IdFaktur] = row.Jumlah
+	}
+
+	// Credit notes taken off a faktur lower its balance like a payment
+	rows = nil
+	if err := tx.WithContext(ctx).Model(&entity.NotaKredit{}).
+		Select("id_faktur, SUM(potong_faktur) AS jumlah").
+		Where("tanggal < ?", batas).
+		Where("id_faktur IN (?)", tx.Model(&entity.Faktur{}).Select("id").Scopes(filterPiutang(batas, idCustomer, idUser))).
+		Group("id_faktur").
+		Scan(&rows).Error; err != nil {
+		return dto.GetPiutangRepositoryResponse{}, err
+	}
+	for _, row := range rows {
+		dibayar[row.IdFaktur] += row.Jumlah
+	}
+
+	// So does customer credit applied to it. Its id_faktur is an optional
+	// reference, kept as text
+	rows = nil
+	if err := tx.WithContext(ctx).Model(&entity.KreditCustomer{}).
+		Select("id_faktur, -SUM(jumlah) AS jumlah").
+		Where("created_at < ?", batas).
+		Where("id_faktur IN (?)", tx.Model(&entity.Faktur{}).Select("id::text").Scopes(filterPiutang(batas, idCustomer, idUser))).
+		Group("id_faktur").
+		Scan(&rows).Error; err != nil {
+		return dto.GetPiutangRepositoryResponse{}, err
+	}
+	for _, row := range rows {
+		dibayar[row.IdFaktur] += row.Jumlah
+	}
+
+	return dto.GetPiutangRepositoryResponse{
+		Fakturs: fakturs,
+		Dibayar: dibayar,
+	}, nil
+}
+
+// GetFakturPajak returns the fakturs carrying PPN issued from mulai up to but
+// not including akhir, with their lines for the e-Faktur export.
+func (r *laporanRepository) GetFakturPajak(ctx context.Context, mulai time.Time, akhir time.Time) ([]entity.Faktur, error) {
+	tx := r.db
+
+	var fakturs []entity.Faktur
+	if err := tx.WithContext(ctx).
+		Preload("Customer").
+		Preload("Items.Barang").
+		Where("tanggal_faktur >= ? AND tanggal_faktur < ?", mulai, akhir).
+		Where("status <> ? AND ppn > 0", constants.ENUM_FAKTUR_BATAL).
+		Order("tanggal_faktur ASC, no_faktur ASC").
+		Find(&fakturs).Error; err != nil {
+		return nil, err
+	}
+
+	return fakturs, nil
+}
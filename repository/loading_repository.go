@@ -3,20 +3,23 @@ package repository
 import (
 	"context"
 	"fmt"
-	"math"
+	"time"
 
+	"github.com/jejevj/ykp_pos/constants"
 	"github.com/jejevj/ykp_pos/dto"
 	"github.com/jejevj/ykp_pos/entity"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type (
 	LoadingRepository interface {
 		AddLoading(ctx context.Context, loading entity.Loading) (entity.Loading, error)
-		GetAllLoadingWithPagination(ctx context.Context) (dto.GetAllLoadingRepositoryResponse, error)
+		GetAllLoadingWithPagination(ctx context.Context, req dto.LoadingFilterRequest) (dto.GetAllLoadingRepositoryResponse, error)
 		GetLoadingById(ctx context.Context, loadingId string) (entity.Loading, error)
 		UpdateLoading(ctx context.Context, loading entity.Loading) (entity.Loading, error)
-		DeleteLoading(ctx context.Context, loadingId string) error
+		UpdateStatusLoading(ctx context.Context, loadingId string, from string, to string, userId string) (entity.Loading, error)
+		DeleteLoading(ctx context.Context, loadingId string, userId string) error
 	}
 	loadingRepository struct {
 		db *gorm.DB
@@ -32,8 +35,16 @@ func NewLoadingRepository(db *gorm.DB) LoadingRepository {
 func (r *loadingRepository) AddLoading(ctx context.Context, loading entity.Loading) (entity.Loading, error) {
 	tx := r.db
 
-	// Start a transaction
-	err := tx.WithContext(ctx).Create(&loading).Error
+	err := tx.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Take the loading number in the same transaction to keep the sequence gap-free
+		noLoading, err := nextNomorDokumen(tx, constants.ENUM_DOKUMEN_LOADING, time.Now())
+		if err != nil {
+			return err
+		}
+		loading.NoLoading = noLoading
+
+		return tx.Create(&loading).Error
+	})
 	if err != nil {
 		return entity.Loading{}, err
 	}
@@ -50,32 +61,59 @@ func (r *loadingRepository) AddLoading(ctx context.Context, loading entity.Loadi
 	return loading, nil
 }
 
-func (r *loadingRepository) GetAllLoadingWithPagination(ctx context.Context) (dto.GetAllLoadingRepositoryResponse, error) {
+// filterLoading applies the optional list filters of a loading query.
+func filterLoading(req dto.LoadingFilterRequest) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if req.IdUser != "" {
+			db = db.Where("id_user = ?", req.IdUser)
+		}
+		if req.Status != "" {
+			db = db.Where("status = ?", req.Status)
+		}
+		return db.Scopes(loadingListOptions.Search(req.Search))
+	}
+}
+
+// loadingListOptions are the searchable and sortable columns of the loading list.
+var loadingListOptions = ListOptions{
+	Searchable: []string{"no_loading", "status"},
+	Sortable: map[string]string{
+		"no_loading": "no_loading",
+		"status":     "status",
+		"created_at": "created_at",
+	},
+	DefaultSort: "created_at DESC, id ASC",
+}
+
+func (r *loadingRepository) GetAllLoadingWithPagination(ctx context.Context, req dto.LoadingFilterRequest) (dto.GetAllLoadingRepositoryResponse, error) {
 	tx := r.db
 
 	var loadings []entity.Loading
-	var err error
 	var count int64
 
-	if err := tx.WithContext(ctx).Model(&entity.Loading{}).Count(&count).Error; err != nil {
+	NormalizePagination(&req.PaginationRequest)
+	order, err := loadingListOptions.Order(req.Sort, req.Order)
+	if err != nil {
 		return dto.GetAllLoadingRepositoryResponse{}, err
 	}
 
-	if err := tx.WithContext(ctx).Preload("User").Scopes(Paginate(1, 10)).Find(&loadings).Error; err != nil {
+	if err := tx.WithContext(ctx).Model(&entity.Loading{}).Scopes(filterLoading(req)).Count(&count).Error; err != nil {
 		return dto.GetAllLoadingRepositoryResponse{}, err
 	}
 
-	totalPage := int64(math.Ceil(float64(count) / float64(10)))
+	if err := tx.WithContext(ctx).
+		Preload("User").
+		Scopes(filterLoading(req)).
+		Order(order).
+		Scopes(Paginate(req.Page, req.PerPage)).
+		Find(&loadings).Error; err != nil {
+		return dto.GetAllLoadingRepositoryResponse{}, err
+	}
 
 	return dto.GetAllLoadingRepositoryResponse{
-		Loadings: loadings,
-		PaginationResponse: dto.PaginationResponse{
-			Page:    1,
-			PerPage: 10,
-			Count:   count,
-			MaxPage: totalPage,
-		},
-	}, err
+		Loadings:           loadings,
+		PaginationResponse: NewPaginationResponse(req.PaginationRequest, count),
+	}, nil
 }
 func (r *loadingRepository) GetLoadingById(ctx context.Context, loadingId string) (entity.Loading, error) {
 	tx := r.db
@@ -100,20 +138,105 @@ func (r *loadingRepository) UpdateLoading(ctx context.Context, loading entity.Lo
 		return entity.Loading{}, err
 	}
 
-	// Proceed with updating the record
-	if err := tx.WithContext(ctx).Model(&existingLoading).Updates(loading).Error; err != nil {
+	// Proceed with updating the record, the status only moves through UpdateStatusLoading
+	if err := tx.WithContext(ctx).Model(&existingLoading).Omit("status", clause.Associations).Updates(loading).Error; err != nil {
 		return entity.Loading{}, err
 	}
 
 	// Return the updated entity
-	return existingLoading, nil
+	return r.GetLoadingById(ctx, loading.ID.String())
+}
+
+// loadingStatusColumns names the timestamp and acting user columns written
+// when a loading reaches a status.
+var loadingStatusColumns = map[string][2]string{
+	constants.ENUM_LOADING_SUBMITTED:  {"submitted_at", "submitted_by"},
+	constants.ENUM_LOADING_APPROVED:   {"approved_at", "approved_by"},
+	constants.ENUM_LOADING_DISPATCHED: {"dispatched_at", "dispatched_by"},
+	constants.ENUM_LOADING_RETURNED:   {"returned_at", "returned_by"},
+	constants.ENUM_LOADING_SETTLED:    {"settled_at", "settled_by"},
+}
+
+// setLoadingStatus writes the new status together with the moment and the
+// acting user of the step.
+func setLoadingStatus(tx *gorm.DB, loading entity.Loading, to string, userId string) error {
+	updates := map[string]interface{}{"status": to}
+	if columns, ok := loadingStatusColumns[to]; ok {
+		updates[columns[0]] = time.Now()
+		updates[columns[1]] = userId
+	}
+
+	return tx.Model(&entity.Loading{}).Where("id = ?", loading.ID).Updates(updates).Error
 }
-func (r *loadingRepository) DeleteLoading(ctx context.Context, loadingId string) error {
-	tx := r.db
 
-	if err := tx.WithContext(ctx).Delete(&entity.Loading{}, "id = ?", loadingId).Error; err != nil {
+func (r *loadingRepository) UpdateStatusLoading(ctx context.Context, loadingId string, from string, to string, userId string) (entity.Loading, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock the loading so two transitions cannot run at the same time
+		var existingLoading entity.Loading
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", loadingId).Take(&existingLoading).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return fmt.Errorf("Loading with ID %s not found", loadingId)
+			}
+			return err
+		}
+
+		if existingLoading.Status != from {
+			return fmt.Errorf("%w: loading is %s, expected %s", dto.ErrLoadingInvalidTransition, existingLoading.Status, from)
+		}
+
+		switch {
+		case to == constants.ENUM_LOADING_APPROVED:
+			// Approval takes the goods from the warehouse onto the truck
+			if err := moveLoadingStock(tx, existingLoading, userId); err != nil {
+				return err
+			}
+		case from == constants.ENUM_LOADING_APPROVED && to == constants.ENUM_LOADING_SUBMITTED:
+			if err := reverseStockMovements(tx, constants.ENUM_DOKUMEN_LOADING, loadingId, userId, "approval loading dibatalkan"); err != nil {
+				return err
+			}
+		}
+
+		return setLoadingStatus(tx, existingLoading, to, userId)
+	})
+	if err != nil {
+		return entity.Loading{}, err
+	}
+
+	return r.GetLoadingById(ctx, loadingId)
+}
+
+// moveLoadingStock deducts every Transaksi line of the loading from the
+// warehouse stock. It fails as a whole when one barang lacks stock.
+func moveLoadingStock(tx *gorm.DB, loading entity.Loading, userId string) error {
+	var transaksis []entity.Transaksi
+	if err := tx.Where("id_loading = ?", loading.ID.String()).Find(&transaksis).Error; err != nil {
 		return err
 	}
 
+	for _, transaksi := range transaksis {
+		if _, err := moveStock(tx, entity.StockMovement{
+			IdBarang: transaksi.IdBarang,
+			Jumlah:   -transaksi.Jumlah,
+			Alasan:   constants.ENUM_STOK_LOADING,
+			RefTipe:  constants.ENUM_DOKUMEN_LOADING,
+			RefId:    loading.ID.String(),
+			RefNo:    loading.NoLoading,
+			IdUser:   userId,
+		}); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
+
+func (r *loadingRepository) DeleteLoading(ctx context.Context, loadingId string, userId string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Goods of an approved loading go back to the warehouse
+		if err := reverseStockMovements(tx, constants.ENUM_DOKUMEN_LOADING, loadingId, userId, "loading dihapus"); err != nil {
+			return err
+		}
+
+		return tx.Delete(&entity.Loading{}, "id = ?", loadingId).Error
+	})
+}
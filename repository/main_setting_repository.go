@@ -3,7 +3,6 @@ package repository
 import (
 	"context"
 	"fmt"
-	"math"
 
 	"github.com/jejevj/ykp_pos/dto"
 	"github.com/jejevj/ykp_pos/entity"
@@ -13,10 +12,13 @@ import (
 type (
 	MainSettingRepository interface {
 		AddMainSetting(ctx context.Context, msetting entity.MainSetting) (entity.MainSetting, error)
-		GetAllMainSettingWithPagination(ctx context.Context) (dto.GetAllMainSettingRepositoryResponse, error)
+		GetAllMainSettingWithPagination(ctx context.Context, req dto.PaginationRequest) (dto.GetAllMainSettingRepositoryResponse, error)
 		GetMainSettingById(ctx context.Context, msettingId string) (entity.MainSetting, error)
 		UpdateMainSetting(ctx context.Context, msetting entity.MainSetting) (entity.MainSetting, error)
 		DeleteMainSetting(ctx context.Context, msettingId string) error
+		UpdatePajakMainSetting(ctx context.Context, msetting entity.MainSetting) (entity.MainSetting, error)
+		UpdateQrisMainSetting(ctx context.Context, msetting entity.MainSetting) (entity.MainSetting, error)
+		GetMainSetting(ctx context.Context) (entity.MainSetting, error)
 	}
 	mainSettingRepository struct {
 		db *gorm.DB
@@ -38,32 +40,44 @@ func (r *mainSettingRepository) AddMainSetting(ctx context.Context, msetting ent
 	return msetting, nil
 }
 
-func (r *mainSettingRepository) GetAllMainSettingWithPagination(ctx context.Context) (dto.GetAllMainSettingRepositoryResponse, error) {
+// mainSettingListOptions are the searchable and sortable columns of the main setting list.
+var mainSettingListOptions = ListOptions{
+	Searchable: []string{"nama_usaha", "jenis_usaha", "alamat"},
+	Sortable: map[string]string{
+		"nama_usaha": "nama_usaha",
+		"created_at": "created_at",
+	},
+	DefaultSort: "created_at ASC, id ASC",
+}
+
+func (r *mainSettingRepository) GetAllMainSettingWithPagination(ctx context.Context, req dto.PaginationRequest) (dto.GetAllMainSettingRepositoryResponse, error) {
 	tx := r.db
 
 	var msettings []entity.MainSetting
-	var err error
 	var count int64
 
-	if err := tx.WithContext(ctx).Model(&entity.MainSetting{}).Count(&count).Error; err != nil {
+	NormalizePagination(&req)
+	order, err := mainSettingListOptions.Order(req.Sort, req.Order)
+	if err != nil {
 		return dto.GetAllMainSettingRepositoryResponse{}, err
 	}
 
-	if err := tx.WithContext(ctx).Scopes(Paginate(1, 10)).Find(&msettings).Error; err != nil {
+	if err := tx.WithContext(ctx).Model(&entity.MainSetting{}).Scopes(mainSettingListOptions.Search(req.Search)).Count(&count).Error; err != nil {
 		return dto.GetAllMainSettingRepositoryResponse{}, err
 	}
 
-	totalPage := int64(math.Ceil(float64(count) / float64(10)))
+	if err := tx.WithContext(ctx).
+		Scopes(mainSettingListOptions.Search(req.Search)).
+		Order(order).
+		Scopes(Paginate(req.Page, req.PerPage)).
+		Find(&msettings).Error; err != nil {
+		return dto.GetAllMainSettingRepositoryResponse{}, err
+	}
 
 	return dto.GetAllMainSettingRepositoryResponse{
-		MainSettings: msettings,
-		PaginationResponse: dto.PaginationResponse{
-			Page:    1,
-			PerPage: 10,
-			Count:   count,
-			MaxPage: totalPage,
-		},
-	}, err
+		MainSettings:       msettings,
+		PaginationResponse: NewPaginationResponse(req, count),
+	}, nil
 }
 func (r *mainSettingRepository) GetMainSettingById(ctx context.Context, msettingId string) (entity.MainSetting, error) {
 	tx := r.db
@@ -75,6 +89,20 @@ func (r *mainSettingRepository) GetMainSettingById(ctx context.Context, msetting
 
 	return msetting, nil
 }
+
+// GetMainSetting returns the settings in force, which are the first ones
+// created. A business without settings gets an empty one.
+func (r *mainSettingRepository) GetMainSetting(ctx context.Context) (entity.MainSetting, error) {
+	tx := r.db
+
+	var msetting entity.MainSetting
+	if err := tx.WithContext(ctx).Order("created_at ASC").Take(&msetting).Error; err != nil && err != gorm.ErrRecordNotFound {
+		return entity.MainSetting{}, err
+	}
+
+	return msetting, nil
+}
+
 func (r *mainSettingRepository) UpdateMainSetting(ctx context.Context, msetting entity.MainSetting) (entity.MainSetting, error) {
 	tx := r.db
 
@@ -101,3 +129,40 @@ func (r *mainSettingRepository) DeleteMainSetting(ctx context.Context, msettingI
 
 	return nil
 }
+
+// UpdatePajakMainSetting writes the tax settings as a whole, so PPN can be
+// switched off with a rate of 0.
+func (r *mainSettingRepository) UpdatePajakMainSetting(ctx context.Context, msetting entity.MainSetting) (entity.MainSetting, error) {
+	tx := r.db
+
+	result := tx.WithContext(ctx).Model(&entity.MainSetting{}).
+		Where("id = ?", msetting.ID).
+		Select("npwp", "tarif_ppn", "harga_termasuk_ppn", "urutan_diskon").
+		Updates(msetting)
+	if result.Error != nil {
+		return entity.MainSetting{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entity.MainSetting{}, fmt.Errorf("MainSetting with ID %s not found", msetting.ID)
+	}
+
+	return r.GetMainSettingById(ctx, msetting.ID.String())
+}
+
+// UpdateQrisMainSetting writes the merchant QRIS, an empty payload clears it.
+func (r *mainSettingRepository) UpdateQrisMainSetting(ctx context.Context, msetting entity.MainSetting) (entity.MainSetting, error) {
+	tx := r.db
+
+	result := tx.WithContext(ctx).Model(&entity.MainSetting{}).
+		Where("id = ?", msetting.ID).
+		Select("qris_statis").
+		Updates(msetting)
+	if result.Error != nil {
+		return entity.MainSetting{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entity.MainSetting{}, fmt.Errorf("MainSetting with ID %s not found", msetting.ID)
+	}
+
+	return r.GetMainSettingById(ctx, msetting.ID.String())
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jejevj/ykp_pos/constants"
+	"github.com/jejevj/ykp_pos/entity"
+	"github.com/jejevj/ykp_pos/helpers"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	NomorDokumenRepository interface {
+		PreviewNomor(ctx context.Context, jenis string, tanggal time.Time) (string, error)
+	}
+	nomorDokumenRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewNomorDokumenRepository(db *gorm.DB) NomorDokumenRepository {
+	return &nomorDokumenRepository{
+		db: db,
+	}
+}
+
+// formatNomorDokumen reads the numbering configuration of the business and
+// returns the prefix of the requested document type.
+func formatNomorDokumen(tx *gorm.DB, jenis string) (entity.MainSetting, string, error) {
+	var setting entity.MainSetting
+	err := tx.Order("created_at ASC").Take(&setting).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return entity.MainSetting{}, "", err
+	}
+
+	switch jenis {
+	case constants.ENUM_DOKUMEN_FAKTUR:
+		if setting.PrefixFaktur == "" {
+			setting.PrefixFaktur = "INV"
+		}
+		return setting, setting.PrefixFaktur, nil
+	case constants.ENUM_DOKUMEN_LOADING:
+		if setting.PrefixLoading == "" {
+			setting.PrefixLoading = "LD"
+		}
+		return setting, setting.PrefixLoading, nil
+	case constants.ENUM_DOKUMEN_RETUR:
+		if setting.PrefixRetur == "" {
+			setting.PrefixRetur = "RTR"
+		}
+		return setting, setting.PrefixRetur, nil
+	case constants.ENUM_DOKUMEN_NOTA_KREDIT:
+		if setting.PrefixNotaKredit == "" {
+			setting.PrefixNotaKredit = "NK"
+		}
+		return setting, setting.PrefixNotaKredit, nil
+	case constants.ENUM_DOKUMEN_PENJUALAN:
+		if setting.PrefixPenjualan == "" {
+			setting.PrefixPenjualan = "POS"
+		}
+		return setting, setting.PrefixPenjualan, nil
+	case constants.ENUM_DOKUMEN_PO:
+		if setting.PrefixPo == "" {
+			setting.PrefixPo = "PO"
+		}
+		return setting, setting.PrefixPo, nil
+	case constants.ENUM_DOKUMEN_PENERIMAAN:
+		if setting.PrefixPenerimaan == "" {
+			setting.PrefixPenerimaan = "GRN"
+		}
+		return setting, setting.PrefixPenerimaan, nil
+	}
+
+	return entity.MainSetting{}, "", fmt.Errorf("unknown document type %s", jenis)
+}
+
+// nextNomorDokumen takes the next number of a document type. It must be called
+// inside the transaction that stores the document: the sequence row stays
+// locked until that transaction ends and a rollback returns the number, so no
+// gaps appear even with several server instances.
+func nextNomorDokumen(tx *gorm.DB, jenis string, tanggal time.Time) (string, error) {
+	setting, prefix, err := formatNomorDokumen(tx, jenis)
+	if err != nil {
+		return "", err
+	}
+
+	periode := helpers.PeriodeNomor(setting.ResetNomor, tanggal)
+
+	// Make sure the sequence row exists, concurrent inserts wait on the unique index
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&entity.NomorDokumen{
+		Jenis:   jenis,
+		Periode: periode,
+	}).Error; err != nil {
+		return "", err
+	}
+
+	var sequence entity.NomorDokumen
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("jenis = ? AND periode = ?", jenis, periode).
+		Take(&sequence).Error; err != nil {
+		return "", err
+	}
+
+	sequence.Nomor++
+	if err := tx.Model(&sequence).Update("nomor", sequence.Nomor).Error; err != nil {
+		return "", err
+	}
+
+	return helpers.FormatNomor(setting.FormatNomor, prefix, setting.PanjangNomor, tanggal, sequence.Nomor), nil
+}
+
+func (r *nomorDokumenRepository) PreviewNomor(ctx context.Context, jenis string, tanggal time.Time) (string, error) {
+	tx := r.db.WithContext(ctx)
+
+	setting, prefix, err := formatNomorDokumen(tx, jenis)
+	if err != nil {
+		return "", err
+	}
+
+	periode := helpers.PeriodeNomor(setting.ResetNomor, tanggal)
+
+	var sequence entity.NomorDokumen
+	if err := tx.Where("jenis = ? AND periode = ?", jenis, periode).Take(&sequence).Error; err != nil && err != gorm.ErrRecordNotFound {
+		return "", err
+	}
+
+	return helpers.FormatNomor(setting.FormatNomor, prefix, setting.PanjangNomor, tanggal, sequence.Nomor+1), nil
+}
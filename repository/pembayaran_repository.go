@@ -0,0 +1,460 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jejevj/ykp_pos/constants"
+	"github.com/jejevj/ykp_pos/dto"
+	"github.com/jejevj/ykp_pos/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	PembayaranRepository interface {
+		AddPembayaran(ctx context.Context, pembayaran entity.Pembayaran, simpanKredit bool) (entity.Pembayaran, error)
+		GetPembayaranById(ctx context.Context, pembayaranId string) (entity.Pembayaran, error)
+		GetPembayaranByFaktur(ctx context.Context, fakturId string) ([]entity.Pembayaran, error)
+		GetPembayaranPending(ctx context.Context) ([]entity.Pembayaran, error)
+		ReviewPembayaran(ctx context.Context, pembayaranId string, statusBukti string, alasan string, reviewerId string) (entity.Pembayaran, error)
+		DeletePembayaran(ctx context.Context, pembayaranId string) error
+		PakaiKredit(ctx context.Context, fakturId string, jumlah int) (entity.Faktur, int, error)
+		GetSaldoKredit(ctx context.Context, customerId string) (int, []entity.KreditCustomer, error)
+	}
+	pembayaranRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewPembayaranRepository(db *gorm.DB) PembayaranRepository {
+	return &pembayaranRepository{
+		db: db,
+	}
+}
+
+// StatusBayar derives the status of a faktur from the amount paid so far.
+func StatusBayar(total int, totalBayar int) string {
+	switch {
+	case totalBayar <= 0:
+		return constants.ENUM_FAKTUR_BELUM_BAYAR
+	case totalBayar < total:
+		return constants.ENUM_FAKTUR_SEBAGIAN
+	default:
+		return constants.ENUM_FAKTUR_LUNAS
+	}
+}
+
+// lockFaktur loads a faktur and locks it for the rest of the transaction, so
+// payments on the same faktur are booked one after the other.
+func lockFaktur(tx *gorm.DB, fakturId string) (entity.Faktur, error) {
+	var faktur entity.Faktur
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", fakturId).Take(&faktur).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return entity.Faktur{}, fmt.Errorf("Faktur with ID %s not found", fakturId)
+		}
+		return entity.Faktur{}, err
+	}
+
+	return faktur, nil
+}
+
+// jumlahPembayaran adds up the payments on a faktur whose proof has one of
+// the given statuses.
+func jumlahPembayaran(tx *gorm.DB, fakturId string, statusBukti ...string) (int, error) {
+	var jumlah int64
+	if err := tx.Model(&entity.Pembayaran{}).
+		Where("id_faktur = ? AND status_bukti IN ?", fakturId, statusBukti).
+		Select("COALESCE(SUM(jumlah), 0)").
+		Scan(&jumlah).Error; err != nil {
+		return 0, err
+	}
+
+	return int(jumlah), nil
+}
+
+// jumlahKreditDipakai adds up the customer credit applied to a faktur, less
+// what was given back when it was voided.
+func jumlahKreditDipakai(tx *gorm.DB, fakturId string) (int, error) {
+	var jumlah int64
+	if err := tx.Model(&entity.KreditCustomer{}).
+		Where("id_faktur = ?", fakturId).
+		Select("COALESCE(-SUM(jumlah), 0)").
+		Scan(&jumlah).Error; err != nil {
+		return 0, err
+	}
+
+	return int(jumlah), nil
+}
+
+// sisaTagihan is what is still open on a faktur once the payments waiting for
+// review or accepted, the credit notes and the credit applied are taken off.
+func sisaTagihan(tx *gorm.DB, faktur entity.Faktur) (int, error) {
+	dibayar, err := jumlahPembayaran(tx, faktur.ID.String(), constants.ENUM_BUKTI_PENDING, constants.ENUM_BUKTI_DITERIMA)
+	if err != nil {
+		return 0, err
+	}
+	dikredit, err := jumlahNotaKredit(tx, faktur.ID.String())
+	if err != nil {
+		return 0, err
+	}
+	dipakai, err := jumlahKreditDipakai(tx, faktur.ID.String())
+	if err != nil {
+		return 0, err
+	}
+
+	return faktur.Total - dibayar - dikredit - dipakai, nil
+}
+
+// updateTotalBayar recalculates the amount paid on a faktur from its accepted
+// payments, the credit notes taken off it and the customer credit applied to
+// it, and derives the status from it.
+func updateTotalBayar(tx *gorm.DB, faktur entity.Faktur) error {
+	totalBayar, err := jumlahPembayaran(tx, faktur.ID.String(), constants.ENUM_BUKTI_DITERIMA)
+	if err != nil {
+		return err
+	}
+	dikredit, err := jumlahNotaKredit(tx, faktur.ID.String())
+	if err != nil {
+		return err
+	}
+	dipakai, err := jumlahKreditDipakai(tx, faktur.ID.String())
+	if err != nil {
+		return err
+	}
+	totalBayar += dikredit + dipakai
+
+	return tx.Model(&entity.Faktur{}).Where("id = ?", faktur.ID).Updates(map[string]interface{}{
+		"total_bayar": totalBayar,
+		"status":      StatusBayar(faktur.Total, totalBayar),
+	}).Error
+}
+
+func (r *pembayaranRepository) AddPembayaran(ctx context.Context, pembayaran entity.Pembayaran, simpanKredit bool) (entity.Pembayaran, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		faktur, err := lockFaktur(tx, pembayaran.IdFaktur)
+		if err != nil {
+			return err
+		}
+
+		if faktur.Status == constants.ENUM_FAKTUR_BATAL {
+			return dto.ErrFakturBatal
+		}
+
+		// Payments still waiting for review already claim part of the faktur
+		sisa, err := sisaTagihan(tx, faktur)
+		if err != nil {
+			return err
+		}
+		if sisa <= 0 {
+			return dto.ErrFakturLunas
+		}
+
+		// Money paid on top of the outstanding amount is either refused or
+		// kept as credit of the customer
+		if pembayaran.Jumlah > sisa {
+			if !simpanKredit {
+				return fmt.Errorf("%w: outstanding %d", dto.ErrPembayaranMelebihi, sisa)
+			}
+			pembayaran.Kelebihan = pembayaran.Jumlah - sisa
+			pembayaran.Jumlah = sisa
+		}
+
+		// The faktur is only paid once the proof has been accepted
+		pembayaran.StatusBukti = constants.ENUM_BUKTI_PENDING
+		return tx.Omit(clause.Associations).Create(&pembayaran).Error
+	})
+	if err != nil {
+		return entity.Pembayaran{}, err
+	}
+
+	return r.GetPembayaranById(ctx, pembayaran.ID.String())
+}
+
+func (r *pembayaranRepository) GetPembayaranById(ctx context.Context, pembayaranId string) (entity.Pembayaran, error) {
+	tx := r.db
+
+	var pembayaran entity.Pembayaran
+	if err := tx.WithContext(ctx).Preload("Faktur").Preload("Penagih").Where("id = ?", pembayaranId).Take(&pembayaran).Error; err != nil {
+		return entity.Pembayaran{}, err
+	}
+
+	return pembayaran, nil
+}
+
+func (r *pembayaranRepository) GetPembayaranByFaktur(ctx context.Context, fakturId string) ([]entity.Pembayaran, error) {
+	tx := r.db
+
+	var pembayarans []entity.Pembayaran
+	if err := tx.WithContext(ctx).
+		Preload("Faktur").
+		Preload("Penagih").
+		Where("id_faktur = ?", fakturId).
+		Order("tanggal_bayar ASC, created_at ASC").
+		Find(&pembayarans).Error; err != nil {
+		return nil, err
+	}
+
+	return pembayarans, nil
+}
+
+func (r *pembayaranRepository) GetPembayaranPending(ctx context.Context) ([]entity.Pembayaran, error) {
+	tx := r.db
+
+	var pembayarans []entity.Pembayaran
+	if err := tx.WithContext(ctx).
+		Preload("Faktur").
+		Preload("Penagih").
+		Where("status_bukti = ?", constants.ENUM_BUKTI_PENDING).
+		Order("created_at ASC").
+		Find(&pembayarans).Error; err != nil {
+		return nil, err
+	}
+
+	return pembayarans, nil
+}
+
+// ReviewPembayaran accepts or rejects the proof of a pending payment. An
+// accepted payment is added to the faktur and its overpayment becomes credit
+// of the customer; a rejected one is kept for reference but never counts.
+func (r *pembayaranRepository) ReviewPembayaran(ctx context.Context, pembayaranId string, statusBukti string, alasan string, reviewerId string) (entity.Pembayaran, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var pembayaran entity.Pembayaran
+		if err := tx.Where("id = ?", pembayaranId).Take(&pembayaran).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return fmt.Errorf("Pembayaran with ID %s not found", pembayaranId)
+			}
+			return err
+		}
+
+		faktur, err := lockFaktur(tx, pembayaran.IdFaktur)
+		if err != nil {
+			return err
+		}
+
+		// Re-read under the faktur lock so two reviewers cannot both accept it
+		if err := tx.Where("id = ?", pembayaranId).Take(&pembayaran).Error; err != nil {
+			return err
+		}
+		if pembayaran.StatusBukti != constants.ENUM_BUKTI_PENDING {
+			return dto.ErrPembayaranSudahDireview
+		}
+
+		now := time.Now()
+		if err := tx.Model(&pembayaran).Updates(map[string]interface{}{
+			"status_bukti":   statusBukti,
+			"alasan_tolak":   alasan,
+			"id_reviewer":    reviewerId,
+			"tanggal_review": &now,
+		}).Error; err != nil {
+			return err
+		}
+
+		if statusBukti != constants.ENUM_BUKTI_DITERIMA {
+			return nil
+		}
+
+		if pembayaran.Kelebihan > 0 {
+			if err := tx.Create(&entity.KreditCustomer{
+				IdCustomer:   faktur.IdCustomer,
+				IdPembayaran: pembayaran.ID.String(),
+				Jumlah:       pembayaran.Kelebihan,
+				Keterangan:   "kelebihan bayar " + faktur.NoFaktur,
+			}).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&entity.Faktur{}).Where("id = ?", faktur.ID).Update("bukti_bayar", pembayaran.BuktiBayar).Error; err != nil {
+			return err
+		}
+
+		return updateTotalBayar(tx, faktur)
+	})
+	if err != nil {
+		return entity.Pembayaran{}, err
+	}
+
+	return r.GetPembayaranById(ctx, pembayaranId)
+}
+
+// batalKreditPembayaran books the credit a payment created back out of the
+// balance of the customer, as long as it has not been spent.
+func batalKreditPembayaran(tx *gorm.DB, faktur entity.Faktur, pembayaran entity.Pembayaran) error {
+	var kredit int64
+	if err := tx.Model(&entity.KreditCustomer{}).
+		Where("id_pembayaran = ?", pembayaran.ID.String()).
+		Select("COALESCE(SUM(jumlah), 0)").
+		Scan(&kredit).Error; err != nil {
+		return err
+	}
+	if kredit <= 0 {
+		return nil
+	}
+
+	// Lock the customer so the credit is not spent while it is taken back
+	saldo, err := lockSaldoKredit(tx, faktur.IdCustomer)
+	if err != nil {
+		return err
+	}
+	if saldo < int(kredit) {
+		return fmt.Errorf("%w: %d of %d left", dto.ErrKreditTerpakai, max(saldo, 0), kredit)
+	}
+
+	return tx.Create(&entity.KreditCustomer{
+		IdCustomer:   faktur.IdCustomer,
+		IdPembayaran: pembayaran.ID.String(),
+		Jumlah:       -int(kredit),
+		Keterangan:   "hapus pembayaran " + faktur.NoFaktur,
+	}).Error
+}
+
+// DeletePembayaran voids a payment entered by mistake, takes back the credit
+// its overpayment created with a reversing mutation and derives the faktur
+// status again. Credit the customer already spent cannot be taken back.
+func (r *pembayaranRepository) DeletePembayaran(ctx context.Context, pembayaranId string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var pembayaran entity.Pembayaran
+		if err := tx.Where("id = ?", pembayaranId).Take(&pembayaran).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return fmt.Errorf("Pembayaran with ID %s not found", pembayaranId)
+			}
+			return err
+		}
+
+		faktur, err := lockFaktur(tx, pembayaran.IdFaktur)
+		if err != nil {
+			return err
+		}
+		// Payments of a voided faktur were turned into credit with it
+		if faktur.Status == constants.ENUM_FAKTUR_BATAL {
+			return dto.ErrFakturBatal
+		}
+
+		if err := batalKreditPembayaran(tx, faktur, pembayaran); err != nil {
+			return err
+		}
+		if err := tx.Delete(&entity.Pembayaran{}, "id = ?", pembayaranId).Error; err != nil {
+			return err
+		}
+
+		return updateTotalBayar(tx, faktur)
+	})
+}
+
+// lockSaldoKredit locks a customer and returns its credit balance, so the
+// balance cannot change until the transaction ends.
+func lockSaldoKredit(tx *gorm.DB, customerId string) (int, error) {
+	var customer entity.Customer
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", customerId).Take(&customer).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return 0, fmt.Errorf("Customer with ID %s not found", customerId)
+		}
+		return 0, err
+	}
+
+	var saldo int64
+	if err := tx.Model(&entity.KreditCustomer{}).
+		Where("id_customer = ?", customerId).
+		Select("COALESCE(SUM(jumlah), 0)").
+		Scan(&saldo).Error; err != nil {
+		return 0, err
+	}
+
+	return int(saldo), nil
+}
+
+// pakaiKredit takes customer credit off the outstanding amount of a faktur
+// with a negative credit mutation. A jumlah of 0 applies as much as both the
+// balance and the faktur allow. It returns the amount applied.
+func pakaiKredit(tx *gorm.DB, faktur entity.Faktur, jumlah int) (int, error) {
+	// Lock the customer so the same credit cannot be spent twice
+	saldo, err := lockSaldoKredit(tx, faktur.IdCustomer)
+	if err != nil {
+		return 0, err
+	}
+
+	sisa, err := sisaTagihan(tx, faktur)
+	if err != nil {
+		return 0, err
+	}
+
+	if jumlah == 0 {
+		jumlah = min(saldo, sisa)
+		if jumlah <= 0 {
+			return 0, nil
+		}
+	}
+	if jumlah > saldo {
+		return 0, fmt.Errorf("%w: balance %d", dto.ErrSaldoKreditKurang, saldo)
+	}
+	if sisa <= 0 {
+		return 0, dto.ErrFakturLunas
+	}
+	if jumlah > sisa {
+		return 0, fmt.Errorf("%w: outstanding %d", dto.ErrPembayaranMelebihi, sisa)
+	}
+
+	if err := tx.Create(&entity.KreditCustomer{
+		IdCustomer: faktur.IdCustomer,
+		IdFaktur:   faktur.ID.String(),
+		Jumlah:     -jumlah,
+		Keterangan: "pakai kredit " + faktur.NoFaktur,
+	}).Error; err != nil {
+		return 0, err
+	}
+
+	return jumlah, updateTotalBayar(tx, faktur)
+}
+
+// PakaiKredit applies the credit of the customer to one of its fakturs and
+// returns the faktur afterwards with the amount applied.
+func (r *pembayaranRepository) PakaiKredit(ctx context.Context, fakturId string, jumlah int) (entity.Faktur, int, error) {
+	var dipakai int
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		faktur, err := lockFaktur(tx, fakturId)
+		if err != nil {
+			return err
+		}
+		if faktur.Status == constants.ENUM_FAKTUR_BATAL {
+			return dto.ErrFakturBatal
+		}
+
+		if dipakai, err = pakaiKredit(tx, faktur, jumlah); err != nil {
+			return err
+		}
+		if dipakai == 0 {
+			return dto.ErrSaldoKreditKurang
+		}
+		return nil
+	})
+	if err != nil {
+		return entity.Faktur{}, 0, err
+	}
+
+	var faktur entity.Faktur
+	if err := r.db.WithContext(ctx).Where("id = ?", fakturId).Take(&faktur).Error; err != nil {
+		return entity.Faktur{}, 0, err
+	}
+
+	return faktur, dipakai, nil
+}
+
+// GetSaldoKredit returns the credit balance of a customer with its mutations,
+// newest first.
+func (r *pembayaranRepository) GetSaldoKredit(ctx context.Context, customerId string) (int, []entity.KreditCustomer, error) {
+	tx := r.db
+
+	var mutasi []entity.KreditCustomer
+	if err := tx.WithContext(ctx).Where("id_customer = ?", customerId).Order("created_at DESC").Find(&mutasi).Error; err != nil {
+		return 0, nil, err
+	}
+
+	saldo := 0
+	for _, item := range mutasi {
+		saldo += item.Jumlah
+	}
+
+	return saldo, mutasi, nil
+}
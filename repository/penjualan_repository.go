@@ -0,0 +1,324 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jejevj/ykp_pos/constants"
+	"github.com/jejevj/ykp_pos/dto"
+	"github.com/jejevj/ykp_pos/entity"
+	"github.com/jejevj/ykp_pos/helpers"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	PenjualanRepository interface {
+		HitungPenjualan(ctx context.Context, penjualan entity.Penjualan) (entity.Penjualan, error)
+		AddPenjualan(ctx context.Context, penjualan entity.Penjualan) (entity.Penjualan, error)
+		GetPenjualanById(ctx context.Context, penjualanId string) (entity.Penjualan, error)
+		GetAllPenjualanWithPagination(ctx context.Context, req dto.PenjualanFilterRequest) (dto.GetAllPenjualanRepositoryResponse, error)
+	}
+	penjualanRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewPenjualanRepository(db *gorm.DB) PenjualanRepository {
+	return &penjualanRepository{
+		db: db,
+	}
+}
+
+// preloadPenjualan loads every relation needed to render a sale with its lines.
+func preloadPenjualan(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Kasir").
+		Preload("Pembayarans").
+		Preload("Items.Barang.Satuan").
+		Preload("Items.Barang.Satuans", OrderSatuan)
+}
+
+// hargaPenjualan prices the cart of a counter sale at the current selling
+// prices with the PPN settings in force, the same way a faktur is priced.
+func hargaPenjualan(tx *gorm.DB, penjualan *entity.Penjualan) error {
+	setting, err := mainSettingPajak(tx)
+	if err != nil {
+		return err
+	}
+	penjualan.TarifPpn = setting.TarifPpn
+	penjualan.HargaTermasukPpn = setting.HargaTermasukPpn
+	nominalDulu := setting.UrutanDiskon == constants.ENUM_URUTAN_DISKON_NOMINAL_PERSEN
+
+	baris := make([]helpers.BarisHarga, len(penjualan.Items))
+	for i := range penjualan.Items {
+		item := &penjualan.Items[i]
+
+		barang, err := findBarangSatuan(tx, item.IdBarang)
+		if err != nil {
+			return err
+		}
+
+		if item.Jumlah, err = jumlahDasar(barang, item.Krat, item.Lusin, item.Satuan, item.Rincian); err != nil {
+			return err
+		}
+		if item.Jumlah <= 0 {
+			return fmt.Errorf("%w: %s", dto.ErrPenjualanJumlahInvalid, barang.NamaBarang)
+		}
+
+		item.Barang = barang
+		item.Harga = barang.HargaJual
+		baris[i] = helpers.BarisHarga{
+			Harga:  item.Harga,
+			Jumlah: item.Jumlah,
+			Diskon: helpers.Diskon{Persen: persenDiskon(item.DiskonP), Nominal: item.Diskon, NominalDulu: nominalDulu},
+		}
+	}
+
+	harga, err := helpers.HitungHarga(baris, helpers.Diskon{
+		Persen:      persenDiskon(penjualan.DiskonP),
+		Nominal:     penjualan.Diskon,
+		NominalDulu: nominalDulu,
+	})
+	if err != nil {
+		return err
+	}
+
+	penjualan.Subtotal = harga.Subtotal
+	penjualan.PotonganDiskon = harga.PotonganFaktur.Potongan()
+	penjualan.Dpp, penjualan.Ppn = 0, 0
+	for i := range penjualan.Items {
+		item := &penjualan.Items[i]
+		item.JumlahRP = harga.Baris[i].Neto
+		item.DiskonFaktur = harga.Baris[i].PotonganFaktur
+		item.Dpp, item.Ppn = helpers.HitungPpn(harga.Baris[i].Bersih, penjualan.TarifPpn, penjualan.HargaTermasukPpn)
+		penjualan.Dpp += item.Dpp
+		penjualan.Ppn += item.Ppn
+	}
+	penjualan.Total = penjualan.Dpp + penjualan.Ppn
+
+	return nil
+}
+
+// bayarPenjualan settles a priced sale over its tenders. Non-cash tenders
+// cannot go over the total, and one of them may be left without an amount to
+// take whatever the others leave. Cash has to cover the rest and only cash
+// is given back as change.
+func bayarPenjualan(penjualan *entity.Penjualan) error {
+	var tunai, nonTunai int
+	sisa := -1
+	for i, tender := range penjualan.Pembayarans {
+		switch {
+		case tender.Metode == constants.ENUM_METODE_BAYAR_TUNAI:
+			tunai += tender.Jumlah
+		case tender.Jumlah == 0:
+			if sisa >= 0 {
+				return dto.ErrPembayaranSisaGanda
+			}
+			sisa = i
+		default:
+			nonTunai += tender.Jumlah
+		}
+	}
+	if nonTunai > penjualan.Total {
+		return fmt.Errorf("%w: %d paid, %d due", dto.ErrNonTunaiMelebihi, nonTunai, penjualan.Total)
+	}
+
+	if sisa >= 0 {
+		jumlah := penjualan.Total - nonTunai - tunai
+		if jumlah < 0 {
+			jumlah = 0
+		}
+		penjualan.Pembayarans[sisa].Jumlah = jumlah
+		nonTunai += jumlah
+	}
+
+	penjualan.MetodeBayar = ""
+	for _, tender := range penjualan.Pembayarans {
+		if tender.Jumlah == 0 {
+			continue
+		}
+		if penjualan.MetodeBayar != "" && penjualan.MetodeBayar != tender.Metode {
+			penjualan.MetodeBayar = constants.ENUM_METODE_BAYAR_CAMPURAN
+			break
+		}
+		penjualan.MetodeBayar = tender.Metode
+	}
+	penjualan.Bayar = tunai + nonTunai
+	penjualan.Kembalian = 0
+	penjualan.Tunai = tunai
+
+	if penjualan.Bayar < penjualan.Total {
+		return fmt.Errorf("%w: %d paid, %d due", dto.ErrBayarKurang, penjualan.Bayar, penjualan.Total)
+	}
+	penjualan.Kembalian = penjualan.Bayar - penjualan.Total
+	penjualan.Tunai = tunai - penjualan.Kembalian
+
+	return nil
+}
+
+func (r *penjualanRepository) HitungPenjualan(ctx context.Context, penjualan entity.Penjualan) (entity.Penjualan, error) {
+	tx := r.db.WithContext(ctx)
+
+	if err := hargaPenjualan(tx, &penjualan); err != nil {
+		return entity.Penjualan{}, err
+	}
+
+	// The cart may not be paid for in full yet, the change is shown once it is
+	if err := bayarPenjualan(&penjualan); err != nil && !errors.Is(err, dto.ErrBayarKurang) {
+		return entity.Penjualan{}, err
+	}
+
+	return penjualan, nil
+}
+
+func (r *penjualanRepository) AddPenjualan(ctx context.Context, penjualan entity.Penjualan) (entity.Penjualan, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// The sale goes on the open shift of the cashier, which stays locked
+		// so the shift cannot be closed halfway
+		shift, err := shiftAktif(tx, penjualan.IdUser)
+		if err != nil {
+			return err
+		}
+		penjualan.IdShift = shift.ID.String()
+
+		now := time.Now()
+		penjualan.Tanggal = &now
+		if penjualan.NoPenjualan, err = nextNomorDokumen(tx, constants.ENUM_DOKUMEN_PENJUALAN, now); err != nil {
+			return err
+		}
+
+		if err := hargaPenjualan(tx, &penjualan); err != nil {
+			return err
+		}
+		if err := bayarPenjualan(&penjualan); err != nil {
+			return err
+		}
+
+		items, pembayarans := penjualan.Items, penjualan.Pembayarans
+		penjualan.Items, penjualan.Pembayarans = nil, nil
+		if err := tx.Omit(clause.Associations).Create(&penjualan).Error; err != nil {
+			return err
+		}
+
+		for _, tender := range pembayarans {
+			// A tender left at nothing once the rest is paid is not kept
+			if tender.Jumlah == 0 {
+				continue
+			}
+			tender.IdPenjualan = penjualan.ID.String()
+			if err := tx.Omit(clause.Associations).Create(&tender).Error; err != nil {
+				return err
+			}
+		}
+
+		// The goods leave the stock as soon as the sale is rung up
+		for _, item := range items {
+			item.IdPenjualan = penjualan.ID.String()
+			if err := tx.Omit(clause.Associations).Create(&item).Error; err != nil {
+				return err
+			}
+
+			if _, err := moveStock(tx, entity.StockMovement{
+				IdBarang: item.IdBarang,
+				Jumlah:   -item.Jumlah,
+				Alasan:   constants.ENUM_STOK_SALE,
+				RefTipe:  constants.ENUM_DOKUMEN_PENJUALAN,
+				RefId:    penjualan.ID.String(),
+				RefNo:    penjualan.NoPenjualan,
+				IdUser:   penjualan.IdUser,
+			}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return entity.Penjualan{}, err
+	}
+
+	return r.GetPenjualanById(ctx, penjualan.ID.String())
+}
+
+func (r *penjualanRepository) GetPenjualanById(ctx context.Context, penjualanId string) (entity.Penjualan, error) {
+	tx := r.db
+
+	var penjualan entity.Penjualan
+	if err := preloadPenjualan(tx.WithContext(ctx)).Where("id = ?", penjualanId).Take(&penjualan).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return entity.Penjualan{}, fmt.Errorf("Penjualan with ID %s not found", penjualanId)
+		}
+		return entity.Penjualan{}, err
+	}
+
+	return penjualan, nil
+}
+
+// filterPenjualan applies the optional list filters of a sales query.
+func filterPenjualan(req dto.PenjualanFilterRequest) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if req.IdShift != "" {
+			db = db.Where("id_shift = ?", req.IdShift)
+		}
+		if req.IdUser != "" {
+			db = db.Where("id_user = ?", req.IdUser)
+		}
+		if req.MetodeBayar != "" {
+			// A split sale is found by any of its tenders
+			db = db.Where("metode_bayar = ? OR id IN (?)", req.MetodeBayar,
+				db.Session(&gorm.Session{NewDB: true}).Model(&entity.PembayaranPenjualan{}).Select("id_penjualan").Where("metode = ?", req.MetodeBayar))
+		}
+		if req.TanggalMulai != "" {
+			db = db.Where("tanggal >= ?", req.TanggalMulai)
+		}
+		if req.TanggalAkhir != "" {
+			db = db.Where("tanggal < (?::date + 1)", req.TanggalAkhir)
+		}
+		return db.Scopes(penjualanListOptions.Search(req.Search))
+	}
+}
+
+// penjualanListOptions are the searchable and sortable columns of the sales
+// list.
+var penjualanListOptions = ListOptions{
+	Searchable: []string{"no_penjualan", "keterangan"},
+	Sortable: map[string]string{
+		"no_penjualan": "no_penjualan",
+		"tanggal":      "tanggal",
+		"total":        "total",
+	},
+	DefaultSort: "tanggal DESC, id ASC",
+}
+
+func (r *penjualanRepository) GetAllPenjualanWithPagination(ctx context.Context, req dto.PenjualanFilterRequest) (dto.GetAllPenjualanRepositoryResponse, error) {
+	tx := r.db
+
+	var penjualans []entity.Penjualan
+	var count int64
+
+	NormalizePagination(&req.PaginationRequest)
+	order, err := penjualanListOptions.Order(req.Sort, req.Order)
+	if err != nil {
+		return dto.GetAllPenjualanRepositoryResponse{}, err
+	}
+
+	if err := tx.WithContext(ctx).Model(&entity.Penjualan{}).Scopes(filterPenjualan(req)).Count(&count).Error; err != nil {
+		return dto.GetAllPenjualanRepositoryResponse{}, err
+	}
+
+	if err := preloadPenjualan(tx.WithContext(ctx)).
+		Scopes(filterPenjualan(req)).
+		Order(order).
+		Scopes(Paginate(req.Page, req.PerPage)).
+		Find(&penjualans).Error; err != nil {
+		return dto.GetAllPenjualanRepositoryResponse{}, err
+	}
+
+	return dto.GetAllPenjualanRepositoryResponse{
+		Penjualans:         penjualans,
+		PaginationResponse: NewPaginationResponse(req.PaginationRequest, count),
+	}, nil
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jejevj/ykp_pos/constants"
+	"github.com/jejevj/ykp_pos/dto"
+	"github.com/jejevj/ykp_pos/entity"
+)
+
+func TestBayarPenjualan(t *testing.T) {
+	type hasilBayar struct {
+		MetodeBayar string
+		Bayar       int
+		Kembalian   int
+		Tunai       int
+		Jumlah      []int
+	}
+
+	tender := func(metode string, jumlah int) entity.PembayaranPenjualan {
+		return entity.PembayaranPenjualan{Metode: metode, Jumlah: jumlah}
+	}
+
+	tests := []struct {
+		name    string
+		total   int
+		tenders []entity.PembayaranPenjualan
+		want    hasilBayar
+		wantErr error
+	}{
+		{
+			name:    "tunai pas",
+			total:   120000,
+			tenders: []entity.PembayaranPenjualan{tender(constants.ENUM_METODE_BAYAR_TUNAI, 120000)},
+			want:    hasilBayar{MetodeBayar: constants.ENUM_METODE_BAYAR_TUNAI, Bayar: 120000, Tunai: 120000, Jumlah: []int{120000}},
+		},
+		{
+			name:    "tunai dengan kembalian",
+			total:   120000,
+			tenders: []entity.PembayaranPenjualan{tender(constants.ENUM_METODE_BAYAR_TUNAI, 150000)},
+			want:    hasilBayar{MetodeBayar: constants.ENUM_METODE_BAYAR_TUNAI, Bayar: 150000, Kembalian: 30000, Tunai: 120000, Jumlah: []int{150000}},
+		},
+		{
+			name:    "non tunai pas",
+			total:   120000,
+			tenders: []entity.PembayaranPenjualan{tender(constants.ENUM_METODE_BAYAR_QRIS, 120000)},
+			want:    hasilBayar{MetodeBayar: constants.ENUM_METODE_BAYAR_QRIS, Bayar: 120000, Jumlah: []int{120000}},
+		},
+		{
+			name:  "kembalian hanya dari tunai",
+			total: 120000,
+			tenders: []entity.PembayaranPenjualan{
+				tender(constants.ENUM_METODE_BAYAR_QRIS, 50000),
+				tender(constants.ENUM_METODE_BAYAR_TUNAI, 100000),
+			},
+			want: hasilBayar{MetodeBayar: constants.ENUM_METODE_BAYAR_CAMPURAN, Bayar: 150000, Kembalian: 30000, Tunai: 70000, Jumlah: []int{50000, 100000}},
+		},
+		{
+			name:  "non tunai mengambil sisa",
+			total: 120000,
+			tenders: []entity.PembayaranPenjualan{
+				tender(constants.ENUM_METODE_BAYAR_TUNAI, 20000),
+				tender(constants.ENUM_METODE_BAYAR_TRANSFER, 0),
+			},
+			want: hasilBayar{MetodeBayar: constants.ENUM_METODE_BAYAR_CAMPURAN, Bayar: 120000, Tunai: 20000, Jumlah: []int{20000, 100000}},
+		},
+		{
+			name:  "sisa sudah lunas oleh tunai",
+			total: 120000,
+			tenders: []entity.PembayaranPenjualan{
+				tender(constants.ENUM_METODE_BAYAR_TUNAI, 150000),
+				tender(constants.ENUM_METODE_BAYAR_QRIS, 0),
+			},
+			want: hasilBayar{MetodeBayar: constants.ENUM_METODE_BAYAR_TUNAI, Bayar: 150000, Kembalian: 30000, Tunai: 120000, Jumlah: []int{150000, 0}},
+		},
+		{
+			name:  "dua tender mengambil sisa",
+			total: 120000,
+			tenders: []entity.PembayaranPenjualan{
+				tender(constants.ENUM_METODE_BAYAR_QRIS, 0),
+				tender(constants.ENUM_METODE_BAYAR_TRANSFER, 0),
+			},
+			wantErr: dto.ErrPembayaranSisaGanda,
+		},
+		{
+			name:    "non tunai melebihi total",
+			total:   120000,
+			tenders: []entity.PembayaranPenjualan{tender(constants.ENUM_METODE_BAYAR_TRANSFER, 130000)},
+			wantErr: dto.ErrNonTunaiMelebihi,
+		},
+		{
+			name:  "non tunai melebihi total bersama tunai",
+			total: 120000,
+			tenders: []entity.PembayaranPenjualan{
+				tender(constants.ENUM_METODE_BAYAR_QRIS, 70000),
+				tender(constants.ENUM_METODE_BAYAR_TRANSFER, 60000),
+				tender(constants.ENUM_METODE_BAYAR_TUNAI, 10000),
+			},
+			wantErr: dto.ErrNonTunaiMelebihi,
+		},
+		{
+			name:  "bayar kurang",
+			total: 120000,
+			tenders: []entity.PembayaranPenjualan{
+				tender(constants.ENUM_METODE_BAYAR_QRIS, 20000),
+				tender(constants.ENUM_METODE_BAYAR_TUNAI, 50000),
+			},
+			want:    hasilBayar{MetodeBayar: constants.ENUM_METODE_BAYAR_CAMPURAN, Bayar: 70000, Tunai: 50000, Jumlah: []int{20000, 50000}},
+			wantErr: dto.ErrBayarKurang,
+		},
+		{
+			name:    "tanpa pembayaran",
+			total:   120000,
+			want:    hasilBayar{},
+			wantErr: dto.ErrBayarKurang,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			penjualan := entity.Penjualan{Total: tt.total, Pembayarans: tt.tenders}
+
+			err := bayarPenjualan(&penjualan)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			// A short payment still shows what has been paid so far
+			if tt.wantErr != nil && !errors.Is(tt.wantErr, dto.ErrBayarKurang) {
+				return
+			}
+
+			got := hasilBayar{
+				MetodeBayar: penjualan.MetodeBayar,
+				Bayar:       penjualan.Bayar,
+				Kembalian:   penjualan.Kembalian,
+				Tunai:       penjualan.Tunai,
+			}
+			for _, p := range penjualan.Pembayarans {
+				got.Jumlah = append(got.Jumlah, p.Jumlah)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("bayarPenjualan() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,366 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jejevj/ykp_pos/constants"
+	"github.com/jejevj/ykp_pos/dto"
+	"github.com/jejevj/ykp_pos/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	PurchaseOrderRepository interface {
+		AddPurchaseOrder(ctx context.Context, po entity.PurchaseOrder) (entity.PurchaseOrder, error)
+		GetPurchaseOrderById(ctx context.Context, poId string) (entity.PurchaseOrder, error)
+		GetAllPurchaseOrderWithPagination(ctx context.Context, req dto.PurchaseOrderFilterRequest) (dto.GetAllPurchaseOrderRepositoryResponse, error)
+		TutupPurchaseOrder(ctx context.Context, poId string, alasan string) (entity.PurchaseOrder, error)
+		AddPenerimaan(ctx context.Context, penerimaan entity.PenerimaanBarang) (entity.PenerimaanBarang, error)
+		GetPenerimaanById(ctx context.Context, penerimaanId string) (entity.PenerimaanBarang, error)
+	}
+	purchaseOrderRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewPurchaseOrderRepository(db *gorm.DB) PurchaseOrderRepository {
+	return &purchaseOrderRepository{
+		db: db,
+	}
+}
+
+// preloadPurchaseOrder loads every relation needed to render a purchase order
+// with its lines and the deliveries received on it.
+func preloadPurchaseOrder(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Supplier").
+		Preload("User").
+		Preload("Items.Barang.Satuan").
+		Preload("Items.Barang.Satuans", OrderSatuan).
+		Preload("Penerimaans", func(db *gorm.DB) *gorm.DB {
+			return db.Order("tanggal ASC, created_at ASC")
+		}).
+		Preload("Penerimaans.User").
+		Preload("Penerimaans.Items.Barang.Satuan").
+		Preload("Penerimaans.Items.Barang.Satuans", OrderSatuan)
+}
+
+func preloadPenerimaan(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("PurchaseOrder").
+		Preload("User").
+		Preload("Items.Barang.Satuan").
+		Preload("Items.Barang.Satuans", OrderSatuan)
+}
+
+// lockPurchaseOrder loads a purchase order and locks it for the rest of the
+// transaction, so deliveries on the same order are booked one after the other.
+func lockPurchaseOrder(tx *gorm.DB, poId string) (entity.PurchaseOrder, error) {
+	var po entity.PurchaseOrder
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", poId).Take(&po).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return entity.PurchaseOrder{}, fmt.Errorf("PurchaseOrder with ID %s not found", poId)
+		}
+		return entity.PurchaseOrder{}, err
+	}
+
+	return po, nil
+}
+
+// statusPurchaseOrder derives the status of an order from what was received
+// on its lines.
+func statusPurchaseOrder(items []entity.TransaksiPurchaseOrder) string {
+	lengkap := true
+	diterima := false
+	for _, item := range items {
+		if item.Diterima > 0 {
+			diterima = true
+		}
+		if item.Diterima < item.Jumlah {
+			lengkap = false
+		}
+	}
+
+	switch {
+	case lengkap:
+		return constants.ENUM_PO_CLOSED
+	case diterima:
+		return constants.ENUM_PO_PARTIAL
+	default:
+		return constants.ENUM_PO_OPEN
+	}
+}
+
+// AddPurchaseOrder numbers an order and prices its lines. A line without a
+// price is ordered at the current HargaBeli of the barang.
+func (r *purchaseOrderRepository) AddPurchaseOrder(ctx context.Context, po entity.PurchaseOrder) (entity.PurchaseOrder, error) {
+	items := po.Items
+	po.Items = nil
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var supplier entity.Supplier
+		if err := tx.Where("id = ?", po.IdSupplier).Take(&supplier).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return fmt.Errorf("Supplier with ID %s not found", po.IdSupplier)
+			}
+			return err
+		}
+
+		var err error
+		if po.NoPo, err = nextNomorDokumen(tx, constants.ENUM_DOKUMEN_PO, *po.Tanggal); err != nil {
+			return err
+		}
+
+		po.Status = constants.ENUM_PO_OPEN
+		if err := tx.Omit(clause.Associations).Create(&po).Error; err != nil {
+			return err
+		}
+
+		for i := range items {
+			item := &items[i]
+
+			barang, err := findBarangSatuan(tx, item.IdBarang)
+			if err != nil {
+				return err
+			}
+
+			item.IdPurchaseOrder = po.ID.String()
+			if item.Jumlah, err = jumlahDasar(barang, item.Krat, item.Lusin, item.Satuan, item.Rincian); err != nil {
+				return err
+			}
+			if item.Jumlah <= 0 {
+				return fmt.Errorf("%w: %s", dto.ErrPurchaseOrderJumlahInvalid, barang.NamaBarang)
+			}
+			if item.HargaBeli == 0 {
+				item.HargaBeli = barang.HargaBeli
+			}
+			item.JumlahRP = item.Jumlah * item.HargaBeli
+
+			if err := tx.Omit(clause.Associations).Create(item).Error; err != nil {
+				return err
+			}
+
+			po.Total += item.JumlahRP
+		}
+
+		return tx.Model(&po).Update("total", po.Total).Error
+	})
+	if err != nil {
+		return entity.PurchaseOrder{}, err
+	}
+
+	return r.GetPurchaseOrderById(ctx, po.ID.String())
+}
+
+func (r *purchaseOrderRepository) GetPurchaseOrderById(ctx context.Context, poId string) (entity.PurchaseOrder, error) {
+	tx := r.db
+
+	var po entity.PurchaseOrder
+	if err := preloadPurchaseOrder(tx.WithContext(ctx)).Where("id = ?", poId).Take(&po).Error; err != nil {
+		return entity.PurchaseOrder{}, err
+	}
+
+	return po, nil
+}
+
+func filterPurchaseOrder(req dto.PurchaseOrderFilterRequest) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if req.IdSupplier != "" {
+			db = db.Where("id_supplier = ?", req.IdSupplier)
+		}
+		if req.Status != "" {
+			db = db.Where("status = ?", req.Status)
+		}
+		if req.TanggalMulai != "" {
+			db = db.Where("tanggal >= ?", req.TanggalMulai)
+		}
+		if req.TanggalAkhir != "" {
+			db = db.Where("tanggal < (?::date + 1)", req.TanggalAkhir)
+		}
+		return db.Scopes(purchaseOrderListOptions.Search(req.Search))
+	}
+}
+
+// purchaseOrderListOptions are the searchable and sortable columns of the
+// purchase order list.
+var purchaseOrderListOptions = ListOptions{
+	Searchable: []string{"no_po", "keterangan"},
+	Sortable: map[string]string{
+		"no_po":   "no_po",
+		"tanggal": "tanggal",
+		"total":   "total",
+		"status":  "status",
+	},
+	DefaultSort: "tanggal DESC, id ASC",
+}
+
+func (r *purchaseOrderRepository) GetAllPurchaseOrderWithPagination(ctx context.Context, req dto.PurchaseOrderFilterRequest) (dto.GetAllPurchaseOrderRepositoryResponse, error) {
+	tx := r.db
+
+	var pos []entity.PurchaseOrder
+	var count int64
+
+	NormalizePagination(&req.PaginationRequest)
+	order, err := purchaseOrderListOptions.Order(req.Sort, req.Order)
+	if err != nil {
+		return dto.GetAllPurchaseOrderRepositoryResponse{}, err
+	}
+
+	if err := tx.WithContext(ctx).Model(&entity.PurchaseOrder{}).Scopes(filterPurchaseOrder(req)).Count(&count).Error; err != nil {
+		return dto.GetAllPurchaseOrderRepositoryResponse{}, err
+	}
+
+	if err := tx.WithContext(ctx).
+		Preload("Supplier").
+		Preload("User").
+		Preload("Items.Barang.Satuan").
+		Preload("Items.Barang.Satuans", OrderSatuan).
+		Scopes(filterPurchaseOrder(req)).
+		Order(order).
+		Scopes(Paginate(req.Page, req.PerPage)).
+		Find(&pos).Error; err != nil {
+		return dto.GetAllPurchaseOrderRepositoryResponse{}, err
+	}
+
+	return dto.GetAllPurchaseOrderRepositoryResponse{
+		PurchaseOrders:     pos,
+		PaginationResponse: NewPaginationResponse(req.PaginationRequest, count),
+	}, nil
+}
+
+// TutupPurchaseOrder closes an order whose remaining goods will not come. What
+// was received stays booked.
+func (r *purchaseOrderRepository) TutupPurchaseOrder(ctx context.Context, poId string, alasan string) (entity.PurchaseOrder, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		po, err := lockPurchaseOrder(tx, poId)
+		if err != nil {
+			return err
+		}
+		if po.Status == constants.ENUM_PO_CLOSED {
+			return dto.ErrPurchaseOrderClosed
+		}
+
+		return tx.Model(&po).Updates(map[string]interface{}{
+			"status":       constants.ENUM_PO_CLOSED,
+			"alasan_tutup": alasan,
+		}).Error
+	})
+	if err != nil {
+		return entity.PurchaseOrder{}, err
+	}
+
+	return r.GetPurchaseOrderById(ctx, poId)
+}
+
+// AddPenerimaan books a delivery against an order. Every line is checked
+// against what is still open on the order line, goes into stock through the
+// kartu stok and sets the HargaBeli of the barang to the price received. The
+// order status follows from what has been received so far.
+func (r *purchaseOrderRepository) AddPenerimaan(ctx context.Context, penerimaan entity.PenerimaanBarang) (entity.PenerimaanBarang, error) {
+	items := penerimaan.Items
+	penerimaan.Items = nil
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		po, err := lockPurchaseOrder(tx, penerimaan.IdPurchaseOrder)
+		if err != nil {
+			return err
+		}
+		if po.Status == constants.ENUM_PO_CLOSED {
+			return dto.ErrPurchaseOrderClosed
+		}
+
+		if penerimaan.NoPenerimaan, err = nextNomorDokumen(tx, constants.ENUM_DOKUMEN_PENERIMAAN, *penerimaan.Tanggal); err != nil {
+			return err
+		}
+		if err := tx.Omit(clause.Associations).Create(&penerimaan).Error; err != nil {
+			return err
+		}
+
+		for i := range items {
+			item := &items[i]
+
+			var line entity.TransaksiPurchaseOrder
+			if err := tx.Where("id = ? AND id_purchase_order = ?", item.IdTransaksiPo, po.ID.String()).Take(&line).Error; err != nil {
+				if err == gorm.ErrRecordNotFound {
+					return fmt.Errorf("TransaksiPurchaseOrder with ID %s not found", item.IdTransaksiPo)
+				}
+				return err
+			}
+
+			barang, err := findBarangSatuan(tx, line.IdBarang)
+			if err != nil {
+				return err
+			}
+
+			item.IdPenerimaan = penerimaan.ID.String()
+			item.IdBarang = line.IdBarang
+			if item.Jumlah, err = jumlahDasar(barang, item.Krat, item.Lusin, item.Satuan, item.Rincian); err != nil {
+				return err
+			}
+			if item.Jumlah <= 0 {
+				return fmt.Errorf("%w: %s", dto.ErrPenerimaanJumlahInvalid, barang.NamaBarang)
+			}
+			// Earlier lines of this delivery are already counted in Diterima
+			if line.Diterima+item.Jumlah > line.Jumlah {
+				return fmt.Errorf("%w: %s (%d ordered, %d received before)", dto.ErrPenerimaanMelebihi, barang.NamaBarang, line.Jumlah, line.Diterima)
+			}
+			if item.HargaBeli == 0 {
+				item.HargaBeli = line.HargaBeli
+			}
+			item.JumlahRP = item.Jumlah * item.HargaBeli
+
+			if err := tx.Omit(clause.Associations).Create(item).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&line).Update("diterima", line.Diterima+item.Jumlah).Error; err != nil {
+				return err
+			}
+
+			if _, err := moveStock(tx, entity.StockMovement{
+				IdBarang: item.IdBarang,
+				Jumlah:   item.Jumlah,
+				Alasan:   constants.ENUM_STOK_RECEIPT,
+				RefTipe:  constants.ENUM_DOKUMEN_PENERIMAAN,
+				RefId:    penerimaan.ID.String(),
+				RefNo:    penerimaan.NoPenerimaan,
+				IdUser:   penerimaan.IdUser,
+			}); err != nil {
+				return err
+			}
+			if err := tx.Model(&entity.Barang{}).Where("id = ?", item.IdBarang).Update("harga_beli", item.HargaBeli).Error; err != nil {
+				return err
+			}
+
+			penerimaan.Total += item.JumlahRP
+		}
+
+		if err := tx.Model(&penerimaan).Update("total", penerimaan.Total).Error; err != nil {
+			return err
+		}
+
+		var lines []entity.TransaksiPurchaseOrder
+		if err := tx.Where("id_purchase_order = ?", po.ID.String()).Find(&lines).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&po).Update("status", statusPurchaseOrder(lines)).Error
+	})
+	if err != nil {
+		return entity.PenerimaanBarang{}, err
+	}
+
+	return r.GetPenerimaanById(ctx, penerimaan.ID.String())
+}
+
+func (r *purchaseOrderRepository) GetPenerimaanById(ctx context.Context, penerimaanId string) (entity.PenerimaanBarang, error) {
+	tx := r.db
+
+	var penerimaan entity.PenerimaanBarang
+	if err := preloadPenerimaan(tx.WithContext(ctx)).Where("id = ?", penerimaanId).Take(&penerimaan).Error; err != nil {
+		return entity.PenerimaanBarang{}, err
+	}
+
+	return penerimaan, nil
+}
@@ -0,0 +1,258 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jejevj/ykp_pos/constants"
+	"github.com/jejevj/ykp_pos/dto"
+	"github.com/jejevj/ykp_pos/entity"
+	"github.com/jejevj/ykp_pos/helpers"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	ReturRepository interface {
+		AddRetur(ctx context.Context, retur entity.Retur, keKredit bool) (entity.Retur, error)
+		GetReturById(ctx context.Context, returId string) (entity.Retur, error)
+		GetReturByFaktur(ctx context.Context, fakturId string) ([]entity.Retur, error)
+	}
+	returRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewReturRepository(db *gorm.DB) ReturRepository {
+	return &returRepository{
+		db: db,
+	}
+}
+
+// preloadRetur loads every relation needed to render a retur with its lines.
+func preloadRetur(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Faktur").
+		Preload("Customer").
+		Preload("User").
+		Preload("NotaKredit").
+		Preload("Items.Barang.Satuan").
+		Preload("Items.Barang.Satuans", OrderSatuan)
+}
+
+// jumlahNotaKredit adds up the credit notes taken off the balance of a faktur.
+func jumlahNotaKredit(tx *gorm.DB, fakturId string) (int, error) {
+	var jumlah int64
+	if err := tx.Model(&entity.NotaKredit{}).
+		Where("id_faktur = ?", fakturId).
+		Select("COALESCE(SUM(potong_faktur), 0)").
+		Scan(&jumlah).Error; err != nil {
+		return 0, err
+	}
+
+	return int(jumlah), nil
+}
+
+// adaRetur tells whether goods of a faktur have been returned, which freezes
+// its lines.
+func adaRetur(tx *gorm.DB, fakturId string) (bool, error) {
+	var count int64
+	if err := tx.Model(&entity.Retur{}).Where("id_faktur = ?", fakturId).Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// nilaiRetur is the part of a line amount belonging to the first jumlah of the
+// terjual pieces sold on it. Crediting the difference between two of these
+// makes several partial returns of a line add up to the line exactly.
+func nilaiRetur(amount int, jumlah int, terjual int) int {
+	if terjual <= 0 {
+		return 0
+	}
+
+	return helpers.BulatkanRupiah(float64(amount) * float64(jumlah) / float64(terjual))
+}
+
+// saveReturItems checks every returned line against the faktur line it comes
+// from, values it at the price it was sold for and puts the goods back: into
+// stock when they can be sold again, otherwise into the damaged goods.
+func saveReturItems(tx *gorm.DB, retur *entity.Retur, items []entity.TransaksiRetur) error {
+	for i := range items {
+		item := &items[i]
+
+		var line entity.TransaksiFaktur
+		if err := tx.Where("id = ? AND id_faktur = ?", item.IdTransaksiFaktur, retur.IdFaktur).Take(&line).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return fmt.Errorf("TransaksiFaktur with ID %s not found", item.IdTransaksiFaktur)
+			}
+			return err
+		}
+
+		barang, err := findBarangSatuan(tx, line.IdBarang)
+		if err != nil {
+			return err
+		}
+
+		item.IdRetur = retur.ID.String()
+		item.IdBarang = line.IdBarang
+		if item.Jumlah, err = jumlahDasar(barang, item.Krat, item.Lusin, item.Satuan, item.Rincian); err != nil {
+			return err
+		}
+		if item.Jumlah <= 0 {
+			return dto.ErrReturJumlahInvalid
+		}
+
+		// Earlier returns, including lines of this retur, already took part of the line
+		var diretur int64
+		if err := tx.Model(&entity.TransaksiRetur{}).
+			Where("id_transaksi_faktur = ?", line.ID.String()).
+			Select("COALESCE(SUM(jumlah), 0)").
+			Scan(&diretur).Error; err != nil {
+			return err
+		}
+		sudah := int(diretur)
+		if sudah+item.Jumlah > line.Jumlah {
+			return fmt.Errorf("%w: %s (%d sold, %d returned before)", dto.ErrReturMelebihi, barang.NamaBarang, line.Jumlah, sudah)
+		}
+
+		item.Dpp = nilaiRetur(line.Dpp, sudah+item.Jumlah, line.Jumlah) - nilaiRetur(line.Dpp, sudah, line.Jumlah)
+		item.Ppn = nilaiRetur(line.Ppn, sudah+item.Jumlah, line.Jumlah) - nilaiRetur(line.Ppn, sudah, line.Jumlah)
+		item.JumlahRP = item.Dpp + item.Ppn
+
+		if err := tx.Omit(clause.Associations).Create(item).Error; err != nil {
+			return err
+		}
+
+		if item.Kondisi == constants.ENUM_KONDISI_RUSAK {
+			if err := tambahStokRusak(tx, item.IdBarang, item.Jumlah); err != nil {
+				return err
+			}
+		} else {
+			if _, err := moveStock(tx, entity.StockMovement{
+				IdBarang: item.IdBarang,
+				Jumlah:   item.Jumlah,
+				Alasan:   constants.ENUM_STOK_RETURN,
+				RefTipe:  constants.ENUM_DOKUMEN_RETUR,
+				RefId:    retur.ID.String(),
+				RefNo:    retur.NoRetur,
+				IdUser:   retur.IdUser,
+			}); err != nil {
+				return err
+			}
+		}
+
+		retur.Total += item.JumlahRP
+	}
+
+	return nil
+}
+
+// AddRetur books a retur against a faktur and issues its credit note. The
+// credit note is taken off what is still open on the faktur, unless keKredit
+// is set; whatever is left becomes credit of the customer.
+func (r *returRepository) AddRetur(ctx context.Context, retur entity.Retur, keKredit bool) (entity.Retur, error) {
+	items := retur.Items
+	retur.Items = nil
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		faktur, err := lockFaktur(tx, retur.IdFaktur)
+		if err != nil {
+			return err
+		}
+		if faktur.Status == constants.ENUM_FAKTUR_BATAL {
+			return dto.ErrFakturBatal
+		}
+
+		retur.IdCustomer = faktur.IdCustomer
+		if retur.NoRetur, err = nextNomorDokumen(tx, constants.ENUM_DOKUMEN_RETUR, *retur.Tanggal); err != nil {
+			return err
+		}
+
+		if err := tx.Omit(clause.Associations).Create(&retur).Error; err != nil {
+			return err
+		}
+		if err := saveReturItems(tx, &retur, items); err != nil {
+			return err
+		}
+		if err := tx.Model(&retur).Update("total", retur.Total).Error; err != nil {
+			return err
+		}
+
+		nota := entity.NotaKredit{
+			Tanggal:    retur.Tanggal,
+			IdRetur:    retur.ID.String(),
+			IdFaktur:   faktur.ID.String(),
+			IdCustomer: faktur.IdCustomer,
+			Total:      retur.Total,
+		}
+		for _, item := range items {
+			nota.Dpp += item.Dpp
+			nota.Ppn += item.Ppn
+		}
+		if nota.NoNotaKredit, err = nextNomorDokumen(tx, constants.ENUM_DOKUMEN_NOTA_KREDIT, *retur.Tanggal); err != nil {
+			return err
+		}
+
+		if !keKredit {
+			// Payments waiting for review already claim part of the faktur
+			sisa, err := sisaTagihan(tx, faktur)
+			if err != nil {
+				return err
+			}
+
+			if sisa > 0 {
+				nota.PotongFaktur = min(sisa, nota.Total)
+			}
+		}
+		nota.Kredit = nota.Total - nota.PotongFaktur
+
+		if err := tx.Create(&nota).Error; err != nil {
+			return err
+		}
+
+		if nota.Kredit > 0 {
+			if err := tx.Create(&entity.KreditCustomer{
+				IdCustomer:   faktur.IdCustomer,
+				IdNotaKredit: nota.ID.String(),
+				Jumlah:       nota.Kredit,
+				Keterangan:   "nota kredit " + nota.NoNotaKredit,
+			}).Error; err != nil {
+				return err
+			}
+		}
+
+		return updateTotalBayar(tx, faktur)
+	})
+	if err != nil {
+		return entity.Retur{}, err
+	}
+
+	return r.GetReturById(ctx, retur.ID.String())
+}
+
+func (r *returRepository) GetReturById(ctx context.Context, returId string) (entity.Retur, error) {
+	tx := r.db
+
+	var retur entity.Retur
+	if err := preloadRetur(tx.WithContext(ctx)).Where("id = ?", returId).Take(&retur).Error; err != nil {
+		return entity.Retur{}, err
+	}
+
+	return retur, nil
+}
+
+func (r *returRepository) GetReturByFaktur(ctx context.Context, fakturId string) ([]entity.Retur, error) {
+	tx := r.db
+
+	var returs []entity.Retur
+	if err := preloadRetur(tx.WithContext(ctx)).
+		Where("id_faktur = ?", fakturId).
+		Order("tanggal ASC, created_at ASC").
+		Find(&returs).Error; err != nil {
+		return nil, err
+	}
+
+	return returs, nil
+}
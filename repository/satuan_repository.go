@@ -3,7 +3,6 @@ package repository
 import (
 	"context"
 	"fmt"
-	"math"
 
 	"github.com/jejevj/ykp_pos/dto"
 	"github.com/jejevj/ykp_pos/entity"
@@ -13,7 +12,7 @@ import (
 type (
 	SatuanRepository interface {
 		AddSatuan(ctx context.Context, satuan entity.Satuan) (entity.Satuan, error)
-		GetAllSatuanWithPagination(ctx context.Context) (dto.GetAllSatuanRepositoryResponse, error)
+		GetAllSatuanWithPagination(ctx context.Context, req dto.PaginationRequest) (dto.GetAllSatuanRepositoryResponse, error)
 		GetSatuanById(ctx context.Context, satuanId string) (entity.Satuan, error)
 		UpdateSatuan(ctx context.Context, satuan entity.Satuan) (entity.Satuan, error)
 		DeleteSatuan(ctx context.Context, satuanId string) error
@@ -38,33 +37,45 @@ func (r *satuanRepository) AddSatuan(ctx context.Context, satuan entity.Satuan)
 	return satuan, nil
 }
 
-func (r *satuanRepository) GetAllSatuanWithPagination(ctx context.Context) (dto.GetAllSatuanRepositoryResponse, error) {
+// satuanListOptions are the searchable and sortable columns of the satuan list.
+var satuanListOptions = ListOptions{
+	Searchable: []string{"nama_satuan"},
+	Sortable: map[string]string{
+		"nama_satuan": "nama_satuan",
+		"value":       "value",
+		"created_at":  "created_at",
+	},
+	DefaultSort: "nama_satuan ASC, id ASC",
+}
+
+func (r *satuanRepository) GetAllSatuanWithPagination(ctx context.Context, req dto.PaginationRequest) (dto.GetAllSatuanRepositoryResponse, error) {
 	tx := r.db
 
 	var satuans []entity.Satuan
-	var err error
 	var count int64
 
-	if err := tx.WithContext(ctx).Model(&entity.Satuan{}).Count(&count).Error; err != nil {
+	NormalizePagination(&req)
+	order, err := satuanListOptions.Order(req.Sort, req.Order)
+	if err != nil {
 		return dto.GetAllSatuanRepositoryResponse{}, err
 	}
 
-	// if err := tx.WithContext(ctx).Scopes(Paginate(1, 10)).Find(&satuans).Error; err != nil {
-	if err := tx.WithContext(ctx).Find(&satuans).Error; err != nil {
+	if err := tx.WithContext(ctx).Model(&entity.Satuan{}).Scopes(satuanListOptions.Search(req.Search)).Count(&count).Error; err != nil {
 		return dto.GetAllSatuanRepositoryResponse{}, err
 	}
 
-	totalPage := int64(math.Ceil(float64(count) / float64(10)))
+	if err := tx.WithContext(ctx).
+		Scopes(satuanListOptions.Search(req.Search)).
+		Order(order).
+		Scopes(Paginate(req.Page, req.PerPage)).
+		Find(&satuans).Error; err != nil {
+		return dto.GetAllSatuanRepositoryResponse{}, err
+	}
 
 	return dto.GetAllSatuanRepositoryResponse{
-		Satuans: satuans,
-		PaginationResponse: dto.PaginationResponse{
-			Page:    1,
-			PerPage: 10,
-			Count:   count,
-			MaxPage: totalPage,
-		},
-	}, err
+		Satuans:            satuans,
+		PaginationResponse: NewPaginationResponse(req, count),
+	}, nil
 }
 func (r *satuanRepository) GetSatuanById(ctx context.Context, satuanId string) (entity.Satuan, error) {
 	tx := r.db
@@ -0,0 +1,275 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jejevj/ykp_pos/constants"
+	"github.com/jejevj/ykp_pos/dto"
+	"github.com/jejevj/ykp_pos/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	SetoranRepository interface {
+		AddSetoran(ctx context.Context, setoran entity.Setoran) (entity.Setoran, error)
+		GetSetoranByLoading(ctx context.Context, loadingId string) (entity.Setoran, error)
+	}
+	setoranRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewSetoranRepository(db *gorm.DB) SetoranRepository {
+	return &setoranRepository{
+		db: db,
+	}
+}
+
+type jumlahPerBarang struct {
+	IdBarang string
+	Jumlah   int
+}
+
+// jumlahDimuat adds up per barang what was put on a loading.
+func jumlahDimuat(tx *gorm.DB, loadingId string) ([]jumlahPerBarang, error) {
+	var dimuat []jumlahPerBarang
+	if err := tx.Model(&entity.Transaksi{}).
+		Select("id_barang, COALESCE(SUM(jumlah), 0) AS jumlah").
+		Where("id_loading = ?", loadingId).
+		Group("id_barang").
+		Scan(&dimuat).Error; err != nil {
+		return nil, err
+	}
+
+	return dimuat, nil
+}
+
+// jumlahTerjual adds up per barang what the fakturs on a loading sold. Only
+// invoices that still stand count as sold.
+func jumlahTerjual(tx *gorm.DB, loadingId string) ([]jumlahPerBarang, error) {
+	fakturs := tx.Model(&entity.Faktur{}).
+		Select("id").
+		Where("id_loading = ? AND status <> ?", loadingId, constants.ENUM_FAKTUR_BATAL)
+
+	var terjual []jumlahPerBarang
+	if err := tx.Model(&entity.TransaksiFaktur{}).
+		Select("id_barang, COALESCE(SUM(jumlah), 0) AS jumlah").
+		Where("id_faktur IN (?)", fakturs).
+		Group("id_barang").
+		Scan(&terjual).Error; err != nil {
+		return nil, err
+	}
+
+	return terjual, nil
+}
+
+// AddSetoran settles a returned loading. The loaded and sold quantities are
+// read inside the transaction, the returned goods go back into stock and every
+// shortage is recorded against the driver before the loading is closed.
+func (r *setoranRepository) AddSetoran(ctx context.Context, setoran entity.Setoran) (entity.Setoran, error) {
+	// The lines sent in only carry the returned quantities
+	kembali := setoran.Items
+	setoran.Items = nil
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var loading entity.Loading
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", setoran.IdLoading).Take(&loading).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return fmt.Errorf("Loading with ID %s not found", setoran.IdLoading)
+			}
+			return err
+		}
+
+		if loading.Status != constants.ENUM_LOADING_RETURNED {
+			return fmt.Errorf("%w: cannot settle a loading with status %s", dto.ErrLoadingInvalidTransition, loading.Status)
+		}
+
+		dimuat, err := jumlahDimuat(tx, setoran.IdLoading)
+		if err != nil {
+			return err
+		}
+		terjual, err := jumlahTerjual(tx, setoran.IdLoading)
+		if err != nil {
+			return err
+		}
+
+		// Cash invoices are paid on delivery, so the driver brings back what is
+		// still open on them: payments booked, credit notes and customer credit
+		// applied are already in total_bayar
+		var totalTagihan int64
+		if err := tx.Model(&entity.Faktur{}).
+			Select("COALESCE(SUM(total - total_bayar), 0)").
+			Where("id_loading = ? AND status <> ? AND cara_bayar = ?", setoran.IdLoading, constants.ENUM_FAKTUR_BATAL, constants.ENUM_CARA_BAYAR_TUNAI).
+			Scan(&totalTagihan).Error; err != nil {
+			return err
+		}
+
+		setoran.IdUser = loading.IdUser
+		setoran.Tanggal = time.Now()
+		setoran.TotalTagihan = int(totalTagihan)
+		setoran.SelisihUang = setoran.TotalTunai + setoran.TotalTransfer - setoran.TotalTagihan
+
+		if err := tx.Omit(clause.Associations).Create(&setoran).Error; err != nil {
+			return err
+		}
+
+		// Returned quantities may be counted per unit
+		for i, row := range kembali {
+			if len(row.Rincian) == 0 {
+				continue
+			}
+
+			barang, err := findBarangSatuan(tx, row.IdBarang)
+			if err != nil {
+				return err
+			}
+			if kembali[i].JumlahKembali, err = jumlahDasar(barang, 0, 0, row.JumlahKembali, row.Rincian); err != nil {
+				return err
+			}
+		}
+
+		details := compareSetoran(dimuat, terjual, kembali)
+		if err := checkKembali(details); err != nil {
+			return err
+		}
+		for _, detail := range details {
+			detail.IdSetoran = setoran.ID.String()
+			if err := tx.Omit(clause.Associations).Create(&detail).Error; err != nil {
+				return err
+			}
+
+			if detail.JumlahKembali > 0 {
+				if _, err := moveStock(tx, entity.StockMovement{
+					IdBarang: detail.IdBarang,
+					Jumlah:   detail.JumlahKembali,
+					Alasan:   constants.ENUM_STOK_RETURN,
+					RefTipe:  constants.ENUM_DOKUMEN_SETORAN,
+					RefId:    setoran.ID.String(),
+					RefNo:    loading.NoLoading,
+					IdUser:   setoran.IdPetugas,
+				}); err != nil {
+					return err
+				}
+			}
+
+			if detail.Selisih <= 0 {
+				continue
+			}
+
+			var barang entity.Barang
+			if err := tx.Where("id = ?", detail.IdBarang).Take(&barang).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Create(&entity.KekuranganDriver{
+				IdSetoran:  setoran.ID.String(),
+				IdUser:     setoran.IdUser,
+				Jenis:      constants.ENUM_KEKURANGAN_BARANG,
+				IdBarang:   detail.IdBarang,
+				Jumlah:     detail.Selisih,
+				Nilai:      detail.Selisih * barang.HargaJual,
+				Keterangan: fmt.Sprintf("kurang %d pcs %s", detail.Selisih, barang.NamaBarang),
+			}).Error; err != nil {
+				return err
+			}
+		}
+
+		if setoran.SelisihUang < 0 {
+			if err := tx.Create(&entity.KekuranganDriver{
+				IdSetoran:  setoran.ID.String(),
+				IdUser:     setoran.IdUser,
+				Jenis:      constants.ENUM_KEKURANGAN_UANG,
+				Nilai:      -setoran.SelisihUang,
+				Keterangan: "kurang setor",
+			}).Error; err != nil {
+				return err
+			}
+		}
+
+		return setLoadingStatus(tx, loading, constants.ENUM_LOADING_SETTLED, setoran.IdPetugas)
+	})
+	if err != nil {
+		return entity.Setoran{}, err
+	}
+
+	return r.GetSetoranByLoading(ctx, setoran.IdLoading)
+}
+
+// checkKembali refuses a settlement whose fakturs sold more than was loaded,
+// and returned goods that never went out on the loading or that exceed what is
+// left of it after the sales, since they would be booked into stock out of
+// nothing.
+func checkKembali(details []entity.SetoranDetail) error {
+	for _, detail := range details {
+		if detail.JumlahTerjual > detail.JumlahMuat {
+			return fmt.Errorf("%w: barang %s (%d loaded, %d sold)", dto.ErrSetoranTerjualMelebihi, detail.IdBarang, detail.JumlahMuat, detail.JumlahTerjual)
+		}
+		if detail.JumlahKembali == 0 {
+			continue
+		}
+		if detail.JumlahMuat == 0 {
+			return fmt.Errorf("%w: barang %s", dto.ErrSetoranBukanMuatan, detail.IdBarang)
+		}
+		if sisa := detail.JumlahMuat - detail.JumlahTerjual; detail.JumlahKembali > sisa {
+			return fmt.Errorf("%w: barang %s (%d loaded, %d sold, %d returned)", dto.ErrSetoranKembaliMelebihi, detail.IdBarang, detail.JumlahMuat, detail.JumlahTerjual, detail.JumlahKembali)
+		}
+	}
+
+	return nil
+}
+
+// compareSetoran lines up loaded, sold and returned quantities per barang, in
+// the order the barang first appear.
+func compareSetoran(dimuat []jumlahPerBarang, terjual []jumlahPerBarang, kembali []entity.SetoranDetail) []entity.SetoranDetail {
+	var order []string
+	details := map[string]*entity.SetoranDetail{}
+	detail := func(idBarang string) *entity.SetoranDetail {
+		if _, ok := details[idBarang]; !ok {
+			order = append(order, idBarang)
+			details[idBarang] = &entity.SetoranDetail{IdBarang: idBarang}
+		}
+		return details[idBarang]
+	}
+
+	for _, row := range dimuat {
+		detail(row.IdBarang).JumlahMuat += row.Jumlah
+	}
+	for _, row := range terjual {
+		detail(row.IdBarang).JumlahTerjual += row.Jumlah
+	}
+	for _, row := range kembali {
+		if row.JumlahKembali != 0 {
+			detail(row.IdBarang).JumlahKembali += row.JumlahKembali
+		}
+	}
+
+	var result []entity.SetoranDetail
+	for _, idBarang := range order {
+		d := details[idBarang]
+		d.Selisih = d.JumlahMuat - d.JumlahTerjual - d.JumlahKembali
+		result = append(result, *d)
+	}
+
+	return result
+}
+
+func (r *setoranRepository) GetSetoranByLoading(ctx context.Context, loadingId string) (entity.Setoran, error) {
+	tx := r.db
+
+	var setoran entity.Setoran
+	if err := tx.WithContext(ctx).
+		Preload("Loading").
+		Preload("Driver").
+		Preload("Items.Barang.Satuan").
+		Preload("Items.Barang.Satuans", OrderSatuan).
+		Preload("Kekurangan").
+		Where("id_loading = ?", loadingId).
+		Take(&setoran).Error; err != nil {
+		return entity.Setoran{}, err
+	}
+
+	return setoran, nil
+}
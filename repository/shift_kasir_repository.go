@@ -0,0 +1,327 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jejevj/ykp_pos/constants"
+	"github.com/jejevj/ykp_pos/dto"
+	"github.com/jejevj/ykp_pos/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	ShiftKasirRepository interface {
+		BukaShift(ctx context.Context, shift entity.ShiftKasir) (entity.ShiftKasir, error)
+		GetShiftAktif(ctx context.Context, userId string) (entity.ShiftKasir, error)
+		AddKasShift(ctx context.Context, kas entity.KasShift) (entity.ShiftKasir, error)
+		TutupShift(ctx context.Context, userId string, kasDihitung int, keterangan string) (entity.ShiftKasir, error)
+		GetShiftById(ctx context.Context, shiftId string) (entity.ShiftKasir, error)
+		GetAllShiftWithPagination(ctx context.Context, req dto.ShiftFilterRequest) (dto.GetAllShiftKasirRepositoryResponse, error)
+		GetShiftTutup(ctx context.Context, req dto.RekapShiftRequest) ([]entity.ShiftKasir, error)
+	}
+	shiftKasirRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewShiftKasirRepository(db *gorm.DB) ShiftKasirRepository {
+	return &shiftKasirRepository{
+		db: db,
+	}
+}
+
+// preloadShift loads the cashier and the cash movements of a shift.
+func preloadShift(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Kasir").
+		Preload("Kas", func(db *gorm.DB) *gorm.DB {
+			return db.Order("waktu ASC")
+		})
+}
+
+// shiftAktif finds the open shift of a cashier and locks it, so sales and
+// cash movements cannot slip in while the shift is being closed.
+func shiftAktif(tx *gorm.DB, userId string) (entity.ShiftKasir, error) {
+	var shift entity.ShiftKasir
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id_user = ? AND status = ?", userId, constants.ENUM_SHIFT_BUKA).
+		Take(&shift).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entity.ShiftKasir{}, dto.ErrShiftTidakAktif
+	}
+	if err != nil {
+		return entity.ShiftKasir{}, err
+	}
+
+	return shift, nil
+}
+
+// hitungShift adds up the sales and cash movements of a shift. The drawer
+// should hold the opening float, the cash kept from the sales and the cash put
+// in, less the cash taken out.
+func hitungShift(tx *gorm.DB, shift *entity.ShiftKasir) error {
+	var penjualan struct {
+		Jumlah int64
+		Total  int64
+		Tunai  int64
+	}
+	if err := tx.Model(&entity.Penjualan{}).
+		Where("id_shift = ?", shift.ID.String()).
+		Select("COUNT(*) AS jumlah, COALESCE(SUM(total), 0) AS total, COALESCE(SUM(tunai), 0) AS tunai").
+		Scan(&penjualan).Error; err != nil {
+		return err
+	}
+
+	var kas struct {
+		Masuk  int64
+		Keluar int64
+	}
+	if err := tx.Model(&entity.KasShift{}).
+		Where("id_shift = ?", shift.ID.String()).
+		Select("COALESCE(SUM(CASE WHEN jenis = ? THEN jumlah ELSE 0 END), 0) AS masuk, COALESCE(SUM(CASE WHEN jenis = ? THEN jumlah ELSE 0 END), 0) AS keluar", constants.ENUM_KAS_MASUK, constants.ENUM_KAS_KELUAR).
+		Scan(&kas).Error; err != nil {
+		return err
+	}
+
+	shift.JumlahPenjualan = int(penjualan.Jumlah)
+	shift.TotalPenjualan = int(penjualan.Total)
+	shift.TotalTunai = int(penjualan.Tunai)
+	shift.KasMasuk = int(kas.Masuk)
+	shift.KasKeluar = int(kas.Keluar)
+	shift.KasDiharapkan = shift.ModalAwal + shift.TotalTunai + shift.KasMasuk - shift.KasKeluar
+
+	return nil
+}
+
+func (r *shiftKasirRepository) BukaShift(ctx context.Context, shift entity.ShiftKasir) (entity.ShiftKasir, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock the cashier so two shifts cannot be opened at the same time
+		var kasir entity.User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", shift.IdUser).Take(&kasir).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return fmt.Errorf("User with ID %s not found", shift.IdUser)
+			}
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&entity.ShiftKasir{}).
+			Where("id_user = ? AND status = ?", shift.IdUser, constants.ENUM_SHIFT_BUKA).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return dto.ErrShiftMasihBuka
+		}
+
+		shift.Status = constants.ENUM_SHIFT_BUKA
+		shift.WaktuBuka = time.Now()
+
+		return tx.Omit(clause.Associations).Create(&shift).Error
+	})
+	if err != nil {
+		return entity.ShiftKasir{}, err
+	}
+
+	return r.GetShiftById(ctx, shift.ID.String())
+}
+
+func (r *shiftKasirRepository) GetShiftAktif(ctx context.Context, userId string) (entity.ShiftKasir, error) {
+	tx := r.db
+
+	var shift entity.ShiftKasir
+	err := preloadShift(tx.WithContext(ctx)).
+		Where("id_user = ? AND status = ?", userId, constants.ENUM_SHIFT_BUKA).
+		Take(&shift).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entity.ShiftKasir{}, dto.ErrShiftTidakAktif
+	}
+	if err != nil {
+		return entity.ShiftKasir{}, err
+	}
+
+	if err := hitungShift(tx.WithContext(ctx), &shift); err != nil {
+		return entity.ShiftKasir{}, err
+	}
+
+	return shift, nil
+}
+
+func (r *shiftKasirRepository) AddKasShift(ctx context.Context, kas entity.KasShift) (entity.ShiftKasir, error) {
+	var shiftId string
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		shift, err := shiftAktif(tx, kas.IdUser)
+		if err != nil {
+			return err
+		}
+		shiftId = shift.ID.String()
+
+		if kas.Jenis == constants.ENUM_KAS_KELUAR {
+			if err := hitungShift(tx, &shift); err != nil {
+				return err
+			}
+			if kas.Jumlah > shift.KasDiharapkan {
+				return fmt.Errorf("%w: %d in the drawer, %d requested", dto.ErrKasTidakCukup, shift.KasDiharapkan, kas.Jumlah)
+			}
+		}
+
+		kas.IdShift = shiftId
+		kas.Waktu = time.Now()
+
+		return tx.Omit(clause.Associations).Create(&kas).Error
+	})
+	if err != nil {
+		return entity.ShiftKasir{}, err
+	}
+
+	return r.GetShiftById(ctx, shiftId)
+}
+
+func (r *shiftKasirRepository) TutupShift(ctx context.Context, userId string, kasDihitung int, keterangan string) (entity.ShiftKasir, error) {
+	var shiftId string
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		shift, err := shiftAktif(tx, userId)
+		if err != nil {
+			return err
+		}
+		shiftId = shift.ID.String()
+
+		if err := hitungShift(tx, &shift); err != nil {
+			return err
+		}
+
+		// The totals are frozen with the shift, the variance is counted
+		// against what the drawer should hold
+		now := time.Now()
+		return tx.Model(&shift).Updates(map[string]interface{}{
+			"status":           constants.ENUM_SHIFT_TUTUP,
+			"waktu_tutup":      now,
+			"jumlah_penjualan": shift.JumlahPenjualan,
+			"total_penjualan":  shift.TotalPenjualan,
+			"total_tunai":      shift.TotalTunai,
+			"kas_masuk":        shift.KasMasuk,
+			"kas_keluar":       shift.KasKeluar,
+			"kas_diharapkan":   shift.KasDiharapkan,
+			"kas_dihitung":     kasDihitung,
+			"selisih":          kasDihitung - shift.KasDiharapkan,
+			"keterangan":       keterangan,
+		}).Error
+	})
+	if err != nil {
+		return entity.ShiftKasir{}, err
+	}
+
+	return r.GetShiftById(ctx, shiftId)
+}
+
+func (r *shiftKasirRepository) GetShiftById(ctx context.Context, shiftId string) (entity.ShiftKasir, error) {
+	tx := r.db
+
+	var shift entity.ShiftKasir
+	if err := preloadShift(tx.WithContext(ctx)).Where("id = ?", shiftId).Take(&shift).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return entity.ShiftKasir{}, fmt.Errorf("Shift with ID %s not found", shiftId)
+		}
+		return entity.ShiftKasir{}, err
+	}
+
+	// An open shift shows its running totals
+	if shift.Status == constants.ENUM_SHIFT_BUKA {
+		if err := hitungShift(tx.WithContext(ctx), &shift); err != nil {
+			return entity.ShiftKasir{}, err
+		}
+	}
+
+	return shift, nil
+}
+
+// filterShift applies the optional list filters of a shift query.
+func filterShift(idUser string, status string, tanggalMulai string, tanggalAkhir string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if idUser != "" {
+			db = db.Where("id_user = ?", idUser)
+		}
+		if status != "" {
+			db = db.Where("status = ?", status)
+		}
+		if tanggalMulai != "" {
+			db = db.Where("waktu_buka >= ?", tanggalMulai)
+		}
+		if tanggalAkhir != "" {
+			db = db.Where("waktu_buka < (?::date + 1)", tanggalAkhir)
+		}
+		return db
+	}
+}
+
+// shiftListOptions are the searchable and sortable columns of the shift list.
+var shiftListOptions = ListOptions{
+	Searchable: []string{"keterangan"},
+	Sortable: map[string]string{
+		"waktu_buka":      "waktu_buka",
+		"waktu_tutup":     "waktu_tutup",
+		"total_penjualan": "total_penjualan",
+		"selisih":         "selisih",
+	},
+	DefaultSort: "waktu_buka DESC, id ASC",
+}
+
+func (r *shiftKasirRepository) GetAllShiftWithPagination(ctx context.Context, req dto.ShiftFilterRequest) (dto.GetAllShiftKasirRepositoryResponse, error) {
+	tx := r.db
+
+	var shifts []entity.ShiftKasir
+	var count int64
+
+	NormalizePagination(&req.PaginationRequest)
+	order, err := shiftListOptions.Order(req.Sort, req.Order)
+	if err != nil {
+		return dto.GetAllShiftKasirRepositoryResponse{}, err
+	}
+
+	filter := filterShift(req.IdUser, req.Status, req.TanggalMulai, req.TanggalAkhir)
+	if err := tx.WithContext(ctx).Model(&entity.ShiftKasir{}).
+		Scopes(filter, shiftListOptions.Search(req.Search)).
+		Count(&count).Error; err != nil {
+		return dto.GetAllShiftKasirRepositoryResponse{}, err
+	}
+
+	if err := preloadShift(tx.WithContext(ctx)).
+		Scopes(filter, shiftListOptions.Search(req.Search)).
+		Order(order).
+		Scopes(Paginate(req.Page, req.PerPage)).
+		Find(&shifts).Error; err != nil {
+		return dto.GetAllShiftKasirRepositoryResponse{}, err
+	}
+
+	for i := range shifts {
+		if shifts[i].Status == constants.ENUM_SHIFT_BUKA {
+			if err := hitungShift(tx.WithContext(ctx), &shifts[i]); err != nil {
+				return dto.GetAllShiftKasirRepositoryResponse{}, err
+			}
+		}
+	}
+
+	return dto.GetAllShiftKasirRepositoryResponse{
+		Shifts:             shifts,
+		PaginationResponse: NewPaginationResponse(req.PaginationRequest, count),
+	}, nil
+}
+
+func (r *shiftKasirRepository) GetShiftTutup(ctx context.Context, req dto.RekapShiftRequest) ([]entity.ShiftKasir, error) {
+	tx := r.db
+
+	var shifts []entity.ShiftKasir
+	if err := tx.WithContext(ctx).
+		Preload("Kasir").
+		Scopes(filterShift(req.IdUser, constants.ENUM_SHIFT_TUTUP, req.TanggalMulai, req.TanggalAkhir)).
+		Order("waktu_buka ASC, id ASC").
+		Find(&shifts).Error; err != nil {
+		return nil, err
+	}
+
+	return shifts, nil
+}
@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jejevj/ykp_pos/constants"
+	"github.com/jejevj/ykp_pos/dto"
+	"github.com/jejevj/ykp_pos/entity"
+	"github.com/jejevj/ykp_pos/helpers"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// findBarangSatuan loads a barang together with its units.
+func findBarangSatuan(tx *gorm.DB, barangId string) (entity.Barang, error) {
+	var barang entity.Barang
+	if err := tx.Preload("Satuan").Preload("Satuans", OrderSatuan).Where("id = ?", barangId).Take(&barang).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return entity.Barang{}, fmt.Errorf("Barang with ID %s not found", barangId)
+		}
+		return entity.Barang{}, err
+	}
+
+	return barang, nil
+}
+
+// isiKrat is the number of pieces in the largest unit of a barang, which is
+// what the krat fields of the older requests refer to.
+func isiKrat(barang entity.Barang) int {
+	if len(barang.Satuans) == 0 {
+		return barang.Satuan.Value
+	}
+
+	return barang.SatuanKonversi()[0].Isi
+}
+
+// jumlahDasar converts a quantity entered as krat, lusin and pieces and/or as
+// a list of units into pieces.
+func jumlahDasar(barang entity.Barang, krat int, lusin int, satuan int, rincian []helpers.JumlahSatuan) (int, error) {
+	satuans := barang.SatuanKonversi()
+
+	total, err := helpers.KeSatuanDasar(satuans, rincian)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %v", barang.NamaBarang, err)
+	}
+
+	isiLusin := constants.ENUM_ISI_LUSIN
+	if lusinBarang, ok := helpers.CariSatuan(satuans, "lusin"); ok {
+		isiLusin = lusinBarang.Isi
+	}
+
+	return total + krat*isiKrat(barang) + lusin*isiLusin + satuan, nil
+}
+
+// moveStock applies movement.Jumlah to the stock of a barang and writes the
+// kartu stok line with the resulting balance. It must run inside the
+// transaction of the document causing the change; the barang row is locked so
+// concurrent movements are applied one after the other.
+func moveStock(tx *gorm.DB, movement entity.StockMovement) (entity.Barang, error) {
+	var barang entity.Barang
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", movement.IdBarang).
+		Take(&barang).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return entity.Barang{}, fmt.Errorf("Barang with ID %s not found", movement.IdBarang)
+		}
+		return entity.Barang{}, err
+	}
+
+	if err := tx.Where("id = ?", barang.IdSatuan).Take(&barang.Satuan).Error; err != nil && err != gorm.ErrRecordNotFound {
+		return entity.Barang{}, err
+	}
+	if err := tx.Scopes(OrderSatuan).Where("id_barang = ?", barang.ID.String()).Find(&barang.Satuans).Error; err != nil {
+		return entity.Barang{}, err
+	}
+
+	stok := barang.Stok + movement.Jumlah
+	if stok < 0 {
+		return entity.Barang{}, fmt.Errorf("%w: %s (%d available, %d requested)", dto.ErrStokTidakCukup, barang.NamaBarang, barang.Stok, -movement.Jumlah)
+	}
+
+	// Keep the krat/satuan breakdown in line with the new balance
+	barang.Stok = stok
+	if isi := isiKrat(barang); isi > 0 {
+		barang.JumlahKrat = stok / isi
+		barang.JumlahSatuan = stok % isi
+	} else {
+		barang.JumlahKrat = 0
+		barang.JumlahSatuan = stok
+	}
+
+	if err := tx.Model(&entity.Barang{}).Where("id = ?", barang.ID).Updates(map[string]interface{}{
+		"stok":          barang.Stok,
+		"jumlah_krat":   barang.JumlahKrat,
+		"jumlah_satuan": barang.JumlahSatuan,
+	}).Error; err != nil {
+		return entity.Barang{}, err
+	}
+
+	if movement.Tanggal.IsZero() {
+		movement.Tanggal = time.Now()
+	}
+	movement.Saldo = barang.Stok
+
+	if err := tx.Omit(clause.Associations).Create(&movement).Error; err != nil {
+		return entity.Barang{}, err
+	}
+
+	return barang, nil
+}
+
+// tambahStokRusak adds damaged goods to the write-off bucket of a barang. They
+// are not sellable stock, so the kartu stok is not touched.
+func tambahStokRusak(tx *gorm.DB, barangId string, jumlah int) error {
+	result := tx.Model(&entity.Barang{}).Where("id = ?", barangId).Update("stok_rusak", gorm.Expr("stok_rusak + ?", jumlah))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("Barang with ID %s not found", barangId)
+	}
+
+	return nil
+}
+
+// reverseStockMovements books the opposite of every movement recorded for a
+// document, e.g. when the document is changed, cancelled or deleted.
+func reverseStockMovements(tx *gorm.DB, refTipe string, refId string, idUser string, keterangan string) error {
+	var movements []entity.StockMovement
+	if err := tx.Where("ref_tipe = ? AND ref_id = ?", refTipe, refId).
+		Order("tanggal ASC, created_at ASC").
+		Find(&movements).Error; err != nil {
+		return err
+	}
+
+	// Net the movements per barang and reason so a document changed several
+	// times is reversed exactly once
+	type key struct {
+		idBarang string
+		alasan   string
+	}
+	var order []key
+	totals := map[key]int{}
+	refNo := map[key]string{}
+	for _, movement := range movements {
+		k := key{movement.IdBarang, movement.Alasan}
+		if _, ok := totals[k]; !ok {
+			order = append(order, k)
+		}
+		totals[k] += movement.Jumlah
+		refNo[k] = movement.RefNo
+	}
+
+	for _, k := range order {
+		if totals[k] == 0 {
+			continue
+		}
+
+		if _, err := moveStock(tx, entity.StockMovement{
+			IdBarang:   k.idBarang,
+			Jumlah:     -totals[k],
+			Alasan:     k.alasan,
+			RefTipe:    refTipe,
+			RefId:      refId,
+			RefNo:      refNo[k],
+			IdUser:     idUser,
+			Keterangan: keterangan,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jejevj/ykp_pos/constants"
+	"github.com/jejevj/ykp_pos/dto"
+	"github.com/jejevj/ykp_pos/entity"
+	"gorm.io/gorm"
+)
+
+type (
+	SupplierRepository interface {
+		AddSupplier(ctx context.Context, supplier entity.Supplier) (entity.Supplier, error)
+		GetAllSupplierWithPagination(ctx context.Context, req dto.PaginationRequest) (dto.GetAllSupplierRepositoryResponse, error)
+		GetSupplierById(ctx context.Context, supplierId string) (entity.Supplier, error)
+		UpdateSupplier(ctx context.Context, supplier entity.Supplier) (entity.Supplier, error)
+		DeleteSupplier(ctx context.Context, supplierId string) error
+	}
+	supplierRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewSupplierRepository(db *gorm.DB) SupplierRepository {
+	return &supplierRepository{
+		db: db,
+	}
+}
+
+func (r *supplierRepository) AddSupplier(ctx context.Context, supplier entity.Supplier) (entity.Supplier, error) {
+	tx := r.db
+
+	if err := tx.WithContext(ctx).Create(&supplier).Error; err != nil {
+		return entity.Supplier{}, err
+	}
+	return supplier, nil
+}
+
+// supplierListOptions are the searchable and sortable columns of the supplier list.
+var supplierListOptions = ListOptions{
+	Searchable: []string{"nama_supplier", "nama_kontak", "alamat", "hp"},
+	Sortable: map[string]string{
+		"nama_supplier": "nama_supplier",
+		"nama_kontak":   "nama_kontak",
+		"created_at":    "created_at",
+	},
+	DefaultSort: "nama_supplier ASC, id ASC",
+}
+
+func (r *supplierRepository) GetAllSupplierWithPagination(ctx context.Context, req dto.PaginationRequest) (dto.GetAllSupplierRepositoryResponse, error) {
+	tx := r.db
+
+	var suppliers []entity.Supplier
+	var count int64
+
+	NormalizePagination(&req)
+	order, err := supplierListOptions.Order(req.Sort, req.Order)
+	if err != nil {
+		return dto.GetAllSupplierRepositoryResponse{}, err
+	}
+
+	if err := tx.WithContext(ctx).Model(&entity.Supplier{}).Scopes(supplierListOptions.Search(req.Search)).Count(&count).Error; err != nil {
+		return dto.GetAllSupplierRepositoryResponse{}, err
+	}
+
+	if err := tx.WithContext(ctx).
+		Scopes(supplierListOptions.Search(req.Search)).
+		Order(order).
+		Scopes(Paginate(req.Page, req.PerPage)).
+		Find(&suppliers).Error; err != nil {
+		return dto.GetAllSupplierRepositoryResponse{}, err
+	}
+
+	return dto.GetAllSupplierRepositoryResponse{
+		Suppliers:          suppliers,
+		PaginationResponse: NewPaginationResponse(req, count),
+	}, nil
+}
+
+func (r *supplierRepository) GetSupplierById(ctx context.Context, supplierId string) (entity.Supplier, error) {
+	tx := r.db
+
+	var supplier entity.Supplier
+	if err := tx.WithContext(ctx).Where("id = ?", supplierId).Take(&supplier).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return entity.Supplier{}, fmt.Errorf("Supplier with ID %s not found", supplierId)
+		}
+		return entity.Supplier{}, err
+	}
+
+	return supplier, nil
+}
+
+func (r *supplierRepository) UpdateSupplier(ctx context.Context, supplier entity.Supplier) (entity.Supplier, error) {
+	tx := r.db
+
+	var existingSupplier entity.Supplier
+	if err := tx.WithContext(ctx).Where("id = ?", supplier.ID).Take(&existingSupplier).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return entity.Supplier{}, fmt.Errorf("Supplier with ID %s not found", supplier.ID)
+		}
+		return entity.Supplier{}, err
+	}
+
+	if err := tx.WithContext(ctx).Model(&existingSupplier).Updates(supplier).Error; err != nil {
+		return entity.Supplier{}, err
+	}
+
+	return existingSupplier, nil
+}
+
+// DeleteSupplier removes a supplier that no longer has goods on order.
+func (r *supplierRepository) DeleteSupplier(ctx context.Context, supplierId string) error {
+	tx := r.db
+
+	var count int64
+	if err := tx.WithContext(ctx).Model(&entity.PurchaseOrder{}).
+		Where("id_supplier = ? AND status <> ?", supplierId, constants.ENUM_PO_CLOSED).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return dto.ErrSupplierAdaPo
+	}
+
+	if err := tx.WithContext(ctx).Delete(&entity.Supplier{}, "id = ?", supplierId).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jejevj/ykp_pos/constants"
+	"github.com/jejevj/ykp_pos/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	TransaksiGatewayRepository interface {
+		CatatTransaksiGateway(ctx context.Context, transaksi entity.TransaksiGateway) (entity.TransaksiGateway, error)
+		GetTransaksiGatewayById(ctx context.Context, transaksiId string) (entity.TransaksiGateway, error)
+		GetTransaksiGatewayByFaktur(ctx context.Context, fakturId string) ([]entity.TransaksiGateway, error)
+	}
+	transaksiGatewayRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewTransaksiGatewayRepository(db *gorm.DB) TransaksiGatewayRepository {
+	return &transaksiGatewayRepository{
+		db: db,
+	}
+}
+
+// bukuPembayaranGateway books a settled gateway transaction as an accepted
+// payment on its faktur. The money has already been received, so whatever
+// the faktur no longer needs, all of it on a voided faktur, becomes credit of
+// the customer.
+func bukuPembayaranGateway(tx *gorm.DB, faktur entity.Faktur, transaksi entity.TransaksiGateway) (entity.Pembayaran, error) {
+	sisa := 0
+	if faktur.Status != constants.ENUM_FAKTUR_BATAL {
+		var err error
+		if sisa, err = sisaTagihan(tx, faktur); err != nil {
+			return entity.Pembayaran{}, err
+		}
+		if sisa < 0 {
+			sisa = 0
+		}
+	}
+
+	jumlah := transaksi.Jumlah
+	if jumlah > sisa {
+		jumlah = sisa
+	}
+
+	now := time.Now()
+	tanggalBayar := transaksi.WaktuBayar
+	if tanggalBayar == nil {
+		tanggalBayar = &now
+	}
+
+	pembayaran := entity.Pembayaran{
+		IdFaktur:      faktur.ID.String(),
+		Jumlah:        jumlah,
+		Kelebihan:     transaksi.Jumlah - jumlah,
+		Metode:        transaksi.Metode,
+		TanggalBayar:  tanggalBayar,
+		Referensi:     transaksi.IdTransaksi,
+		Keterangan:    transaksi.Gateway + " " + transaksi.OrderId,
+		StatusBukti:   constants.ENUM_BUKTI_DITERIMA,
+		TanggalReview: &now,
+	}
+	if err := tx.Omit(clause.Associations).Create(&pembayaran).Error; err != nil {
+		return entity.Pembayaran{}, err
+	}
+
+	if pembayaran.Kelebihan > 0 {
+		if err := tx.Create(&entity.KreditCustomer{
+			IdCustomer:   faktur.IdCustomer,
+			IdPembayaran: pembayaran.ID.String(),
+			Jumlah:       pembayaran.Kelebihan,
+			Keterangan:   "kelebihan bayar " + faktur.NoFaktur,
+		}).Error; err != nil {
+			return entity.Pembayaran{}, err
+		}
+	}
+
+	// A voided faktur keeps its status, the money only went to credit
+	if faktur.Status == constants.ENUM_FAKTUR_BATAL {
+		return pembayaran, nil
+	}
+
+	return pembayaran, updateTotalBayar(tx, faktur)
+}
+
+// CatatTransaksiGateway records a gateway notification. Notifications about
+// the same order update one transaction, and the payment is booked only the
+// first time it is reported settled; a settled transaction is not changed by
+// later notifications.
+func (r *transaksiGatewayRepository) CatatTransaksiGateway(ctx context.Context, transaksi entity.TransaksiGateway) (entity.TransaksiGateway, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Notifications of one faktur are handled one after the other
+		faktur, err := lockFaktur(tx, transaksi.IdFaktur)
+		if err != nil {
+			return err
+		}
+
+		var tercatat entity.TransaksiGateway
+		err = tx.Where("gateway = ? AND order_id = ?", transaksi.Gateway, transaksi.OrderId).Take(&tercatat).Error
+		switch {
+		case err == gorm.ErrRecordNotFound:
+			if err := tx.Omit(clause.Associations).Create(&transaksi).Error; err != nil {
+				return err
+			}
+		case err != nil:
+			return err
+		case tercatat.Status == constants.ENUM_GATEWAY_BERHASIL:
+			transaksi = tercatat
+			return nil
+		default:
+			transaksi.ID = tercatat.ID
+			if err := tx.Model(&tercatat).Updates(map[string]interface{}{
+				"id_transaksi":   transaksi.IdTransaksi,
+				"status":         transaksi.Status,
+				"status_gateway": transaksi.StatusGateway,
+				"metode":         transaksi.Metode,
+				"jumlah":         transaksi.Jumlah,
+				"waktu_bayar":    transaksi.WaktuBayar,
+				"payload":        transaksi.Payload,
+			}).Error; err != nil {
+				return err
+			}
+		}
+
+		if transaksi.Status != constants.ENUM_GATEWAY_BERHASIL {
+			return nil
+		}
+
+		pembayaran, err := bukuPembayaranGateway(tx, faktur, transaksi)
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(&entity.TransaksiGateway{}).Where("id = ?", transaksi.ID).Update("id_pembayaran", pembayaran.ID.String()).Error
+	})
+	if err != nil {
+		return entity.TransaksiGateway{}, err
+	}
+
+	return r.GetTransaksiGatewayById(ctx, transaksi.ID.String())
+}
+
+func (r *transaksiGatewayRepository) GetTransaksiGatewayById(ctx context.Context, transaksiId string) (entity.TransaksiGateway, error) {
+	tx := r.db
+
+	var transaksi entity.TransaksiGateway
+	if err := tx.WithContext(ctx).Preload("Faktur").Where("id = ?", transaksiId).Take(&transaksi).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return entity.TransaksiGateway{}, fmt.Errorf("TransaksiGateway with ID %s not found", transaksiId)
+		}
+		return entity.TransaksiGateway{}, err
+	}
+
+	return transaksi, nil
+}
+
+func (r *transaksiGatewayRepository) GetTransaksiGatewayByFaktur(ctx context.Context, fakturId string) ([]entity.TransaksiGateway, error) {
+	tx := r.db
+
+	var transaksis []entity.TransaksiGateway
+	if err := tx.WithContext(ctx).
+		Preload("Faktur").
+		Where("id_faktur = ?", fakturId).
+		Order("created_at ASC").
+		Find(&transaksis).Error; err != nil {
+		return nil, err
+	}
+
+	return transaksis, nil
+}
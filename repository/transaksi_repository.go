@@ -3,21 +3,22 @@ package repository
 import (
 	"context"
 	"fmt"
-	"math"
 
 	"github.com/google/uuid"
+	"github.com/jejevj/ykp_pos/constants"
 	"github.com/jejevj/ykp_pos/dto"
 	"github.com/jejevj/ykp_pos/entity"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type (
 	TransaksiRepository interface {
-		AddTransaksi(ctx context.Context, transaksi entity.Transaksi) (entity.Transaksi, error)
-		GetAllTransaksiWithPagination(ctx context.Context) (dto.GetAllTransaksiRepositoryResponse, error)
+		AddTransaksi(ctx context.Context, transaksi entity.Transaksi, userId string) (entity.Transaksi, error)
+		GetAllTransaksiWithPagination(ctx context.Context, req dto.TransaksiFilterRequest) (dto.GetAllTransaksiRepositoryResponse, error)
 		GetTransaksiById(ctx context.Context, transaksiId string) (entity.Transaksi, error)
-		UpdateTransaksi(ctx context.Context, transaksi entity.Transaksi) (entity.Transaksi, error)
-		DeleteTransaksi(ctx context.Context, transaksiId string) error
+		UpdateTransaksi(ctx context.Context, transaksi entity.Transaksi, userId string) (entity.Transaksi, error)
+		DeleteTransaksi(ctx context.Context, transaksiId string, userId string) error
 	}
 	transaksiRepository struct {
 		db *gorm.DB
@@ -30,17 +31,87 @@ func NewTransaksiRepository(db *gorm.DB) TransaksiRepository {
 	}
 }
 
-func (r *transaksiRepository) AddTransaksi(ctx context.Context, transaksi entity.Transaksi) (entity.Transaksi, error) {
+// jumlahTransaksi adds the quantity entered per unit to the pieces of a
+// loading line.
+func jumlahTransaksi(tx *gorm.DB, transaksi entity.Transaksi) (int, error) {
+	if len(transaksi.Rincian) == 0 {
+		return transaksi.Jumlah, nil
+	}
+
+	barang, err := findBarangSatuan(tx, transaksi.IdBarang)
+	if err != nil {
+		return 0, err
+	}
+
+	return jumlahDasar(barang, 0, 0, transaksi.Jumlah, transaksi.Rincian)
+}
+
+// lockLoadingLines locks the loading a line belongs to and refuses changes
+// once the goods have left with the truck.
+func lockLoadingLines(tx *gorm.DB, loadingId string) (entity.Loading, error) {
+	var loading entity.Loading
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", loadingId).Take(&loading).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return entity.Loading{}, fmt.Errorf("Loading with ID %s not found", loadingId)
+		}
+		return entity.Loading{}, err
+	}
+
+	switch loading.Status {
+	case constants.ENUM_LOADING_DRAFT, constants.ENUM_LOADING_SUBMITTED, constants.ENUM_LOADING_APPROVED:
+		return loading, nil
+	default:
+		return entity.Loading{}, dto.ErrLoadingLocked
+	}
+}
+
+// moveLoadingLineStock keeps the warehouse stock in line when a line of an
+// approved loading changes; before approval nothing has been taken yet.
+func moveLoadingLineStock(tx *gorm.DB, loading entity.Loading, idBarang string, jumlah int, userId string) error {
+	if loading.Status != constants.ENUM_LOADING_APPROVED || jumlah == 0 {
+		return nil
+	}
+
+	_, err := moveStock(tx, entity.StockMovement{
+		IdBarang: idBarang,
+		Jumlah:   jumlah,
+		Alasan:   constants.ENUM_STOK_LOADING,
+		RefTipe:  constants.ENUM_DOKUMEN_LOADING,
+		RefId:    loading.ID.String(),
+		RefNo:    loading.NoLoading,
+		IdUser:   userId,
+	})
+	return err
+}
+
+func (r *transaksiRepository) AddTransaksi(ctx context.Context, transaksi entity.Transaksi, userId string) (entity.Transaksi, error) {
 	tx := r.db
 
-	// Create the transaksi
-	if err := tx.WithContext(ctx).Create(&transaksi).Error; err != nil {
+	err := tx.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		loading, err := lockLoadingLines(tx, transaksi.IdLoading)
+		if err != nil {
+			return err
+		}
+
+		if transaksi.Jumlah, err = jumlahTransaksi(tx, transaksi); err != nil {
+			return err
+		}
+
+		// Create the transaksi
+		if err := tx.Omit(clause.Associations).Create(&transaksi).Error; err != nil {
+			return err
+		}
+
+		return moveLoadingLineStock(tx, loading, transaksi.IdBarang, -transaksi.Jumlah, userId)
+	})
+	if err != nil {
 		return entity.Transaksi{}, err
 	}
 
 	// Preload related data (Loading.User and Barang.Satuan)
 	if err := tx.WithContext(ctx).
 		Preload("Barang.Satuan").
+		Preload("Barang.Satuans", OrderSatuan).
 		Preload("Loading.User").
 		Where("id = ?", transaksi.ID).
 		Take(&transaksi).Error; err != nil {
@@ -50,39 +121,66 @@ func (r *transaksiRepository) AddTransaksi(ctx context.Context, transaksi entity
 	return transaksi, nil
 }
 
-func (r *transaksiRepository) GetAllTransaksiWithPagination(ctx context.Context) (dto.GetAllTransaksiRepositoryResponse, error) {
+// filterTransaksi applies the optional list filters of a transaksi query. The
+// search matches the name or code of the barang and the number of the loading.
+func filterTransaksi(req dto.TransaksiFilterRequest) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if req.IdLoading != "" {
+			db = db.Where("id_loading = ?", req.IdLoading)
+		}
+		if req.IdUser != "" {
+			db = db.Where("id_loading IN (SELECT id FROM loadings WHERE id_user = ?)", req.IdUser)
+		}
+		if req.Search == "" {
+			return db
+		}
+
+		search := "%" + req.Search + "%"
+		return db.Where("(id_barang IN (SELECT id FROM barangs WHERE nama_barang ILIKE ? OR kode_barang ILIKE ?) OR id_loading IN (SELECT id FROM loadings WHERE no_loading ILIKE ?))", search, search, search)
+	}
+}
+
+// transaksiListOptions whitelists the columns the transaksi list can be sorted
+// by; searching goes through filterTransaksi.
+var transaksiListOptions = ListOptions{
+	Sortable: map[string]string{
+		"jumlah":     "jumlah",
+		"created_at": "created_at",
+	},
+	DefaultSort: "created_at DESC, id ASC",
+}
+
+func (r *transaksiRepository) GetAllTransaksiWithPagination(ctx context.Context, req dto.TransaksiFilterRequest) (dto.GetAllTransaksiRepositoryResponse, error) {
 	tx := r.db
 
 	var transaksis []entity.Transaksi
-	var err error
 	var count int64
 
-	// Count the total number of transaksis
-	if err := tx.WithContext(ctx).Model(&entity.Transaksi{}).Count(&count).Error; err != nil {
+	NormalizePagination(&req.PaginationRequest)
+	order, err := transaksiListOptions.Order(req.Sort, req.Order)
+	if err != nil {
+		return dto.GetAllTransaksiRepositoryResponse{}, err
+	}
+
+	if err := tx.WithContext(ctx).Model(&entity.Transaksi{}).Scopes(filterTransaksi(req)).Count(&count).Error; err != nil {
 		return dto.GetAllTransaksiRepositoryResponse{}, err
 	}
 
-	// Fetch the transaksis with preloaded relationships (Loading.User and Barang.Satuan)
-	// Fetch transaksis with preloaded relationships (Barang and Satuan)
 	if err := tx.WithContext(ctx).
 		Preload("Loading.User").
 		Preload("Barang.Satuan").
-		Scopes(Paginate(1, 10)).
+		Preload("Barang.Satuans", OrderSatuan).
+		Scopes(filterTransaksi(req)).
+		Order(order).
+		Scopes(Paginate(req.Page, req.PerPage)).
 		Find(&transaksis).Error; err != nil {
 		return dto.GetAllTransaksiRepositoryResponse{}, err
 	}
 
-	totalPage := int64(math.Ceil(float64(count) / float64(10)))
-
 	return dto.GetAllTransaksiRepositoryResponse{
-		Transaksis: transaksis,
-		PaginationResponse: dto.PaginationResponse{
-			Page:    1,
-			PerPage: 10,
-			Count:   count,
-			MaxPage: totalPage,
-		},
-	}, err
+		Transaksis:         transaksis,
+		PaginationResponse: NewPaginationResponse(req.PaginationRequest, count),
+	}, nil
 }
 
 func (r *transaksiRepository) GetTransaksiById(ctx context.Context, transaksiId string) (entity.Transaksi, error) {
@@ -96,39 +194,85 @@ func (r *transaksiRepository) GetTransaksiById(ctx context.Context, transaksiId
 
 	// Fetch the transaksi with preloaded relationships
 	var transaksi entity.Transaksi
-	if err := tx.WithContext(ctx).Preload("Loading.User").Preload("Barang.Satuan").Where("id = ?", id).First(&transaksi).Error; err != nil {
+	if err := tx.WithContext(ctx).Preload("Loading.User").Preload("Barang.Satuan").Preload("Barang.Satuans", OrderSatuan).Where("id = ?", id).First(&transaksi).Error; err != nil {
 		return entity.Transaksi{}, err
 	}
 
 	return transaksi, nil
 }
-func (r *transaksiRepository) UpdateTransaksi(ctx context.Context, transaksi entity.Transaksi) (entity.Transaksi, error) {
-	tx := r.db
+func (r *transaksiRepository) UpdateTransaksi(ctx context.Context, transaksi entity.Transaksi, userId string) (entity.Transaksi, error) {
+	var updatedTransaksi entity.Transaksi
 
-	// First, check if the record exists
-	var existingTransaksi entity.Transaksi
-	if err := tx.WithContext(ctx).Where("id = ?", transaksi.ID).Take(&existingTransaksi).Error; err != nil {
-		// If the record doesn't exist, return a specific error
-		if err == gorm.ErrRecordNotFound {
-			return entity.Transaksi{}, fmt.Errorf("Transaksi with ID %s not found", transaksi.ID)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// First, check if the record exists
+		var existingTransaksi entity.Transaksi
+		if err := tx.Where("id = ?", transaksi.ID).Take(&existingTransaksi).Error; err != nil {
+			// If the record doesn't exist, return a specific error
+			if err == gorm.ErrRecordNotFound {
+				return fmt.Errorf("Transaksi with ID %s not found", transaksi.ID)
+			}
+			return err
+		}
+
+		// Both the current and the target loading must still be open
+		oldLoading, err := lockLoadingLines(tx, existingTransaksi.IdLoading)
+		if err != nil {
+			return err
+		}
+		newLoading := oldLoading
+		if transaksi.IdLoading != "" && transaksi.IdLoading != existingTransaksi.IdLoading {
+			if newLoading, err = lockLoadingLines(tx, transaksi.IdLoading); err != nil {
+				return err
+			}
+		}
+
+		if len(transaksi.Rincian) > 0 {
+			if transaksi.IdBarang == "" {
+				transaksi.IdBarang = existingTransaksi.IdBarang
+			}
+			if transaksi.Jumlah, err = jumlahTransaksi(tx, transaksi); err != nil {
+				return err
+			}
+		}
+
+		// Put the old quantity back before taking the new one
+		if err := moveLoadingLineStock(tx, oldLoading, existingTransaksi.IdBarang, existingTransaksi.Jumlah, userId); err != nil {
+			return err
+		}
+
+		// Proceed with updating the record
+		if err := tx.Model(&existingTransaksi).Omit(clause.Associations).Updates(transaksi).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("id = ?", transaksi.ID).Take(&updatedTransaksi).Error; err != nil {
+			return err
 		}
-		return entity.Transaksi{}, err
-	}
 
-	// Proceed with updating the record
-	if err := tx.WithContext(ctx).Model(&existingTransaksi).Updates(transaksi).Error; err != nil {
+		return moveLoadingLineStock(tx, newLoading, updatedTransaksi.IdBarang, -updatedTransaksi.Jumlah, userId)
+	})
+	if err != nil {
 		return entity.Transaksi{}, err
 	}
 
 	// Return the updated entity
-	return existingTransaksi, nil
+	return updatedTransaksi, nil
 }
-func (r *transaksiRepository) DeleteTransaksi(ctx context.Context, transaksiId string) error {
-	tx := r.db
 
-	if err := tx.WithContext(ctx).Delete(&entity.Transaksi{}, "id = ?", transaksiId).Error; err != nil {
-		return err
-	}
+func (r *transaksiRepository) DeleteTransaksi(ctx context.Context, transaksiId string, userId string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var transaksi entity.Transaksi
+		if err := tx.Where("id = ?", transaksiId).Take(&transaksi).Error; err != nil {
+			return err
+		}
+
+		loading, err := lockLoadingLines(tx, transaksi.IdLoading)
+		if err != nil {
+			return err
+		}
+		if err := moveLoadingLineStock(tx, loading, transaksi.IdBarang, transaksi.Jumlah, userId); err != nil {
+			return err
+		}
 
-	return nil
+		return tx.Delete(&entity.Transaksi{}, "id = ?", transaksiId).Error
+	})
 }
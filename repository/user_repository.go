@@ -2,7 +2,6 @@ package repository
 
 import (
 	"context"
-	"math"
 
 	"github.com/jejevj/ykp_pos/dto"
 	"github.com/jejevj/ykp_pos/entity"
@@ -12,7 +11,7 @@ import (
 type (
 	UserRepository interface {
 		RegisterUser(ctx context.Context, user entity.User) (entity.User, error)
-		GetAllUserWithPagination(ctx context.Context) (dto.GetAllUserRepositoryResponse, error)
+		GetAllUserWithPagination(ctx context.Context, req dto.PaginationRequest) (dto.GetAllUserRepositoryResponse, error)
 		GetUserById(ctx context.Context, userId string) (entity.User, error)
 		GetUserByEmail(ctx context.Context, email string) (entity.User, error)
 		CheckEmail(ctx context.Context, email string) (entity.User, bool, error)
@@ -41,32 +40,46 @@ func (r *userRepository) RegisterUser(ctx context.Context, user entity.User) (en
 	return user, nil
 }
 
-func (r *userRepository) GetAllUserWithPagination(ctx context.Context) (dto.GetAllUserRepositoryResponse, error) {
+// userListOptions are the searchable and sortable columns of the user list.
+var userListOptions = ListOptions{
+	Searchable: []string{"name", "email", "telp_number"},
+	Sortable: map[string]string{
+		"name":       "name",
+		"email":      "email",
+		"role":       "role",
+		"created_at": "created_at",
+	},
+	DefaultSort: "name ASC, id ASC",
+}
+
+func (r *userRepository) GetAllUserWithPagination(ctx context.Context, req dto.PaginationRequest) (dto.GetAllUserRepositoryResponse, error) {
 	tx := r.db
 
 	var users []entity.User
-	var err error
 	var count int64
 
-	if err := tx.WithContext(ctx).Model(&entity.User{}).Count(&count).Error; err != nil {
+	NormalizePagination(&req)
+	order, err := userListOptions.Order(req.Sort, req.Order)
+	if err != nil {
 		return dto.GetAllUserRepositoryResponse{}, err
 	}
 
-	if err := tx.WithContext(ctx).Scopes(Paginate(1, 10)).Find(&users).Error; err != nil {
+	if err := tx.WithContext(ctx).Model(&entity.User{}).Scopes(userListOptions.Search(req.Search)).Count(&count).Error; err != nil {
 		return dto.GetAllUserRepositoryResponse{}, err
 	}
 
-	totalPage := int64(math.Ceil(float64(count) / float64(10)))
+	if err := tx.WithContext(ctx).
+		Scopes(userListOptions.Search(req.Search)).
+		Order(order).
+		Scopes(Paginate(req.Page, req.PerPage)).
+		Find(&users).Error; err != nil {
+		return dto.GetAllUserRepositoryResponse{}, err
+	}
 
 	return dto.GetAllUserRepositoryResponse{
-		Users: users,
-		PaginationResponse: dto.PaginationResponse{
-			Page:    1,
-			PerPage: 10,
-			Count:   count,
-			MaxPage: totalPage,
-		},
-	}, err
+		Users:              users,
+		PaginationResponse: NewPaginationResponse(req, count),
+	}, nil
 }
 
 func (r *userRepository) GetUserById(ctx context.Context, userId string) (entity.User, error) {
@@ -2,6 +2,7 @@ package routes
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"github.com/jejevj/ykp_pos/constants"
 	"github.com/jejevj/ykp_pos/controller"
 	"github.com/jejevj/ykp_pos/middleware"
 	"github.com/jejevj/ykp_pos/service"
@@ -10,10 +11,13 @@ import (
 func Barang(route fiber.Router, barangController controller.BarangController, jwtService service.JWTService) {
 	routes := route.Group("/barang")
 
-	routes.Post("", barangController.AddBarang)
-	routes.Get("", barangController.GetAllBarangWithPagination)
-	routes.Delete("", middleware.Authenticate(jwtService), barangController.DeleteBarang)
-	routes.Put("", middleware.Authenticate(jwtService), barangController.UpdateBarang)
-	routes.Put("/stok", middleware.Authenticate(jwtService), barangController.UpdateStokBarang)
+	routes.Post("", middleware.Authenticate(jwtService), middleware.Authorize(constants.ENUM_ROLE_ADMIN), barangController.AddBarang)
+	routes.Get("", middleware.Authenticate(jwtService), barangController.GetAllBarangWithPagination)
+	routes.Delete("", middleware.Authenticate(jwtService), middleware.Authorize(constants.ENUM_ROLE_ADMIN), barangController.DeleteBarang)
+	routes.Put("", middleware.Authenticate(jwtService), middleware.Authorize(constants.ENUM_ROLE_ADMIN), barangController.UpdateBarang)
+	routes.Put("/stok", middleware.Authenticate(jwtService), middleware.Authorize(constants.ENUM_ROLE_ADMIN), barangController.UpdateStokBarang)
+	routes.Put("/opname", middleware.Authenticate(jwtService), middleware.Authorize(constants.ENUM_ROLE_ADMIN), barangController.OpnameBarang)
 	routes.Get("/by-id", middleware.Authenticate(jwtService), barangController.GetBarangById)
+	routes.Get("/by-kode", middleware.Authenticate(jwtService), barangController.GetBarangByKode)
+	routes.Get("/kartu-stok", middleware.Authenticate(jwtService), barangController.GetKartuStok)
 }
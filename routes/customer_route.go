@@ -2,6 +2,7 @@ package routes
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"github.com/jejevj/ykp_pos/constants"
 	"github.com/jejevj/ykp_pos/controller"
 	"github.com/jejevj/ykp_pos/middleware"
 	"github.com/jejevj/ykp_pos/service"
@@ -10,9 +11,12 @@ import (
 func Customer(route fiber.Router, customerController controller.CustomerController, jwtService service.JWTService) {
 	routes := route.Group("/customer")
 
-	routes.Post("", customerController.AddCustomer)
-	routes.Get("", customerController.GetAllCustomerWithPagination)
-	routes.Delete("", middleware.Authenticate(jwtService), customerController.DeleteCustomer)
-	routes.Put("", middleware.Authenticate(jwtService), customerController.UpdateCustomer)
+	routes.Post("", middleware.Authenticate(jwtService), customerController.AddCustomer)
+	routes.Get("", middleware.Authenticate(jwtService), customerController.GetAllCustomerWithPagination)
+	routes.Delete("", middleware.Authenticate(jwtService), middleware.Authorize(constants.ENUM_ROLE_ADMIN), customerController.DeleteCustomer)
+	routes.Put("", middleware.Authenticate(jwtService), middleware.Authorize(constants.ENUM_ROLE_ADMIN), customerController.UpdateCustomer)
 	routes.Get("/by-id", middleware.Authenticate(jwtService), customerController.GetCustomerById)
+	routes.Put("/kredit", middleware.Authenticate(jwtService), middleware.Authorize(constants.ENUM_ROLE_ADMIN), customerController.UpdateKreditCustomer)
+	routes.Post("/override-kredit", middleware.Authenticate(jwtService), middleware.Authorize(constants.ENUM_ROLE_ADMIN), customerController.AddOverrideKredit)
+	routes.Get("/override-kredit", middleware.Authenticate(jwtService), middleware.Authorize(constants.ENUM_ROLE_ADMIN), customerController.GetOverrideKredit)
 }
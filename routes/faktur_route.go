@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/jejevj/ykp_pos/constants"
+	"github.com/jejevj/ykp_pos/controller"
+	"github.com/jejevj/ykp_pos/middleware"
+	"github.com/jejevj/ykp_pos/service"
+)
+
+func Faktur(route fiber.Router, fakturController controller.FakturController, jwtService service.JWTService) {
+	routes := route.Group("/faktur")
+
+	routes.Post("", middleware.Authenticate(jwtService), fakturController.AddFaktur)
+	routes.Get("", middleware.Authenticate(jwtService), fakturController.GetAllFakturWithPagination)
+	routes.Put("", middleware.Authenticate(jwtService), fakturController.UpdateFaktur)
+	routes.Put("/cancel", middleware.Authenticate(jwtService), middleware.Authorize(constants.ENUM_ROLE_ADMIN), fakturController.CancelFaktur)
+	routes.Get("/by-id", middleware.Authenticate(jwtService), fakturController.GetFakturById)
+	routes.Get("/:id/pdf", middleware.Authenticate(jwtService), fakturController.CetakFaktur)
+	routes.Get("/:id/struk", middleware.Authenticate(jwtService), fakturController.CetakStrukFaktur)
+}
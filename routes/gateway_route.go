@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/jejevj/ykp_pos/constants"
+	"github.com/jejevj/ykp_pos/controller"
+	"github.com/jejevj/ykp_pos/middleware"
+	"github.com/jejevj/ykp_pos/service"
+)
+
+func Gateway(route fiber.Router, gatewayController controller.GatewayController, jwtService service.JWTService) {
+	routes := route.Group("/gateway")
+
+	// Called by the payment gateway, which signs the body instead of logging in
+	routes.Post("/notifikasi", gatewayController.TerimaNotifikasi)
+	routes.Get("", middleware.Authenticate(jwtService), middleware.Authorize(constants.ENUM_ROLE_ADMIN), gatewayController.GetTransaksiGatewayByFaktur)
+}
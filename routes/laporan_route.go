@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/jejevj/ykp_pos/constants"
+	"github.com/jejevj/ykp_pos/controller"
+	"github.com/jejevj/ykp_pos/middleware"
+	"github.com/jejevj/ykp_pos/service"
+)
+
+func Laporan(route fiber.Router, laporanController controller.LaporanController, jwtService service.JWTService) {
+	routes := route.Group("/laporan")
+
+	routes.Get("/umur-piutang", middleware.Authenticate(jwtService), middleware.Authorize(constants.ENUM_ROLE_ADMIN), laporanController.GetUmurPiutang)
+	routes.Get("/ppn", middleware.Authenticate(jwtService), middleware.Authorize(constants.ENUM_ROLE_ADMIN), laporanController.GetRekapPpn)
+}
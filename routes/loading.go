@@ -2,6 +2,7 @@ package routes
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"github.com/jejevj/ykp_pos/constants"
 	"github.com/jejevj/ykp_pos/controller"
 	"github.com/jejevj/ykp_pos/middleware"
 	"github.com/jejevj/ykp_pos/service"
@@ -10,9 +11,14 @@ import (
 func Loading(route fiber.Router, loadingController controller.LoadingController, jwtService service.JWTService) {
 	routes := route.Group("/loading")
 
-	routes.Post("", loadingController.AddLoading)
-	routes.Get("", loadingController.GetAllLoadingWithPagination)
+	routes.Post("", middleware.Authenticate(jwtService), loadingController.AddLoading)
+	routes.Get("", middleware.Authenticate(jwtService), loadingController.GetAllLoadingWithPagination)
 	routes.Delete("", middleware.Authenticate(jwtService), loadingController.DeleteLoading)
 	routes.Put("", middleware.Authenticate(jwtService), loadingController.UpdateLoading)
+	routes.Put("/submit", middleware.Authenticate(jwtService), loadingController.SubmitLoading)
+	routes.Put("/approve", middleware.Authenticate(jwtService), middleware.Authorize(constants.ENUM_ROLE_ADMIN), loadingController.ApproveLoading)
+	routes.Put("/unapprove", middleware.Authenticate(jwtService), middleware.Authorize(constants.ENUM_ROLE_ADMIN), loadingController.UnapproveLoading)
+	routes.Put("/dispatch", middleware.Authenticate(jwtService), loadingController.DispatchLoading)
+	routes.Put("/return", middleware.Authenticate(jwtService), loadingController.ReturnLoading)
 	routes.Get("/by-id", middleware.Authenticate(jwtService), loadingController.GetLoadingById)
 }
@@ -2,6 +2,7 @@ package routes
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"github.com/jejevj/ykp_pos/constants"
 	"github.com/jejevj/ykp_pos/controller"
 	"github.com/jejevj/ykp_pos/middleware"
 	"github.com/jejevj/ykp_pos/service"
@@ -10,9 +11,12 @@ import (
 func MainSetting(route fiber.Router, mainSettingController controller.MainSettingController, jwtService service.JWTService) {
 	routes := route.Group("/main-setting")
 
-	routes.Post("", mainSettingController.AddMainSetting)
-	routes.Get("", mainSettingController.GetAllMainSettingWithPagination)
-	routes.Delete("", middleware.Authenticate(jwtService), mainSettingController.DeleteMainSetting)
-	routes.Put("", middleware.Authenticate(jwtService), mainSettingController.UpdateMainSetting)
+	routes.Post("", middleware.Authenticate(jwtService), middleware.Authorize(constants.ENUM_ROLE_ADMIN), mainSettingController.AddMainSetting)
+	routes.Get("", middleware.Authenticate(jwtService), mainSettingController.GetAllMainSettingWithPagination)
+	routes.Delete("", middleware.Authenticate(jwtService), middleware.Authorize(constants.ENUM_ROLE_ADMIN), mainSettingController.DeleteMainSetting)
+	routes.Put("", middleware.Authenticate(jwtService), middleware.Authorize(constants.ENUM_ROLE_ADMIN), mainSettingController.UpdateMainSetting)
 	routes.Get("/by-id", middleware.Authenticate(jwtService), mainSettingController.GetMainSettingById)
+	routes.Put("/pajak", middleware.Authenticate(jwtService), middleware.Authorize(constants.ENUM_ROLE_ADMIN), mainSettingController.UpdatePajakMainSetting)
+	routes.Put("/qris", middleware.Authenticate(jwtService), middleware.Authorize(constants.ENUM_ROLE_ADMIN), mainSettingController.UpdateQrisMainSetting)
+	routes.Get("/qris", middleware.Authenticate(jwtService), mainSettingController.BuatQris)
 }
@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/jejevj/ykp_pos/controller"
+	"github.com/jejevj/ykp_pos/middleware"
+	"github.com/jejevj/ykp_pos/service"
+)
+
+func NomorDokumen(route fiber.Router, nomorDokumenController controller.NomorDokumenController, jwtService service.JWTService) {
+	routes := route.Group("/nomor-dokumen")
+
+	routes.Get("/preview", middleware.Authenticate(jwtService), nomorDokumenController.PreviewNomor)
+}
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/jejevj/ykp_pos/constants"
+	"github.com/jejevj/ykp_pos/controller"
+	"github.com/jejevj/ykp_pos/middleware"
+	"github.com/jejevj/ykp_pos/service"
+)
+
+func Pembayaran(route fiber.Router, pembayaranController controller.PembayaranController, jwtService service.JWTService) {
+	routes := route.Group("/pembayaran")
+
+	routes.Post("", middleware.Authenticate(jwtService), pembayaranController.AddPembayaran)
+	routes.Get("", middleware.Authenticate(jwtService), pembayaranController.GetPembayaranByFaktur)
+	routes.Delete("", middleware.Authenticate(jwtService), middleware.Authorize(constants.ENUM_ROLE_ADMIN), pembayaranController.DeletePembayaran)
+	routes.Get("/pending", middleware.Authenticate(jwtService), middleware.Authorize(constants.ENUM_ROLE_ADMIN), pembayaranController.GetPembayaranPending)
+	routes.Put("/terima", middleware.Authenticate(jwtService), middleware.Authorize(constants.ENUM_ROLE_ADMIN), pembayaranController.TerimaPembayaran)
+	routes.Put("/tolak", middleware.Authenticate(jwtService), middleware.Authorize(constants.ENUM_ROLE_ADMIN), pembayaranController.TolakPembayaran)
+	routes.Get("/kredit", middleware.Authenticate(jwtService), pembayaranController.GetSaldoKredit)
+	routes.Post("/kredit", middleware.Authenticate(jwtService), middleware.Authorize(constants.ENUM_ROLE_ADMIN), pembayaranController.PakaiKredit)
+}
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/jejevj/ykp_pos/controller"
+	"github.com/jejevj/ykp_pos/middleware"
+	"github.com/jejevj/ykp_pos/service"
+)
+
+func Penjualan(route fiber.Router, penjualanController controller.PenjualanController, jwtService service.JWTService) {
+	routes := route.Group("/penjualan")
+
+	routes.Post("", middleware.Authenticate(jwtService), penjualanController.AddPenjualan)
+	routes.Get("", middleware.Authenticate(jwtService), penjualanController.GetAllPenjualanWithPagination)
+	routes.Post("/hitung", middleware.Authenticate(jwtService), penjualanController.HitungPenjualan)
+	routes.Get("/by-id", middleware.Authenticate(jwtService), penjualanController.GetPenjualanById)
+	routes.Get("/:id/struk", middleware.Authenticate(jwtService), penjualanController.CetakStrukPenjualan)
+}
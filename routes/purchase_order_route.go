@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/jejevj/ykp_pos/constants"
+	"github.com/jejevj/ykp_pos/controller"
+	"github.com/jejevj/ykp_pos/middleware"
+	"github.com/jejevj/ykp_pos/service"
+)
+
+func PurchaseOrder(route fiber.Router, purchaseOrderController controller.PurchaseOrderController, jwtService service.JWTService) {
+	routes := route.Group("/purchase-order")
+
+	routes.Post("", middleware.Authenticate(jwtService), middleware.Authorize(constants.ENUM_ROLE_ADMIN), purchaseOrderController.AddPurchaseOrder)
+	routes.Get("", middleware.Authenticate(jwtService), purchaseOrderController.GetAllPurchaseOrderWithPagination)
+	routes.Get("/by-id", middleware.Authenticate(jwtService), purchaseOrderController.GetPurchaseOrderById)
+	routes.Put("/tutup", middleware.Authenticate(jwtService), middleware.Authorize(constants.ENUM_ROLE_ADMIN), purchaseOrderController.TutupPurchaseOrder)
+	routes.Post("/penerimaan", middleware.Authenticate(jwtService), middleware.Authorize(constants.ENUM_ROLE_ADMIN), purchaseOrderController.AddPenerimaan)
+	routes.Get("/penerimaan/by-id", middleware.Authenticate(jwtService), purchaseOrderController.GetPenerimaanById)
+}
@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/jejevj/ykp_pos/controller"
+	"github.com/jejevj/ykp_pos/middleware"
+	"github.com/jejevj/ykp_pos/service"
+)
+
+func Retur(route fiber.Router, returController controller.ReturController, jwtService service.JWTService) {
+	routes := route.Group("/retur")
+
+	routes.Post("", middleware.Authenticate(jwtService), returController.AddRetur)
+	routes.Get("", middleware.Authenticate(jwtService), returController.GetReturByFaktur)
+	routes.Get("/by-id", middleware.Authenticate(jwtService), returController.GetReturById)
+}
@@ -2,6 +2,7 @@ package routes
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"github.com/jejevj/ykp_pos/constants"
 	"github.com/jejevj/ykp_pos/controller"
 	"github.com/jejevj/ykp_pos/middleware"
 	"github.com/jejevj/ykp_pos/service"
@@ -10,9 +11,9 @@ import (
 func Satuan(route fiber.Router, satuanController controller.SatuanController, jwtService service.JWTService) {
 	routes := route.Group("/satuan")
 
-	routes.Post("", satuanController.AddSatuan)
-	routes.Get("", satuanController.GetAllSatuanWithPagination)
-	routes.Delete("", middleware.Authenticate(jwtService), satuanController.DeleteSatuan)
-	routes.Put("", middleware.Authenticate(jwtService), satuanController.UpdateSatuan)
+	routes.Post("", middleware.Authenticate(jwtService), middleware.Authorize(constants.ENUM_ROLE_ADMIN), satuanController.AddSatuan)
+	routes.Get("", middleware.Authenticate(jwtService), satuanController.GetAllSatuanWithPagination)
+	routes.Delete("", middleware.Authenticate(jwtService), middleware.Authorize(constants.ENUM_ROLE_ADMIN), satuanController.DeleteSatuan)
+	routes.Put("", middleware.Authenticate(jwtService), middleware.Authorize(constants.ENUM_ROLE_ADMIN), satuanController.UpdateSatuan)
 	routes.Get("/by-id", middleware.Authenticate(jwtService), satuanController.GetSatuanById)
 }
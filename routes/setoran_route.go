@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/jejevj/ykp_pos/constants"
+	"github.com/jejevj/ykp_pos/controller"
+	"github.com/jejevj/ykp_pos/middleware"
+	"github.com/jejevj/ykp_pos/service"
+)
+
+func Setoran(route fiber.Router, setoranController controller.SetoranController, jwtService service.JWTService) {
+	routes := route.Group("/setoran")
+
+	routes.Post("", middleware.Authenticate(jwtService), middleware.Authorize(constants.ENUM_ROLE_ADMIN), setoranController.AddSetoran)
+	routes.Get("/by-loading", middleware.Authenticate(jwtService), setoranController.GetSetoranByLoading)
+	routes.Get("/pdf", middleware.Authenticate(jwtService), setoranController.CetakSetoran)
+}
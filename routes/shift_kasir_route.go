@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/jejevj/ykp_pos/constants"
+	"github.com/jejevj/ykp_pos/controller"
+	"github.com/jejevj/ykp_pos/middleware"
+	"github.com/jejevj/ykp_pos/service"
+)
+
+func ShiftKasir(route fiber.Router, shiftKasirController controller.ShiftKasirController, jwtService service.JWTService) {
+	routes := route.Group("/shift")
+
+	routes.Post("", middleware.Authenticate(jwtService), shiftKasirController.BukaShift)
+	routes.Get("", middleware.Authenticate(jwtService), middleware.Authorize(constants.ENUM_ROLE_ADMIN), shiftKasirController.GetAllShiftWithPagination)
+	routes.Get("/aktif", middleware.Authenticate(jwtService), shiftKasirController.GetShiftAktif)
+	routes.Post("/kas", middleware.Authenticate(jwtService), shiftKasirController.AddKasShift)
+	routes.Put("/tutup", middleware.Authenticate(jwtService), shiftKasirController.TutupShift)
+	routes.Get("/by-id", middleware.Authenticate(jwtService), shiftKasirController.GetShiftById)
+	routes.Get("/rekap", middleware.Authenticate(jwtService), middleware.Authorize(constants.ENUM_ROLE_ADMIN), shiftKasirController.GetRekapShift)
+}
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/jejevj/ykp_pos/constants"
+	"github.com/jejevj/ykp_pos/controller"
+	"github.com/jejevj/ykp_pos/middleware"
+	"github.com/jejevj/ykp_pos/service"
+)
+
+func Supplier(route fiber.Router, supplierController controller.SupplierController, jwtService service.JWTService) {
+	routes := route.Group("/supplier")
+
+	routes.Post("", middleware.Authenticate(jwtService), middleware.Authorize(constants.ENUM_ROLE_ADMIN), supplierController.AddSupplier)
+	routes.Get("", middleware.Authenticate(jwtService), supplierController.GetAllSupplierWithPagination)
+	routes.Delete("", middleware.Authenticate(jwtService), middleware.Authorize(constants.ENUM_ROLE_ADMIN), supplierController.DeleteSupplier)
+	routes.Put("", middleware.Authenticate(jwtService), middleware.Authorize(constants.ENUM_ROLE_ADMIN), supplierController.UpdateSupplier)
+	routes.Get("/by-id", middleware.Authenticate(jwtService), supplierController.GetSupplierById)
+}
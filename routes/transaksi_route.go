@@ -10,8 +10,8 @@ import (
 func Transaksi(route fiber.Router, transaksiController controller.TransaksiController, jwtService service.JWTService) {
 	routes := route.Group("/trx")
 
-	routes.Post("", transaksiController.AddTransaksi)
-	routes.Get("", transaksiController.GetAllTransaksiWithPagination)
+	routes.Post("", middleware.Authenticate(jwtService), transaksiController.AddTransaksi)
+	routes.Get("", middleware.Authenticate(jwtService), transaksiController.GetAllTransaksiWithPagination)
 	routes.Delete("", middleware.Authenticate(jwtService), transaksiController.DeleteTransaksi)
 	routes.Put("", middleware.Authenticate(jwtService), transaksiController.UpdateTransaksi)
 	routes.Get("/by-id", middleware.Authenticate(jwtService), transaksiController.GetTransaksiById)
@@ -2,6 +2,7 @@ package routes
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"github.com/jejevj/ykp_pos/constants"
 	"github.com/jejevj/ykp_pos/controller"
 	"github.com/jejevj/ykp_pos/middleware"
 	"github.com/jejevj/ykp_pos/service"
@@ -11,9 +12,9 @@ func User(route fiber.Router, userController controller.UserController, jwtServi
 	routes := route.Group("/user")
 
 	routes.Post("", userController.Register)
-	routes.Get("", userController.GetAllUser)
+	routes.Get("", middleware.Authenticate(jwtService), middleware.Authorize(constants.ENUM_ROLE_ADMIN), userController.GetAllUser)
 	routes.Post("/login", userController.Login)
-	routes.Delete("", middleware.Authenticate(jwtService), userController.Delete)
+	routes.Delete("", middleware.Authenticate(jwtService), middleware.Authorize(constants.ENUM_ROLE_ADMIN), userController.Delete)
 	routes.Put("", middleware.Authenticate(jwtService), userController.Update)
 	routes.Get("/me", middleware.Authenticate(jwtService), userController.Me)
 	routes.Post("/verify_email", userController.VerifyEmail)
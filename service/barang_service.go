@@ -3,21 +3,27 @@ package service
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jejevj/ykp_pos/dto"
 	"github.com/jejevj/ykp_pos/entity"
+	"github.com/jejevj/ykp_pos/helpers"
 	"github.com/jejevj/ykp_pos/repository"
 )
 
 type (
 	BarangService interface {
-		AddBarang(ctx context.Context, req dto.BarangCreateRequest) (dto.BarangResponse, error)
-		GetAllBarangWithPagination(ctx context.Context) (dto.BarangPaginationResponse, error)
+		AddBarang(ctx context.Context, req dto.BarangCreateRequest, userId string) (dto.BarangResponse, error)
+		GetAllBarangWithPagination(ctx context.Context, req dto.PaginationRequest) (dto.BarangPaginationResponse, error)
 		GetBarangById(ctx context.Context, barangId string) (dto.BarangResponse, error)
+		GetBarangByKode(ctx context.Context, kodeBarang string) (dto.BarangResponse, error)
 		UpdateBarang(ctx context.Context, req dto.BarangUpdateRequest, barangId string) (dto.BarangUpdateResponse, error)
-		UpdateStokBarang(ctx context.Context, req dto.BarangUpdateStokRequest, barangId string) (dto.BarangUpdateResponse, error)
+		UpdateStokBarang(ctx context.Context, req dto.BarangUpdateStokRequest, barangId string, userId string) (dto.BarangUpdateResponse, error)
+		OpnameBarang(ctx context.Context, req dto.BarangOpnameRequest, userId string) (dto.BarangUpdateResponse, error)
 		DeleteBarang(ctx context.Context, barangId string) error
+		GetKartuStok(ctx context.Context, req dto.KartuStokRequest) (dto.KartuStokResponse, error)
 	}
 	barangService struct {
 		barangRepo repository.BarangRepository
@@ -31,10 +37,115 @@ func NewBarangService(barangRepo repository.BarangRepository, jwtService JWTServ
 		jwtService: jwtService,
 	}
 }
-func (s *barangService) AddBarang(ctx context.Context, req dto.BarangCreateRequest) (dto.BarangResponse, error) {
+
+// validSatuanBarang checks a unit list: every unit needs a unique name and a
+// positive number of base units.
+func validSatuanBarang(satuans []dto.BarangSatuanRequest) error {
+	names := map[string]bool{}
+	for _, satuan := range satuans {
+		name := strings.ToLower(strings.TrimSpace(satuan.NamaSatuan))
+		if name == "" || satuan.Isi <= 0 || names[name] {
+			return dto.ErrSatuanBarangInvalid
+		}
+		names[name] = true
+	}
+
+	return nil
+}
+
+func toBarangSatuanEntities(satuans []dto.BarangSatuanRequest) []entity.BarangSatuan {
+	var datas []entity.BarangSatuan
+	for _, satuan := range satuans {
+		datas = append(datas, entity.BarangSatuan{
+			NamaSatuan: strings.TrimSpace(satuan.NamaSatuan),
+			Isi:        satuan.Isi,
+		})
+	}
+
+	return datas
+}
+
+func toRincian(rincian []dto.JumlahSatuanRequest) []helpers.JumlahSatuan {
+	var datas []helpers.JumlahSatuan
+	for _, item := range rincian {
+		datas = append(datas, helpers.JumlahSatuan{Nama: item.Satuan, Jumlah: item.Jumlah})
+	}
+
+	return datas
+}
+
+// formatJumlahBarang renders a quantity in pieces in the units of the barang.
+func formatJumlahBarang(barang entity.Barang, jumlah int) string {
+	return helpers.FormatJumlah(barang.SatuanKonversi(), jumlah)
+}
+
+func toBarangResponse(barang entity.Barang) dto.BarangResponse {
+	satuans := barang.SatuanKonversi()
+
+	var rincian []dto.JumlahSatuanResponse
+	for _, item := range helpers.UraiJumlah(satuans, barang.Stok) {
+		rincian = append(rincian, dto.JumlahSatuanResponse{Satuan: item.Nama, Jumlah: item.Jumlah})
+	}
+
+	var satuanBarang []dto.BarangSatuanResponse
+	for _, satuan := range barang.Satuans {
+		satuanBarang = append(satuanBarang, dto.BarangSatuanResponse{
+			ID:         satuan.ID.String(),
+			NamaSatuan: satuan.NamaSatuan,
+			Isi:        satuan.Isi,
+		})
+	}
+
+	return dto.BarangResponse{
+		ID:           barang.ID.String(),
+		NamaBarang:   barang.NamaBarang,
+		KodeBarang:   barang.KodeBarang,
+		HargaBeli:    barang.HargaBeli,
+		HargaJual:    barang.HargaJual,
+		IdSatuan:     barang.IdSatuan,
+		JumlahKrat:   barang.JumlahKrat,
+		JumlahSatuan: barang.JumlahSatuan,
+		Stok:         barang.Stok,
+		StokFormat:   helpers.FormatJumlah(satuans, barang.Stok),
+		StokRincian:  rincian,
+		StokRusak:    barang.StokRusak,
+		Satuans:      satuanBarang,
+		Satuan: dto.SatuanResponse{
+			ID:         barang.Satuan.ID.String(),
+			NamaSatuan: barang.Satuan.NamaSatuan,
+			Value:      barang.Satuan.Value,
+		},
+	}
+}
+
+func toBarangUpdateResponse(barang entity.Barang) dto.BarangUpdateResponse {
+	res := toBarangResponse(barang)
+
+	return dto.BarangUpdateResponse{
+		ID:           res.ID,
+		NamaBarang:   res.NamaBarang,
+		KodeBarang:   res.KodeBarang,
+		HargaBeli:    res.HargaBeli,
+		HargaJual:    res.HargaJual,
+		IdSatuan:     res.IdSatuan,
+		Satuan:       res.Satuan,
+		JumlahKrat:   res.JumlahKrat,
+		JumlahSatuan: res.JumlahSatuan,
+		Stok:         res.Stok,
+		StokFormat:   res.StokFormat,
+		StokRincian:  res.StokRincian,
+		Satuans:      res.Satuans,
+	}
+}
+
+func (s *barangService) AddBarang(ctx context.Context, req dto.BarangCreateRequest, userId string) (dto.BarangResponse, error) {
 	mu.Lock()
 	defer mu.Unlock()
 
+	if err := validSatuanBarang(req.Satuans); err != nil {
+		return dto.BarangResponse{}, err
+	}
+
 	barang := entity.Barang{
 		NamaBarang:   req.NamaBarang,
 		KodeBarang:   req.KodeBarang,
@@ -44,66 +155,33 @@ func (s *barangService) AddBarang(ctx context.Context, req dto.BarangCreateReque
 		JumlahKrat:   req.JumlahKrat,
 		JumlahSatuan: req.JumlahSatuan,
 		// Stok:         req.Stok,
+		Satuans: toBarangSatuanEntities(req.Satuans),
+		Rincian: toRincian(req.Rincian),
 	}
 
-	barangAdd, err := s.barangRepo.AddBarang(ctx, barang)
+	barangAdd, err := s.barangRepo.AddBarang(ctx, barang, userId)
 	if err != nil {
-		return dto.BarangResponse{}, dto.ErrCreateBarang
+		return dto.BarangResponse{}, fmt.Errorf("%v: %v", dto.ErrCreateBarang, err)
 	}
 
-	// Convert Satuan entity to SatuanResponse DTO
-	satuanResponse := dto.SatuanResponse{
-		ID:         barangAdd.Satuan.ID.String(),
-		NamaSatuan: barangAdd.Satuan.NamaSatuan,
-		Value:      barangAdd.Satuan.Value,
-		// Add other fields from Satuan entity if necessary
-	}
-
-	// req.JumlahKrat
-
-	return dto.BarangResponse{
-		ID:           barangAdd.ID.String(),
-		NamaBarang:   barangAdd.NamaBarang,
-		KodeBarang:   barangAdd.KodeBarang,
-		HargaBeli:    barangAdd.HargaBeli,
-		HargaJual:    barangAdd.HargaJual,
-		IdSatuan:     barangAdd.IdSatuan,
-		Satuan:       satuanResponse,
-		JumlahKrat:   barangAdd.JumlahKrat,
-		JumlahSatuan: barangAdd.JumlahSatuan,
-		Stok:         barangAdd.Stok,
-	}, nil
+	return toBarangResponse(barangAdd), nil
 }
-func (s *barangService) GetAllBarangWithPagination(ctx context.Context) (dto.BarangPaginationResponse, error) {
-	dataWithPaginate, err := s.barangRepo.GetAllBarangWithPagination(ctx)
+
+func (s *barangService) GetAllBarangWithPagination(ctx context.Context, req dto.PaginationRequest) (dto.BarangPaginationResponse, error) {
+	dataWithPaginate, err := s.barangRepo.GetAllBarangWithPagination(ctx, req)
 	if err != nil {
 		return dto.BarangPaginationResponse{}, err
 	}
 
 	var datas []dto.BarangResponse
 	for _, barang := range dataWithPaginate.Barangs {
-		data := dto.BarangResponse{
-			ID:           barang.ID.String(),
-			NamaBarang:   barang.NamaBarang,
-			KodeBarang:   barang.KodeBarang,
-			HargaBeli:    barang.HargaBeli,
-			HargaJual:    barang.HargaJual,
-			IdSatuan:     barang.IdSatuan,
-			JumlahKrat:   barang.JumlahKrat,
-			JumlahSatuan: barang.JumlahSatuan,
-			Satuan: dto.SatuanResponse{
-				ID:         barang.Satuan.ID.String(),
-				NamaSatuan: barang.Satuan.NamaSatuan,
-				Value:      barang.Satuan.Value,
-			},
-			Stok: barang.Stok,
-		}
-		datas = append(datas, data)
+		datas = append(datas, toBarangResponse(barang))
 	}
 
 	// Return the response in a format compatible with DataTable
 	return dto.BarangPaginationResponse{
-		Data: datas,
+		Data:  datas,
+		Total: dataWithPaginate.Total,
 		PaginationResponse: dto.PaginationResponse{
 			Page:    dataWithPaginate.Page,
 			PerPage: dataWithPaginate.PerPage,
@@ -119,26 +197,18 @@ func (s *barangService) GetBarangById(ctx context.Context, barangId string) (dto
 		return dto.BarangResponse{}, dto.ErrGetBarangById
 	}
 
-	satuanResponse := dto.SatuanResponse{
-		ID:         barang.Satuan.ID.String(),
-		NamaSatuan: barang.Satuan.NamaSatuan,
-		Value:      barang.Satuan.Value,
-		// Add other fields from Satuan entity if necessary
+	return toBarangResponse(barang), nil
+}
+
+func (s *barangService) GetBarangByKode(ctx context.Context, kodeBarang string) (dto.BarangResponse, error) {
+	barang, err := s.barangRepo.GetBarangByKode(ctx, strings.TrimSpace(kodeBarang))
+	if err != nil {
+		return dto.BarangResponse{}, fmt.Errorf("%v: %v", dto.ErrGetBarangByKode, err)
 	}
 
-	return dto.BarangResponse{
-		ID:           barang.ID.String(),
-		NamaBarang:   barang.NamaBarang,
-		KodeBarang:   barang.KodeBarang,
-		HargaBeli:    barang.HargaBeli,
-		HargaJual:    barang.HargaJual,
-		IdSatuan:     barang.IdSatuan,
-		JumlahKrat:   barang.JumlahKrat,
-		JumlahSatuan: barang.JumlahSatuan,
-		Satuan:       satuanResponse,
-		Stok:         barang.Stok,
-	}, nil
+	return toBarangResponse(barang), nil
 }
+
 func (s *barangService) UpdateBarang(ctx context.Context, req dto.BarangUpdateRequest, barangId string) (dto.BarangUpdateResponse, error) {
 	// Convert string ID to uuid.UUID (if needed)
 	id, err := uuid.Parse(barangId)
@@ -146,6 +216,10 @@ func (s *barangService) UpdateBarang(ctx context.Context, req dto.BarangUpdateRe
 		return dto.BarangUpdateResponse{}, fmt.Errorf("invalid ID format: %v", err)
 	}
 
+	if err := validSatuanBarang(req.Satuans); err != nil {
+		return dto.BarangUpdateResponse{}, err
+	}
+
 	// Prepare the entity to be updated
 	data := entity.Barang{
 		ID:         id,
@@ -154,33 +228,19 @@ func (s *barangService) UpdateBarang(ctx context.Context, req dto.BarangUpdateRe
 		HargaBeli:  req.HargaBeli,
 		HargaJual:  req.HargaJual,
 		IdSatuan:   req.IdSatuan,
-		Stok:       req.Stok,
+		Satuans:    toBarangSatuanEntities(req.Satuans),
 	}
 
 	// Call the repository to update
 	barangUpdate, err := s.barangRepo.UpdateBarang(ctx, data)
 	if err != nil {
 		return dto.BarangUpdateResponse{}, fmt.Errorf("failed to update Barang: %v", err)
-	} // Convert Satuan entity to SatuanResponse DTO
-	satuanResponse := dto.SatuanResponse{
-		ID:         barangUpdate.Satuan.ID.String(),
-		NamaSatuan: barangUpdate.Satuan.NamaSatuan,
-		// Add other fields from Satuan entity if necessary
 	}
 
-	return dto.BarangUpdateResponse{
-		ID:         barangUpdate.ID.String(),
-		NamaBarang: barangUpdate.NamaBarang,
-		KodeBarang: barangUpdate.KodeBarang,
-		HargaBeli:  barangUpdate.HargaBeli,
-		HargaJual:  barangUpdate.HargaJual,
-		IdSatuan:   barangUpdate.IdSatuan,
-		Satuan:     satuanResponse,
-		Stok:       barangUpdate.Stok,
-	}, nil
+	return toBarangUpdateResponse(barangUpdate), nil
 }
 
-func (s *barangService) UpdateStokBarang(ctx context.Context, req dto.BarangUpdateStokRequest, barangId string) (dto.BarangUpdateResponse, error) {
+func (s *barangService) UpdateStokBarang(ctx context.Context, req dto.BarangUpdateStokRequest, barangId string, userId string) (dto.BarangUpdateResponse, error) {
 	// Convert string ID to uuid.UUID (if needed)
 	id, err := uuid.Parse(barangId)
 	if err != nil {
@@ -193,32 +253,40 @@ func (s *barangService) UpdateStokBarang(ctx context.Context, req dto.BarangUpda
 
 		JumlahKrat:   req.JumlahKrat,
 		JumlahSatuan: req.JumlahSatuan,
+		Rincian:      toRincian(req.Rincian),
 	}
 
 	// Call the repository to update
-	barangUpdate, err := s.barangRepo.UpdateStokBarang(ctx, data)
+	barangUpdate, err := s.barangRepo.UpdateStokBarang(ctx, data, userId)
 	if err != nil {
 		return dto.BarangUpdateResponse{}, fmt.Errorf("failed to update Barang: %v", err)
-	} // Convert Satuan entity to SatuanResponse DTO
-	satuanResponse := dto.SatuanResponse{
-		ID:         barangUpdate.Satuan.ID.String(),
-		NamaSatuan: barangUpdate.Satuan.NamaSatuan,
-		Value:      barangUpdate.Satuan.Value,
-		// Add other fields from Satuan entity if necessary
 	}
 
-	return dto.BarangUpdateResponse{
-		ID:           barangUpdate.ID.String(),
-		NamaBarang:   barangUpdate.NamaBarang,
-		KodeBarang:   barangUpdate.KodeBarang,
-		HargaBeli:    barangUpdate.HargaBeli,
-		HargaJual:    barangUpdate.HargaJual,
-		IdSatuan:     barangUpdate.IdSatuan,
-		JumlahKrat:   barangUpdate.JumlahKrat,
-		JumlahSatuan: barangUpdate.JumlahSatuan,
-		Satuan:       satuanResponse,
-		Stok:         barangUpdate.Stok,
-	}, nil
+	return toBarangUpdateResponse(barangUpdate), nil
+}
+
+func (s *barangService) OpnameBarang(ctx context.Context, req dto.BarangOpnameRequest, userId string) (dto.BarangUpdateResponse, error) {
+	id, err := uuid.Parse(req.ID)
+	if err != nil {
+		return dto.BarangUpdateResponse{}, fmt.Errorf("invalid ID format: %v", err)
+	}
+
+	keterangan := strings.TrimSpace(req.Keterangan)
+	if keterangan == "" {
+		keterangan = "stok opname"
+	}
+
+	barangOpname, err := s.barangRepo.OpnameBarang(ctx, entity.Barang{
+		ID:           id,
+		JumlahKrat:   req.JumlahKrat,
+		JumlahSatuan: req.JumlahSatuan,
+		Rincian:      toRincian(req.Rincian),
+	}, keterangan, userId)
+	if err != nil {
+		return dto.BarangUpdateResponse{}, fmt.Errorf("failed to update Barang: %v", err)
+	}
+
+	return toBarangUpdateResponse(barangOpname), nil
 }
 
 func (s *barangService) DeleteBarang(ctx context.Context, barangId string) error {
@@ -234,3 +302,67 @@ func (s *barangService) DeleteBarang(ctx context.Context, barangId string) error
 
 	return nil
 }
+
+func (s *barangService) GetKartuStok(ctx context.Context, req dto.KartuStokRequest) (dto.KartuStokResponse, error) {
+	tanggalMulai, err := parseTanggal(req.TanggalMulai)
+	if err != nil {
+		return dto.KartuStokResponse{}, err
+	}
+	tanggalAkhir, err := parseTanggal(req.TanggalAkhir)
+	if err != nil {
+		return dto.KartuStokResponse{}, err
+	}
+
+	// Default to the current month when no period is given
+	now := time.Now()
+	if tanggalMulai == nil {
+		awalBulan := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+		tanggalMulai = &awalBulan
+	}
+	if tanggalAkhir == nil {
+		hariIni := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+		tanggalAkhir = &hariIni
+	}
+
+	// The end date is inclusive, so the query runs up to the next midnight
+	kartuStok, err := s.barangRepo.GetKartuStok(ctx, req.IdBarang, *tanggalMulai, tanggalAkhir.AddDate(0, 0, 1))
+	if err != nil {
+		return dto.KartuStokResponse{}, fmt.Errorf("%v: %v", dto.ErrGetKartuStok, err)
+	}
+
+	res := dto.KartuStokResponse{
+		Barang:       toBarangResponse(kartuStok.Barang),
+		TanggalMulai: formatTanggal(tanggalMulai),
+		TanggalAkhir: formatTanggal(tanggalAkhir),
+		SaldoAwal:    kartuStok.SaldoAwal,
+		SaldoAkhir:   kartuStok.SaldoAwal,
+	}
+	for _, movement := range kartuStok.Movements {
+		data := dto.StockMovementResponse{
+			ID:           movement.ID.String(),
+			Tanggal:      movement.Tanggal.Format(time.RFC3339),
+			Jumlah:       movement.Jumlah,
+			JumlahFormat: formatJumlahBarang(kartuStok.Barang, movement.Jumlah),
+			Alasan:       movement.Alasan,
+			RefTipe:      movement.RefTipe,
+			RefId:        movement.RefId,
+			RefNo:        movement.RefNo,
+			IdUser:       movement.IdUser,
+			Saldo:        movement.Saldo,
+			Keterangan:   movement.Keterangan,
+		}
+		if movement.Jumlah > 0 {
+			data.Masuk = movement.Jumlah
+			res.TotalMasuk += movement.Jumlah
+		} else {
+			data.Keluar = -movement.Jumlah
+			res.TotalKeluar -= movement.Jumlah
+		}
+		res.SaldoAkhir += movement.Jumlah
+		res.Data = append(res.Data, data)
+	}
+	res.SaldoAwalFormat = formatJumlahBarang(kartuStok.Barang, res.SaldoAwal)
+	res.SaldoAkhirFormat = formatJumlahBarang(kartuStok.Barang, res.SaldoAkhir)
+
+	return res, nil
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/jejevj/ykp_pos/dto"
@@ -13,10 +14,13 @@ import (
 type (
 	CustomerService interface {
 		AddCustomer(ctx context.Context, req dto.CustomerCreateRequest) (dto.CustomerResponse, error)
-		GetAllCustomerWithPagination(ctx context.Context) (dto.CustomerPaginationResponse, error)
+		GetAllCustomerWithPagination(ctx context.Context, req dto.PaginationRequest) (dto.CustomerPaginationResponse, error)
 		GetCustomerById(ctx context.Context, customerId string) (dto.CustomerResponse, error)
 		UpdateCustomer(ctx context.Context, req dto.CustomerUpdateRequest, customerId string) (dto.CustomerUpdateResponse, error)
 		DeleteCustomer(ctx context.Context, customerId string) error
+		UpdateKreditCustomer(ctx context.Context, req dto.CustomerKreditRequest) (dto.CustomerResponse, error)
+		AddOverrideKredit(ctx context.Context, req dto.OverrideKreditCreateRequest, userId string) (dto.OverrideKreditResponse, error)
+		GetOverrideKredit(ctx context.Context, customerId string) ([]dto.OverrideKreditResponse, error)
 	}
 	customerService struct {
 		customerRepo repository.CustomerRepository
@@ -39,6 +43,7 @@ func (s *customerService) AddCustomer(ctx context.Context, req dto.CustomerCreat
 		NamaPemilik: req.NamaPemilik,
 		Alamat:      req.Alamat,
 		HP:          req.HP,
+		Npwp:        req.Npwp,
 	}
 
 	customerAdd, err := s.customerRepo.AddCustomer(ctx, customer)
@@ -47,15 +52,18 @@ func (s *customerService) AddCustomer(ctx context.Context, req dto.CustomerCreat
 	}
 
 	return dto.CustomerResponse{
-		ID:          customerAdd.ID.String(),
-		NamaToko:    customerAdd.NamaToko,
-		NamaPemilik: customerAdd.NamaPemilik,
-		Alamat:      customerAdd.Alamat,
-		HP:          customerAdd.HP,
+		ID:           customerAdd.ID.String(),
+		NamaToko:     customerAdd.NamaToko,
+		NamaPemilik:  customerAdd.NamaPemilik,
+		Alamat:       customerAdd.Alamat,
+		HP:           customerAdd.HP,
+		Npwp:         customerAdd.Npwp,
+		LimitKredit:  customerAdd.LimitKredit,
+		MaxHariTempo: customerAdd.MaxHariTempo,
 	}, nil
 }
-func (s *customerService) GetAllCustomerWithPagination(ctx context.Context) (dto.CustomerPaginationResponse, error) {
-	dataWithPaginate, err := s.customerRepo.GetAllCustomerWithPagination(ctx)
+func (s *customerService) GetAllCustomerWithPagination(ctx context.Context, req dto.PaginationRequest) (dto.CustomerPaginationResponse, error) {
+	dataWithPaginate, err := s.customerRepo.GetAllCustomerWithPagination(ctx, req)
 	if err != nil {
 		return dto.CustomerPaginationResponse{}, err
 	}
@@ -63,11 +71,14 @@ func (s *customerService) GetAllCustomerWithPagination(ctx context.Context) (dto
 	var datas []dto.CustomerResponse
 	for _, customer := range dataWithPaginate.Customers {
 		data := dto.CustomerResponse{
-			ID:          customer.ID.String(),
-			NamaToko:    customer.NamaToko,
-			NamaPemilik: customer.NamaPemilik,
-			Alamat:      customer.Alamat,
-			HP:          customer.HP,
+			ID:           customer.ID.String(),
+			NamaToko:     customer.NamaToko,
+			NamaPemilik:  customer.NamaPemilik,
+			Alamat:       customer.Alamat,
+			HP:           customer.HP,
+			Npwp:         customer.Npwp,
+			LimitKredit:  customer.LimitKredit,
+			MaxHariTempo: customer.MaxHariTempo,
 		}
 
 		datas = append(datas, data)
@@ -90,11 +101,14 @@ func (s *customerService) GetCustomerById(ctx context.Context, customerId string
 	}
 
 	return dto.CustomerResponse{
-		ID:          customer.ID.String(),
-		NamaToko:    customer.NamaToko,
-		NamaPemilik: customer.NamaPemilik,
-		Alamat:      customer.Alamat,
-		HP:          customer.HP,
+		ID:           customer.ID.String(),
+		NamaToko:     customer.NamaToko,
+		NamaPemilik:  customer.NamaPemilik,
+		Alamat:       customer.Alamat,
+		HP:           customer.HP,
+		Npwp:         customer.Npwp,
+		LimitKredit:  customer.LimitKredit,
+		MaxHariTempo: customer.MaxHariTempo,
 	}, nil
 }
 func (s *customerService) UpdateCustomer(ctx context.Context, req dto.CustomerUpdateRequest, customerId string) (dto.CustomerUpdateResponse, error) {
@@ -111,6 +125,7 @@ func (s *customerService) UpdateCustomer(ctx context.Context, req dto.CustomerUp
 		NamaPemilik: req.NamaPemilik,
 		Alamat:      req.Alamat,
 		HP:          req.HP,
+		Npwp:        req.Npwp,
 	}
 
 	// Call the repository to update
@@ -125,6 +140,7 @@ func (s *customerService) UpdateCustomer(ctx context.Context, req dto.CustomerUp
 		NamaPemilik: customerUpdate.NamaPemilik,
 		Alamat:      customerUpdate.Alamat,
 		HP:          customerUpdate.HP,
+		Npwp:        customerUpdate.Npwp,
 	}, nil
 }
 
@@ -141,3 +157,84 @@ func (s *customerService) DeleteCustomer(ctx context.Context, customerId string)
 
 	return nil
 }
+
+func (s *customerService) UpdateKreditCustomer(ctx context.Context, req dto.CustomerKreditRequest) (dto.CustomerResponse, error) {
+	id, err := uuid.Parse(req.ID)
+	if err != nil {
+		return dto.CustomerResponse{}, fmt.Errorf("invalid ID format: %v", err)
+	}
+	if req.LimitKredit < 0 || req.MaxHariTempo < 0 {
+		return dto.CustomerResponse{}, dto.ErrKreditInvalid
+	}
+
+	customer, err := s.customerRepo.UpdateKreditCustomer(ctx, entity.Customer{
+		ID:           id,
+		LimitKredit:  req.LimitKredit,
+		MaxHariTempo: req.MaxHariTempo,
+	})
+	if err != nil {
+		return dto.CustomerResponse{}, fmt.Errorf("%v: %v", dto.ErrUpdateCustomer, err)
+	}
+
+	return dto.CustomerResponse{
+		ID:           customer.ID.String(),
+		NamaToko:     customer.NamaToko,
+		NamaPemilik:  customer.NamaPemilik,
+		Alamat:       customer.Alamat,
+		HP:           customer.HP,
+		Npwp:         customer.Npwp,
+		LimitKredit:  customer.LimitKredit,
+		MaxHariTempo: customer.MaxHariTempo,
+	}, nil
+}
+
+func toOverrideKreditResponse(override entity.OverrideKredit) dto.OverrideKreditResponse {
+	return dto.OverrideKreditResponse{
+		ID:         override.ID.String(),
+		IdCustomer: override.IdCustomer,
+		Alasan:     override.Alasan,
+		IdUser:     override.IdUser,
+		Admin: dto.UserResponse{
+			ID:         override.Admin.ID.String(),
+			Name:       override.Admin.Name,
+			Email:      override.Admin.Email,
+			TelpNumber: override.Admin.TelpNumber,
+			Role:       override.Admin.Role,
+			ImageUrl:   override.Admin.ImageUrl,
+		},
+		BerlakuSampai: formatTanggal(override.BerlakuSampai),
+		IdFaktur:      override.IdFaktur,
+		Dipakai:       override.IdFaktur != "",
+	}
+}
+
+func (s *customerService) AddOverrideKredit(ctx context.Context, req dto.OverrideKreditCreateRequest, userId string) (dto.OverrideKreditResponse, error) {
+	if strings.TrimSpace(req.Alasan) == "" {
+		return dto.OverrideKreditResponse{}, dto.ErrAlasanOverrideRequired
+	}
+
+	override, err := s.customerRepo.AddOverrideKredit(ctx, entity.OverrideKredit{
+		IdCustomer: req.IdCustomer,
+		Alasan:     strings.TrimSpace(req.Alasan),
+		IdUser:     userId,
+	})
+	if err != nil {
+		return dto.OverrideKreditResponse{}, fmt.Errorf("%v: %v", dto.ErrCreateOverrideKredit, err)
+	}
+
+	return toOverrideKreditResponse(override), nil
+}
+
+func (s *customerService) GetOverrideKredit(ctx context.Context, customerId string) ([]dto.OverrideKreditResponse, error) {
+	overrides, err := s.customerRepo.GetOverrideKredit(ctx, customerId)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", dto.ErrGetOverrideKredit, err)
+	}
+
+	datas := []dto.OverrideKreditResponse{}
+	for _, override := range overrides {
+		datas = append(datas, toOverrideKreditResponse(override))
+	}
+
+	return datas, nil
+}
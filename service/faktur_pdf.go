@@ -0,0 +1,228 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/jejevj/ykp_pos/constants"
+	"github.com/jejevj/ykp_pos/entity"
+	"github.com/jejevj/ykp_pos/helpers"
+	"github.com/jejevj/ykp_pos/utils"
+)
+
+// logoImageTypes are the logo formats fpdf can embed.
+var logoImageTypes = map[string]string{
+	".jpg":  "JPG",
+	".jpeg": "JPG",
+	".png":  "PNG",
+	".gif":  "GIF",
+}
+
+// kolomJumlah splits a quantity in pieces into the krat, lusin and satuan
+// columns of a printed invoice. The krat column holds the largest unit of the
+// barang unless that is the lusin itself.
+func kolomJumlah(barang entity.Barang, jumlah int) (int, int, int) {
+	satuans := barang.SatuanKonversi()
+
+	krat := 0
+	if isi := satuans[0].Isi; isi > 1 && !strings.EqualFold(satuans[0].Nama, "lusin") {
+		krat = jumlah / isi
+		jumlah %= isi
+	}
+
+	lusin := 0
+	if satuanLusin, ok := helpers.CariSatuan(satuans, "lusin"); ok && satuanLusin.Isi < satuans[0].Isi {
+		lusin = jumlah / satuanLusin.Isi
+		jumlah %= satuanLusin.Isi
+	}
+
+	return krat, lusin, jumlah
+}
+
+// formatDiskon describes the discounts of a line or invoice, e.g. "10% + 500".
+func formatDiskon(persen float32, nominal int) string {
+	var parts []string
+	if persen > 0 {
+		parts = append(parts, strconv.FormatFloat(float64(persen), 'f', -1, 32)+"%")
+	}
+	if nominal > 0 {
+		parts = append(parts, helpers.FormatAngka(nominal))
+	}
+	if len(parts) == 0 {
+		return "-"
+	}
+
+	return strings.Join(parts, " + ")
+}
+
+// renderFakturPDF prints a faktur with the letterhead of the business on A4
+// or A5 paper.
+func renderFakturPDF(faktur entity.Faktur, setting entity.MainSetting, ukuran string) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", ukuran, "")
+	margin, fontSize := 15.0, 9.0
+	if ukuran == constants.ENUM_KERTAS_A5 {
+		margin, fontSize = 8.0, 7.0
+	}
+	pdf.SetMargins(margin, margin, margin)
+	pdf.SetAutoPageBreak(true, margin)
+	pdf.AddPage()
+
+	pageWidth, _ := pdf.GetPageSize()
+	lebar := pageWidth - 2*margin
+	baris := fontSize * 0.6
+
+	// Letterhead
+	teksX := margin
+	if setting.LogoUrl != "" {
+		logo := filepath.Join(utils.PATH, setting.LogoUrl)
+		tipe := logoImageTypes[strings.ToLower(filepath.Ext(logo))]
+		if _, err := os.Stat(logo); err == nil && tipe != "" {
+			pdf.ImageOptions(logo, margin, margin, 0, baris*3, false, fpdf.ImageOptions{ImageType: tipe}, 0, "")
+			teksX = margin + baris*3 + 4
+		}
+	}
+	pdf.SetXY(teksX, margin)
+	pdf.SetFont("Helvetica", "B", fontSize+4)
+	pdf.CellFormat(0, baris+1, setting.NamaUsaha, "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", fontSize)
+	for _, line := range []string{setting.Alamat, setting.Hp} {
+		if line != "" {
+			pdf.SetX(teksX)
+			pdf.CellFormat(0, baris, line, "", 1, "L", false, 0, "")
+		}
+	}
+	if setting.Npwp != "" {
+		pdf.SetX(teksX)
+		pdf.CellFormat(0, baris, "NPWP: "+setting.Npwp, "", 1, "L", false, 0, "")
+	}
+	pdf.SetY(max(pdf.GetY(), margin+baris*3) + 2)
+	pdf.Line(margin, pdf.GetY(), margin+lebar, pdf.GetY())
+	pdf.Ln(2)
+
+	judul := "FAKTUR PENJUALAN"
+	if faktur.Status == constants.ENUM_FAKTUR_BATAL {
+		judul += " (BATAL)"
+	}
+	pdf.SetFont("Helvetica", "B", fontSize+3)
+	pdf.CellFormat(0, baris+2, judul, "", 1, "C", false, 0, "")
+	pdf.Ln(1)
+
+	// Invoice on the left, customer on the right
+	setengah := lebar / 2
+	pdf.SetFont("Helvetica", "", fontSize)
+	kiri := [][2]string{
+		{"No. Faktur", faktur.NoFaktur},
+		{"Tanggal", formatTanggal(faktur.TanggalFaktur)},
+		{"Jatuh Tempo", formatTanggal(faktur.TanggalTempo)},
+		{"Cara Bayar", faktur.CaraBayar},
+		{"Sales", faktur.Driver.Name},
+	}
+	kanan := [][2]string{
+		{"Kepada", faktur.Customer.NamaToko},
+		{"Pemilik", faktur.Customer.NamaPemilik},
+		{"Alamat", faktur.Customer.Alamat},
+		{"HP", faktur.Customer.HP},
+		{"NPWP", faktur.Customer.Npwp},
+	}
+	labelWidth := setengah * 0.3
+	for i := range kiri {
+		pdf.CellFormat(labelWidth, baris, kiri[i][0], "", 0, "L", false, 0, "")
+		pdf.CellFormat(setengah-labelWidth, baris, ": "+kiri[i][1], "", 0, "L", false, 0, "")
+		pdf.CellFormat(labelWidth, baris, kanan[i][0], "", 0, "L", false, 0, "")
+		pdf.CellFormat(setengah-labelWidth, baris, ": "+kanan[i][1], "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(2)
+
+	// Lines
+	proporsi := []float64{0.05, 0.29, 0.07, 0.07, 0.08, 0.14, 0.13, 0.17}
+	widths := make([]float64, len(proporsi))
+	for i, p := range proporsi {
+		widths[i] = lebar * p
+	}
+	pdf.SetFont("Helvetica", "B", fontSize)
+	for i, title := range []string{"No", "Barang", "Krat", "Lusin", "Satuan", "Harga", "Diskon", "Jumlah"} {
+		pdf.CellFormat(widths[i], baris+1, title, "1", 0, "C", false, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetFont("Helvetica", "", fontSize)
+	for i, item := range faktur.Items {
+		krat, lusin, satuan := kolomJumlah(item.Barang, item.Jumlah)
+		pdf.CellFormat(widths[0], baris+1, strconv.Itoa(i+1), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(widths[1], baris+1, item.Barang.NamaBarang, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[2], baris+1, helpers.FormatAngka(krat), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[3], baris+1, helpers.FormatAngka(lusin), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[4], baris+1, helpers.FormatAngka(satuan), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[5], baris+1, helpers.FormatAngka(item.Harga), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[6], baris+1, formatDiskon(item.DiskonP, item.Diskon), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[7], baris+1, helpers.FormatAngka(item.JumlahRP), "1", 1, "R", false, 0, "")
+	}
+	pdf.Ln(2)
+
+	// Totals
+	totals := [][2]string{{"Subtotal", helpers.FormatRupiah(faktur.Subtotal)}}
+	if faktur.PotonganDiskon > 0 {
+		totals = append(totals, [2]string{"Diskon " + formatDiskon(faktur.DiskonP, faktur.Diskon), "-" + helpers.FormatRupiah(faktur.PotonganDiskon)})
+	}
+	if faktur.TarifPpn > 0 {
+		totals = append(totals,
+			[2]string{"DPP", helpers.FormatRupiah(faktur.Dpp)},
+			[2]string{fmt.Sprintf("PPN %s%%", strconv.FormatFloat(faktur.TarifPpn, 'f', -1, 64)), helpers.FormatRupiah(faktur.Ppn)},
+		)
+	}
+	totals = append(totals, [2]string{"Total", helpers.FormatRupiah(faktur.Total)})
+	if faktur.TotalBayar > 0 {
+		totals = append(totals,
+			[2]string{"Dibayar", helpers.FormatRupiah(faktur.TotalBayar)},
+			[2]string{"Sisa", helpers.FormatRupiah(faktur.Total - faktur.TotalBayar)},
+		)
+	}
+	for _, row := range totals {
+		if row[0] == "Total" {
+			pdf.SetFont("Helvetica", "B", fontSize)
+		}
+		pdf.SetX(margin + lebar*0.55)
+		pdf.CellFormat(lebar*0.2, baris+1, row[0], "", 0, "L", false, 0, "")
+		pdf.CellFormat(lebar*0.25, baris+1, row[1], "", 1, "R", false, 0, "")
+		pdf.SetFont("Helvetica", "", fontSize)
+	}
+	pdf.Ln(1)
+
+	terbilang := helpers.Terbilang(faktur.Total) + " rupiah"
+	pdf.SetFont("Helvetica", "I", fontSize)
+	pdf.MultiCell(0, baris, "Terbilang: "+strings.ToUpper(terbilang[:1])+terbilang[1:], "", "L", false)
+	pdf.SetFont("Helvetica", "", fontSize)
+
+	if faktur.TanggalTempo != nil {
+		pdf.Ln(1)
+		pdf.CellFormat(0, baris, "Harap dibayar paling lambat "+formatTanggal(faktur.TanggalTempo), "", 1, "L", false, 0, "")
+	}
+	if faktur.Keterangan != "" {
+		pdf.Ln(1)
+		pdf.MultiCell(0, baris, "Keterangan: "+faktur.Keterangan, "", "L", false)
+	}
+	if faktur.Status == constants.ENUM_FAKTUR_BATAL && faktur.AlasanBatal != "" {
+		pdf.Ln(1)
+		pdf.MultiCell(0, baris, "Dibatalkan: "+faktur.AlasanBatal, "", "L", false)
+	}
+
+	// Signatures
+	pdf.Ln(baris * 2)
+	pdf.CellFormat(setengah, baris, "Penerima", "", 0, "C", false, 0, "")
+	pdf.CellFormat(setengah, baris, "Hormat kami", "", 1, "C", false, 0, "")
+	pdf.Ln(baris * 3)
+	pdf.CellFormat(setengah, baris, "(                    )", "", 0, "C", false, 0, "")
+	pdf.CellFormat(setengah, baris, "( "+faktur.Driver.Name+" )", "", 1, "C", false, 0, "")
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
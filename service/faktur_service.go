@@ -0,0 +1,374 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jejevj/ykp_pos/constants"
+	"github.com/jejevj/ykp_pos/dto"
+	"github.com/jejevj/ykp_pos/entity"
+	"github.com/jejevj/ykp_pos/repository"
+)
+
+type (
+	FakturService interface {
+		AddFaktur(ctx context.Context, req dto.FakturCreateRequest, userId string, ownerId string) (dto.FakturResponse, error)
+		GetAllFakturWithPagination(ctx context.Context, req dto.FakturFilterRequest) (dto.FakturPaginationResponse, error)
+		GetFakturById(ctx context.Context, fakturId string, ownerId string) (dto.FakturResponse, error)
+		UpdateFaktur(ctx context.Context, req dto.FakturUpdateRequest, fakturId string, userId string, ownerId string) (dto.FakturResponse, error)
+		CancelFaktur(ctx context.Context, req dto.FakturCancelRequest, userId string) (dto.FakturResponse, error)
+		CetakFaktur(ctx context.Context, fakturId string, ukuran string, ownerId string) ([]byte, error)
+		CetakStrukFaktur(ctx context.Context, fakturId string, req dto.StrukRequest, ownerId string) ([]byte, error)
+	}
+	fakturService struct {
+		fakturRepo      repository.FakturRepository
+		mainSettingRepo repository.MainSettingRepository
+		jwtService      JWTService
+	}
+)
+
+func NewFakturService(fakturRepo repository.FakturRepository, mainSettingRepo repository.MainSettingRepository, jwtService JWTService) FakturService {
+	return &fakturService{
+		fakturRepo:      fakturRepo,
+		mainSettingRepo: mainSettingRepo,
+		jwtService:      jwtService,
+	}
+}
+
+// parseTanggal converts an optional YYYY-MM-DD string into a time pointer.
+func parseTanggal(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	tanggal, err := time.ParseInLocation(constants.ENUM_DATE_FORMAT, value, time.Local)
+	if err != nil {
+		return nil, dto.ErrInvalidDateFormat
+	}
+
+	return &tanggal, nil
+}
+
+func formatTanggal(value *time.Time) string {
+	if value == nil {
+		return ""
+	}
+
+	return value.Format(constants.ENUM_DATE_FORMAT)
+}
+
+func validCaraBayar(caraBayar string) bool {
+	return caraBayar == constants.ENUM_CARA_BAYAR_TUNAI || caraBayar == constants.ENUM_CARA_BAYAR_KREDIT
+}
+
+func toTransaksiFakturEntities(items []dto.TransaksiFakturRequest) []entity.TransaksiFaktur {
+	var datas []entity.TransaksiFaktur
+	for _, item := range items {
+		datas = append(datas, entity.TransaksiFaktur{
+			IdBarang: item.IdBarang,
+			Krat:     item.Krat,
+			Lusin:    item.Lusin,
+			Satuan:   item.Satuan,
+			Rincian:  toRincian(item.Rincian),
+			Diskon:   item.Diskon,
+			DiskonP:  item.DiskonP,
+			Ket:      item.Ket,
+		})
+	}
+
+	return datas
+}
+
+func toFakturResponse(faktur entity.Faktur) dto.FakturResponse {
+	var items []dto.TransaksiFakturResponse
+	for _, item := range faktur.Items {
+		items = append(items, dto.TransaksiFakturResponse{
+			ID:           item.ID.String(),
+			IdFaktur:     item.IdFaktur,
+			IdBarang:     item.IdBarang,
+			Barang:       toBarangResponse(item.Barang),
+			Krat:         item.Krat,
+			Lusin:        item.Lusin,
+			Satuan:       item.Satuan,
+			Jumlah:       item.Jumlah,
+			JumlahFormat: formatJumlahBarang(item.Barang, item.Jumlah),
+			Harga:        item.Harga,
+			JumlahRP:     item.JumlahRP,
+			Dpp:          item.Dpp,
+			Ppn:          item.Ppn,
+			Diskon:       item.Diskon,
+			DiskonP:      item.DiskonP,
+			DiskonFaktur: item.DiskonFaktur,
+			Ket:          item.Ket,
+		})
+	}
+
+	return dto.FakturResponse{
+		ID:            faktur.ID.String(),
+		NoFaktur:      faktur.NoFaktur,
+		TanggalFaktur: formatTanggal(faktur.TanggalFaktur),
+		TanggalTempo:  formatTanggal(faktur.TanggalTempo),
+		CaraBayar:     faktur.CaraBayar,
+		IdCustomer:    faktur.IdCustomer,
+		Customer: dto.CustomerResponse{
+			ID:          faktur.Customer.ID.String(),
+			NamaToko:    faktur.Customer.NamaToko,
+			NamaPemilik: faktur.Customer.NamaPemilik,
+			Alamat:      faktur.Customer.Alamat,
+			HP:          faktur.Customer.HP,
+			Npwp:        faktur.Customer.Npwp,
+		},
+		IdUser: faktur.IdUser,
+		Driver: dto.UserResponse{
+			ID:         faktur.Driver.ID.String(),
+			Name:       faktur.Driver.Name,
+			Email:      faktur.Driver.Email,
+			TelpNumber: faktur.Driver.TelpNumber,
+			Role:       faktur.Driver.Role,
+			ImageUrl:   faktur.Driver.ImageUrl,
+		},
+		IdLoading:        faktur.IdLoading,
+		Status:           faktur.Status,
+		Subtotal:         faktur.Subtotal,
+		Diskon:           faktur.Diskon,
+		DiskonP:          faktur.DiskonP,
+		PotonganDiskon:   faktur.PotonganDiskon,
+		Total:            faktur.Total,
+		TarifPpn:         faktur.TarifPpn,
+		HargaTermasukPpn: faktur.HargaTermasukPpn != nil && *faktur.HargaTermasukPpn,
+		Dpp:              faktur.Dpp,
+		Ppn:              faktur.Ppn,
+		Eceran:           faktur.Eceran,
+		TotalBayar:       faktur.TotalBayar,
+		SisaTagihan:      faktur.Total - faktur.TotalBayar,
+		BuktiBayar:       faktur.BuktiBayar,
+		IdOverrideKredit: faktur.IdOverrideKredit,
+		Keterangan:       faktur.Keterangan,
+		AlasanBatal:      faktur.AlasanBatal,
+		IdUserBatal:      faktur.IdUserBatal,
+		TanggalBatal:     formatTanggal(faktur.TanggalBatal),
+		Items:            items,
+	}
+}
+
+func (s *fakturService) AddFaktur(ctx context.Context, req dto.FakturCreateRequest, userId string, ownerId string) (dto.FakturResponse, error) {
+	if len(req.Items) == 0 {
+		return dto.FakturResponse{}, dto.ErrFakturItemsEmpty
+	}
+	if !validCaraBayar(req.CaraBayar) {
+		return dto.FakturResponse{}, dto.ErrInvalidCaraBayar
+	}
+
+	tanggalFaktur, err := parseTanggal(req.TanggalFaktur)
+	if err != nil {
+		return dto.FakturResponse{}, err
+	}
+	if tanggalFaktur == nil {
+		now := time.Now()
+		tanggalFaktur = &now
+	}
+
+	tanggalTempo, err := parseTanggal(req.TanggalTempo)
+	if err != nil {
+		return dto.FakturResponse{}, err
+	}
+
+	faktur := entity.Faktur{
+		TanggalFaktur:    tanggalFaktur,
+		TanggalTempo:     tanggalTempo,
+		CaraBayar:        req.CaraBayar,
+		IdCustomer:       req.IdCustomer,
+		IdUser:           userId,
+		IdLoading:        req.IdLoading,
+		Status:           constants.ENUM_FAKTUR_BELUM_BAYAR,
+		Keterangan:       req.Keterangan,
+		Items:            toTransaksiFakturEntities(req.Items),
+		Diskon:           req.Diskon,
+		DiskonP:          req.DiskonP,
+		HargaTermasukPpn: req.HargaTermasukPpn,
+	}
+
+	fakturAdd, err := s.fakturRepo.AddFaktur(ctx, faktur, req.PakaiKredit, ownerId)
+	if err != nil {
+		return dto.FakturResponse{}, fmt.Errorf("%v: %v", dto.ErrCreateFaktur, err)
+	}
+
+	return toFakturResponse(fakturAdd), nil
+}
+
+func (s *fakturService) GetAllFakturWithPagination(ctx context.Context, req dto.FakturFilterRequest) (dto.FakturPaginationResponse, error) {
+	dataWithPaginate, err := s.fakturRepo.GetAllFakturWithPagination(ctx, req)
+	if err != nil {
+		return dto.FakturPaginationResponse{}, err
+	}
+
+	var datas []dto.FakturResponse
+	for _, faktur := range dataWithPaginate.Fakturs {
+		datas = append(datas, toFakturResponse(faktur))
+	}
+
+	return dto.FakturPaginationResponse{
+		Data: datas,
+		PaginationResponse: dto.PaginationResponse{
+			Page:    dataWithPaginate.Page,
+			PerPage: dataWithPaginate.PerPage,
+			MaxPage: dataWithPaginate.MaxPage,
+			Count:   dataWithPaginate.Count,
+		},
+	}, nil
+}
+
+// checkFakturOwner makes sure a sales user only reads and changes the fakturs
+// they issued (ownerId is empty for roles that see everything).
+func checkFakturOwner(faktur entity.Faktur, ownerId string) error {
+	if ownerId != "" && faktur.IdUser != ownerId {
+		return dto.ErrFakturNotOwned
+	}
+
+	return nil
+}
+
+func (s *fakturService) GetFakturById(ctx context.Context, fakturId string, ownerId string) (dto.FakturResponse, error) {
+	faktur, err := s.fakturRepo.GetFakturById(ctx, fakturId)
+	if err != nil {
+		return dto.FakturResponse{}, dto.ErrGetFakturById
+	}
+	if err := checkFakturOwner(faktur, ownerId); err != nil {
+		return dto.FakturResponse{}, err
+	}
+
+	return toFakturResponse(faktur), nil
+}
+
+// CetakFaktur renders the faktur as a PDF invoice on A4 paper, or A5 when
+// asked for.
+func (s *fakturService) CetakFaktur(ctx context.Context, fakturId string, ukuran string, ownerId string) ([]byte, error) {
+	ukuran = strings.ToUpper(ukuran)
+	if ukuran == "" {
+		ukuran = constants.ENUM_KERTAS_A4
+	}
+	if ukuran != constants.ENUM_KERTAS_A4 && ukuran != constants.ENUM_KERTAS_A5 {
+		return nil, dto.ErrInvalidUkuranKertas
+	}
+
+	faktur, err := s.fakturRepo.GetFakturById(ctx, fakturId)
+	if err != nil {
+		return nil, dto.ErrGetFakturById
+	}
+	if err := checkFakturOwner(faktur, ownerId); err != nil {
+		return nil, err
+	}
+
+	setting, err := s.mainSettingRepo.GetMainSetting(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", dto.ErrCetakFaktur, err)
+	}
+
+	pdf, err := renderFakturPDF(faktur, setting, ukuran)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", dto.ErrCetakFaktur, err)
+	}
+
+	return pdf, nil
+}
+
+// CetakStrukFaktur renders the faktur as a thermal printer receipt.
+func (s *fakturService) CetakStrukFaktur(ctx context.Context, fakturId string, req dto.StrukRequest, ownerId string) ([]byte, error) {
+	if err := validStrukRequest(&req); err != nil {
+		return nil, err
+	}
+
+	faktur, err := s.fakturRepo.GetFakturById(ctx, fakturId)
+	if err != nil {
+		return nil, dto.ErrGetFakturById
+	}
+	if err := checkFakturOwner(faktur, ownerId); err != nil {
+		return nil, err
+	}
+
+	setting, err := s.mainSettingRepo.GetMainSetting(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", dto.ErrCetakStruk, err)
+	}
+
+	return renderStruk(strukFaktur(faktur, setting), req), nil
+}
+
+func (s *fakturService) UpdateFaktur(ctx context.Context, req dto.FakturUpdateRequest, fakturId string, userId string, ownerId string) (dto.FakturResponse, error) {
+	// Convert string ID to uuid.UUID (if needed)
+	id, err := uuid.Parse(fakturId)
+	if err != nil {
+		return dto.FakturResponse{}, fmt.Errorf("invalid ID format: %v", err)
+	}
+
+	existingFaktur, err := s.fakturRepo.GetFakturById(ctx, fakturId)
+	if err != nil {
+		return dto.FakturResponse{}, dto.ErrFakturNotFound
+	}
+	if err := checkFakturOwner(existingFaktur, ownerId); err != nil {
+		return dto.FakturResponse{}, err
+	}
+
+	// Whether the faktur can still be changed is checked under its lock
+	if req.CaraBayar != "" && !validCaraBayar(req.CaraBayar) {
+		return dto.FakturResponse{}, dto.ErrInvalidCaraBayar
+	}
+
+	tanggalFaktur, err := parseTanggal(req.TanggalFaktur)
+	if err != nil {
+		return dto.FakturResponse{}, err
+	}
+	tanggalTempo, err := parseTanggal(req.TanggalTempo)
+	if err != nil {
+		return dto.FakturResponse{}, err
+	}
+
+	// Prepare the entity to be updated
+	data := entity.Faktur{
+		ID:               id,
+		TanggalFaktur:    tanggalFaktur,
+		TanggalTempo:     tanggalTempo,
+		CaraBayar:        req.CaraBayar,
+		IdCustomer:       req.IdCustomer,
+		Keterangan:       req.Keterangan,
+		Items:            toTransaksiFakturEntities(req.Items),
+		Diskon:           existingFaktur.Diskon,
+		DiskonP:          existingFaktur.DiskonP,
+		HargaTermasukPpn: req.HargaTermasukPpn,
+	}
+	if req.Diskon != nil {
+		data.Diskon = *req.Diskon
+	}
+	if req.DiskonP != nil {
+		data.DiskonP = *req.DiskonP
+	}
+
+	// Call the repository to update
+	fakturUpdate, err := s.fakturRepo.UpdateFaktur(ctx, data, userId)
+	if err != nil {
+		return dto.FakturResponse{}, fmt.Errorf("failed to update Faktur: %v", err)
+	}
+
+	return toFakturResponse(fakturUpdate), nil
+}
+
+func (s *fakturService) CancelFaktur(ctx context.Context, req dto.FakturCancelRequest, userId string) (dto.FakturResponse, error) {
+	if strings.TrimSpace(req.Alasan) == "" {
+		return dto.FakturResponse{}, dto.ErrAlasanBatalRequired
+	}
+
+	faktur, err := s.fakturRepo.GetFakturById(ctx, req.ID)
+	if err != nil {
+		return dto.FakturResponse{}, dto.ErrFakturNotFound
+	}
+
+	fakturCancel, err := s.fakturRepo.CancelFaktur(ctx, faktur.ID.String(), strings.TrimSpace(req.Alasan), userId)
+	if err != nil {
+		return dto.FakturResponse{}, fmt.Errorf("%v: %v", dto.ErrCancelFaktur, err)
+	}
+
+	return toFakturResponse(fakturCancel), nil
+}
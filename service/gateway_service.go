@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jejevj/ykp_pos/constants"
+	"github.com/jejevj/ykp_pos/dto"
+	"github.com/jejevj/ykp_pos/entity"
+	"github.com/jejevj/ykp_pos/repository"
+)
+
+type (
+	GatewayService interface {
+		TerimaNotifikasi(ctx context.Context, body []byte) (dto.TransaksiGatewayResponse, error)
+		GetTransaksiGatewayByFaktur(ctx context.Context, fakturId string) ([]dto.TransaksiGatewayResponse, error)
+	}
+	gatewayService struct {
+		transaksiGatewayRepo repository.TransaksiGatewayRepository
+		gateway              PaymentGateway
+		jwtService           JWTService
+	}
+)
+
+func NewGatewayService(transaksiGatewayRepo repository.TransaksiGatewayRepository, gateway PaymentGateway, jwtService JWTService) GatewayService {
+	return &gatewayService{
+		transaksiGatewayRepo: transaksiGatewayRepo,
+		gateway:              gateway,
+		jwtService:           jwtService,
+	}
+}
+
+// fakturDariOrderId reads the faktur an order is for. Order ids are the
+// faktur ID, optionally followed by a dash and a suffix so a faktur can be
+// charged more than once.
+func fakturDariOrderId(orderId string) (string, error) {
+	if len(orderId) < 36 || (len(orderId) > 36 && orderId[36] != '-') {
+		return "", dto.ErrOrderIdGateway
+	}
+
+	id, err := uuid.Parse(orderId[:36])
+	if err != nil {
+		return "", dto.ErrOrderIdGateway
+	}
+
+	return id.String(), nil
+}
+
+func toTransaksiGatewayResponse(transaksi entity.TransaksiGateway) dto.TransaksiGatewayResponse {
+	return dto.TransaksiGatewayResponse{
+		ID:            transaksi.ID.String(),
+		Gateway:       transaksi.Gateway,
+		OrderId:       transaksi.OrderId,
+		IdTransaksi:   transaksi.IdTransaksi,
+		IdFaktur:      transaksi.IdFaktur,
+		NoFaktur:      transaksi.Faktur.NoFaktur,
+		Status:        transaksi.Status,
+		StatusGateway: transaksi.StatusGateway,
+		Metode:        transaksi.Metode,
+		Jumlah:        transaksi.Jumlah,
+		WaktuBayar:    formatWaktu(transaksi.WaktuBayar),
+		IdPembayaran:  transaksi.IdPembayaran,
+	}
+}
+
+// TerimaNotifikasi handles a webhook of the payment gateway. Only signed
+// notifications are taken, and a settlement is confirmed with the gateway
+// before the faktur is marked as paid.
+func (s *gatewayService) TerimaNotifikasi(ctx context.Context, body []byte) (dto.TransaksiGatewayResponse, error) {
+	notifikasi, err := s.gateway.VerifikasiNotifikasi(body)
+	if err != nil {
+		if errors.Is(err, dto.ErrSignatureGateway) {
+			return dto.TransaksiGatewayResponse{}, err
+		}
+		return dto.TransaksiGatewayResponse{}, fmt.Errorf("%v: %v", dto.ErrNotifikasiGateway, err)
+	}
+
+	idFaktur, err := fakturDariOrderId(notifikasi.OrderId)
+	if err != nil {
+		return dto.TransaksiGatewayResponse{}, err
+	}
+
+	if notifikasi.Status == constants.ENUM_GATEWAY_BERHASIL {
+		status, err := s.gateway.CekStatus(ctx, notifikasi.OrderId)
+		if err != nil {
+			return dto.TransaksiGatewayResponse{}, fmt.Errorf("%v: %v", dto.ErrStatusGateway, err)
+		}
+		notifikasi = status
+	}
+
+	transaksi, err := s.transaksiGatewayRepo.CatatTransaksiGateway(ctx, entity.TransaksiGateway{
+		Gateway:       s.gateway.Nama(),
+		OrderId:       notifikasi.OrderId,
+		IdTransaksi:   notifikasi.IdTransaksi,
+		IdFaktur:      idFaktur,
+		Status:        notifikasi.Status,
+		StatusGateway: notifikasi.StatusGateway,
+		Metode:        notifikasi.Metode,
+		Jumlah:        notifikasi.Jumlah,
+		WaktuBayar:    notifikasi.WaktuBayar,
+		Payload:       notifikasi.Payload,
+	})
+	if err != nil {
+		return dto.TransaksiGatewayResponse{}, fmt.Errorf("%v: %v", dto.ErrNotifikasiGateway, err)
+	}
+
+	return toTransaksiGatewayResponse(transaksi), nil
+}
+
+func (s *gatewayService) GetTransaksiGatewayByFaktur(ctx context.Context, fakturId string) ([]dto.TransaksiGatewayResponse, error) {
+	transaksis, err := s.transaksiGatewayRepo.GetTransaksiGatewayByFaktur(ctx, fakturId)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", dto.ErrGetTransaksiGateway, err)
+	}
+
+	datas := []dto.TransaksiGatewayResponse{}
+	for _, transaksi := range transaksis {
+		datas = append(datas, toTransaksiGatewayResponse(transaksi))
+	}
+
+	return datas, nil
+}
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+
+	"github.com/jejevj/ykp_pos/constants"
+	"github.com/jejevj/ykp_pos/dto"
+	"github.com/jejevj/ykp_pos/entity"
+	"github.com/jejevj/ykp_pos/helpers"
+	"github.com/xuri/excelize/v2"
+)
+
+var umurPiutangBucketHeader = []string{"Current", "1-30", "31-60", "61-90", ">90", "Total"}
+
+var umurPiutangFakturHeader = []string{
+	"No Faktur", "Tanggal Faktur", "Tanggal Tempo", "Customer", "Sales",
+	"Total", "Dibayar", "Sisa", "Hari Lewat", "Kelompok",
+}
+
+func bucketValues(bucket dto.UmurPiutangBucket) []int {
+	return []int{bucket.Current, bucket.Hari1To30, bucket.Hari31To60, bucket.Hari61To90, bucket.HariOver90, bucket.Total}
+}
+
+func umurPiutangGroupHeader(laporan dto.UmurPiutangResponse) []string {
+	nama := "Customer"
+	if laporan.Group == constants.ENUM_LAPORAN_GROUP_SALES {
+		nama = "Sales"
+	}
+
+	return append([]string{nama}, umurPiutangBucketHeader...)
+}
+
+func umurPiutangFakturValues(faktur dto.UmurPiutangFakturResponse) []interface{} {
+	return []interface{}{
+		faktur.NoFaktur, faktur.TanggalFaktur, faktur.TanggalTempo, faktur.NamaCustomer, faktur.NamaSales,
+		faktur.Total, faktur.Dibayar, faktur.Sisa, faktur.HariLewat, faktur.Kelompok,
+	}
+}
+
+// renderUmurPiutangCSV writes the aging report as CSV: one line per invoice
+// when drilling down, otherwise one line per group followed by the total.
+func renderUmurPiutangCSV(laporan dto.UmurPiutangResponse, detail bool) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	if detail {
+		if err := w.Write(umurPiutangFakturHeader); err != nil {
+			return nil, err
+		}
+		for _, faktur := range laporan.Fakturs {
+			var record []string
+			for _, value := range umurPiutangFakturValues(faktur) {
+				record = append(record, fmt.Sprint(value))
+			}
+			if err := w.Write(record); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		if err := w.Write(umurPiutangGroupHeader(laporan)); err != nil {
+			return nil, err
+		}
+
+		rows := append([]dto.UmurPiutangGroupResponse{}, laporan.Groups...)
+		rows = append(rows, dto.UmurPiutangGroupResponse{Nama: "TOTAL", UmurPiutangBucket: laporan.Total})
+		for _, row := range rows {
+			record := []string{row.Nama}
+			for _, value := range bucketValues(row.UmurPiutangBucket) {
+				record = append(record, strconv.Itoa(value))
+			}
+			if err := w.Write(record); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// renderUmurPiutangXLSX writes the aging report as a workbook with the group
+// summary on the first sheet and the invoices behind it on the second.
+func renderUmurPiutangXLSX(laporan dto.UmurPiutangResponse) ([]byte, error) {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	ringkasan := "Ringkasan"
+	if err := f.SetSheetName("Sheet1", ringkasan); err != nil {
+		return nil, err
+	}
+
+	if err := f.SetSheetRow(ringkasan, "A1", &[]interface{}{"Umur Piutang per " + laporan.Tanggal}); err != nil {
+		return nil, err
+	}
+	header := umurPiutangGroupHeader(laporan)
+	if err := f.SetSheetRow(ringkasan, "A3", &header); err != nil {
+		return nil, err
+	}
+
+	rows := append([]dto.UmurPiutangGroupResponse{}, laporan.Groups...)
+	rows = append(rows, dto.UmurPiutangGroupResponse{Nama: "TOTAL", UmurPiutangBucket: laporan.Total})
+	for i, row := range rows {
+		values := []interface{}{row.Nama}
+		for _, value := range bucketValues(row.UmurPiutangBucket) {
+			values = append(values, value)
+		}
+		cell, err := excelize.CoordinatesToCellName(1, i+4)
+		if err != nil {
+			return nil, err
+		}
+		if err := f.SetSheetRow(ringkasan, cell, &values); err != nil {
+			return nil, err
+		}
+	}
+
+	detail := "Faktur"
+	if _, err := f.NewSheet(detail); err != nil {
+		return nil, err
+	}
+	if err := f.SetSheetRow(detail, "A1", &umurPiutangFakturHeader); err != nil {
+		return nil, err
+	}
+	for i, faktur := range laporan.Fakturs {
+		values := umurPiutangFakturValues(faktur)
+		cell, err := excelize.CoordinatesToCellName(1, i+2)
+		if err != nil {
+			return nil, err
+		}
+		if err := f.SetSheetRow(detail, cell, &values); err != nil {
+			return nil, err
+		}
+	}
+
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// eFakturHeader is the three line header of the faktur keluaran import file
+// of the DJP e-Faktur application.
+var eFakturHeader = [][]string{
+	{"FK", "KD_JENIS_TRANSAKSI", "FG_PENGGANTI", "NOMOR_FAKTUR", "MASA_PAJAK", "TAHUN_PAJAK", "TANGGAL_FAKTUR", "NPWP", "NAMA", "ALAMAT_LENGKAP", "JUMLAH_DPP", "JUMLAH_PPN", "JUMLAH_PPNBM", "ID_KETERANGAN_TAMBAHAN", "FG_UANG_MUKA", "UANG_MUKA_DPP", "UANG_MUKA_PPN", "UANG_MUKA_PPNBM", "REFERENSI", "KODE_DOKUMEN_PENDUKUNG"},
+	{"LT", "NPWP", "NAMA", "JALAN", "BLOK", "NOMOR", "RT", "RW", "KECAMATAN", "KELURAHAN", "KABUPATEN", "PROPINSI", "KODE_POS", "NOMOR_TELEPON"},
+	{"OF", "KODE_OBJEK", "NAMA", "HARGA_SATUAN", "JUMLAH_BARANG", "HARGA_TOTAL", "DISKON", "DPP", "PPN", "TARIF_PPNBM", "PPNBM"},
+}
+
+// renderEFakturCSV writes the fakturs as an e-Faktur import file: an FK line
+// per faktur followed by an OF line per item. Retail invoices are reported
+// in total and are not part of the file. NOMOR_FAKTUR stays empty, the tax
+// invoice number is assigned from the NSFP range inside e-Faktur.
+func renderEFakturCSV(fakturs []entity.Faktur) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	if err := w.WriteAll(eFakturHeader); err != nil {
+		return nil, err
+	}
+
+	for _, faktur := range fakturs {
+		if faktur.Eceran {
+			continue
+		}
+
+		tanggal := ""
+		masa, tahun := "", ""
+		if faktur.TanggalFaktur != nil {
+			tanggal = faktur.TanggalFaktur.Format("02/01/2006")
+			masa = strconv.Itoa(int(faktur.TanggalFaktur.Month()))
+			tahun = strconv.Itoa(faktur.TanggalFaktur.Year())
+		}
+
+		npwp := helpers.NormalisasiNpwp(faktur.Customer.Npwp)
+		if npwp == "" {
+			npwp = "000000000000000"
+		}
+
+		if err := w.Write([]string{
+			"FK", "01", "0", "", masa, tahun, tanggal, npwp,
+			faktur.Customer.NamaToko, faktur.Customer.Alamat,
+			strconv.Itoa(faktur.Dpp), strconv.Itoa(faktur.Ppn), "0",
+			"", "0", "0", "0", "0", faktur.NoFaktur, "",
+		}); err != nil {
+			return nil, err
+		}
+
+		for _, item := range faktur.Items {
+			hargaSatuan := float64(item.Dpp)
+			if item.Jumlah > 0 {
+				hargaSatuan = float64(item.Dpp) / float64(item.Jumlah)
+			}
+
+			if err := w.Write([]string{
+				"OF", item.Barang.KodeBarang, item.Barang.NamaBarang,
+				strconv.FormatFloat(hargaSatuan, 'f', 2, 64), strconv.Itoa(item.Jumlah),
+				strconv.Itoa(item.Dpp), "0", strconv.Itoa(item.Dpp), strconv.Itoa(item.Ppn), "0", "0",
+			}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,298 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jejevj/ykp_pos/constants"
+	"github.com/jejevj/ykp_pos/dto"
+	"github.com/jejevj/ykp_pos/entity"
+	"github.com/jejevj/ykp_pos/repository"
+)
+
+type (
+	LaporanService interface {
+		GetUmurPiutang(ctx context.Context, req dto.UmurPiutangRequest) (dto.UmurPiutangResponse, error)
+		ExportUmurPiutang(ctx context.Context, req dto.UmurPiutangRequest) ([]byte, error)
+		GetRekapPpn(ctx context.Context, req dto.RekapPpnRequest) (dto.RekapPpnResponse, error)
+		ExportEFaktur(ctx context.Context, req dto.RekapPpnRequest) ([]byte, error)
+	}
+	laporanService struct {
+		laporanRepo repository.LaporanRepository
+		jwtService  JWTService
+	}
+)
+
+func NewLaporanService(laporanRepo repository.LaporanRepository, jwtService JWTService) LaporanService {
+	return &laporanService{
+		laporanRepo: laporanRepo,
+		jwtService:  jwtService,
+	}
+}
+
+// kelompokUmur names the aging bucket for a balance that is hariLewat days
+// past its due date.
+func kelompokUmur(hariLewat int) string {
+	switch {
+	case hariLewat <= 0:
+		return constants.ENUM_UMUR_CURRENT
+	case hariLewat <= 30:
+		return constants.ENUM_UMUR_1_30
+	case hariLewat <= 60:
+		return constants.ENUM_UMUR_31_60
+	case hariLewat <= 90:
+		return constants.ENUM_UMUR_61_90
+	default:
+		return constants.ENUM_UMUR_OVER_90
+	}
+}
+
+func tambahBucket(bucket *dto.UmurPiutangBucket, kelompok string, jumlah int) {
+	switch kelompok {
+	case constants.ENUM_UMUR_CURRENT:
+		bucket.Current += jumlah
+	case constants.ENUM_UMUR_1_30:
+		bucket.Hari1To30 += jumlah
+	case constants.ENUM_UMUR_31_60:
+		bucket.Hari31To60 += jumlah
+	case constants.ENUM_UMUR_61_90:
+		bucket.Hari61To90 += jumlah
+	default:
+		bucket.HariOver90 += jumlah
+	}
+	bucket.Total += jumlah
+}
+
+// hariLewat counts the calendar days between the due date and the report
+// date. A faktur without tanggal tempo is due on its own date.
+func hariLewat(faktur entity.Faktur, tanggal time.Time) int {
+	jatuhTempo := faktur.TanggalTempo
+	if jatuhTempo == nil {
+		jatuhTempo = faktur.TanggalFaktur
+	}
+	if jatuhTempo == nil {
+		return 0
+	}
+
+	dari := time.Date(jatuhTempo.Year(), jatuhTempo.Month(), jatuhTempo.Day(), 0, 0, 0, 0, time.UTC)
+	sampai := time.Date(tanggal.Year(), tanggal.Month(), tanggal.Day(), 0, 0, 0, 0, time.UTC)
+	return int(sampai.Sub(dari).Hours() / 24)
+}
+
+// hitungUmurPiutang buckets the open balance of every faktur as of tanggal
+// and totals them per customer or per sales user.
+func hitungUmurPiutang(piutang dto.GetPiutangRepositoryResponse, tanggal time.Time, group string) dto.UmurPiutangResponse {
+	laporan := dto.UmurPiutangResponse{
+		Tanggal: tanggal.Format(constants.ENUM_DATE_FORMAT),
+		Group:   group,
+		Groups:  []dto.UmurPiutangGroupResponse{},
+		Fakturs: []dto.UmurPiutangFakturResponse{},
+	}
+
+	groups := map[string]*dto.UmurPiutangGroupResponse{}
+	for _, faktur := range piutang.Fakturs {
+		dibayar := piutang.Dibayar[faktur.ID.String()]
+		sisa := faktur.Total - dibayar
+		if sisa <= 0 {
+			continue
+		}
+
+		hari := hariLewat(faktur, tanggal)
+		kelompok := kelompokUmur(hari)
+
+		id, nama := faktur.IdCustomer, faktur.Customer.NamaToko
+		if group == constants.ENUM_LAPORAN_GROUP_SALES {
+			id, nama = faktur.IdUser, faktur.Driver.Name
+		}
+		row, ok := groups[id]
+		if !ok {
+			row = &dto.UmurPiutangGroupResponse{ID: id, Nama: nama}
+			groups[id] = row
+		}
+		tambahBucket(&row.UmurPiutangBucket, kelompok, sisa)
+		tambahBucket(&laporan.Total, kelompok, sisa)
+
+		laporan.Fakturs = append(laporan.Fakturs, dto.UmurPiutangFakturResponse{
+			IdFaktur:      faktur.ID.String(),
+			NoFaktur:      faktur.NoFaktur,
+			TanggalFaktur: formatTanggal(faktur.TanggalFaktur),
+			TanggalTempo:  formatTanggal(faktur.TanggalTempo),
+			IdCustomer:    faktur.IdCustomer,
+			NamaCustomer:  faktur.Customer.NamaToko,
+			IdUser:        faktur.IdUser,
+			NamaSales:     faktur.Driver.Name,
+			Total:         faktur.Total,
+			Dibayar:       dibayar,
+			Sisa:          sisa,
+			HariLewat:     hari,
+			Kelompok:      kelompok,
+		})
+	}
+
+	for _, row := range groups {
+		laporan.Groups = append(laporan.Groups, *row)
+	}
+	sort.Slice(laporan.Groups, func(i, j int) bool {
+		return laporan.Groups[i].Nama < laporan.Groups[j].Nama
+	})
+
+	return laporan
+}
+
+func (s *laporanService) getUmurPiutang(ctx context.Context, req dto.UmurPiutangRequest) (dto.UmurPiutangResponse, error) {
+	if req.Group == "" {
+		req.Group = constants.ENUM_LAPORAN_GROUP_CUSTOMER
+	}
+	if req.Group != constants.ENUM_LAPORAN_GROUP_CUSTOMER && req.Group != constants.ENUM_LAPORAN_GROUP_SALES {
+		return dto.UmurPiutangResponse{}, dto.ErrInvalidGroupLaporan
+	}
+
+	tanggal, err := parseTanggal(req.Tanggal)
+	if err != nil {
+		return dto.UmurPiutangResponse{}, err
+	}
+	if tanggal == nil {
+		now := time.Now()
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+		tanggal = &today
+	}
+
+	piutang, err := s.laporanRepo.GetPiutang(ctx, *tanggal, req.IdCustomer, req.IdUser)
+	if err != nil {
+		return dto.UmurPiutangResponse{}, fmt.Errorf("%v: %v", dto.ErrGetLaporan, err)
+	}
+
+	return hitungUmurPiutang(piutang, *tanggal, req.Group), nil
+}
+
+func (s *laporanService) GetUmurPiutang(ctx context.Context, req dto.UmurPiutangRequest) (dto.UmurPiutangResponse, error) {
+	laporan, err := s.getUmurPiutang(ctx, req)
+	if err != nil {
+		return dto.UmurPiutangResponse{}, err
+	}
+
+	// The invoices are only listed when drilling down into one customer or
+	// one sales user
+	if req.IdCustomer == "" && req.IdUser == "" {
+		laporan.Fakturs = nil
+	}
+
+	return laporan, nil
+}
+
+func (s *laporanService) ExportUmurPiutang(ctx context.Context, req dto.UmurPiutangRequest) ([]byte, error) {
+	laporan, err := s.getUmurPiutang(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	switch req.Format {
+	case constants.ENUM_EXPORT_CSV:
+		data, err = renderUmurPiutangCSV(laporan, req.IdCustomer != "" || req.IdUser != "")
+	case constants.ENUM_EXPORT_XLSX:
+		data, err = renderUmurPiutangXLSX(laporan)
+	default:
+		return nil, dto.ErrInvalidFormatLaporan
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", dto.ErrExportLaporan, err)
+	}
+
+	return data, nil
+}
+
+// parseBulan reads a YYYY-MM tax period and returns its first day and the
+// first day of the next month. The current month is used when it is empty.
+func parseBulan(bulan string) (time.Time, time.Time, error) {
+	if bulan == "" {
+		now := time.Now()
+		mulai := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+		return mulai, mulai.AddDate(0, 1, 0), nil
+	}
+
+	mulai, err := time.ParseInLocation("2006-01", bulan, time.Local)
+	if err != nil {
+		return time.Time{}, time.Time{}, dto.ErrInvalidBulan
+	}
+
+	return mulai, mulai.AddDate(0, 1, 0), nil
+}
+
+func tambahRekapPpn(total *dto.RekapPpnTotal, faktur entity.Faktur) {
+	total.Jumlah++
+	total.Dpp += faktur.Dpp
+	total.Ppn += faktur.Ppn
+}
+
+// hitungRekapPpn sums the PPN of the fakturs of a month, keeping retail tax
+// invoices apart from those to customers with an NPWP.
+func hitungRekapPpn(mulai time.Time, fakturs []entity.Faktur) dto.RekapPpnResponse {
+	rekap := dto.RekapPpnResponse{
+		Bulan:   mulai.Format("2006-01"),
+		Fakturs: []dto.RekapPpnFakturResponse{},
+	}
+
+	for _, faktur := range fakturs {
+		if faktur.Eceran {
+			tambahRekapPpn(&rekap.Eceran, faktur)
+		} else {
+			tambahRekapPpn(&rekap.FakturNpwp, faktur)
+		}
+		tambahRekapPpn(&rekap.Total, faktur)
+
+		rekap.Fakturs = append(rekap.Fakturs, dto.RekapPpnFakturResponse{
+			IdFaktur:      faktur.ID.String(),
+			NoFaktur:      faktur.NoFaktur,
+			TanggalFaktur: formatTanggal(faktur.TanggalFaktur),
+			IdCustomer:    faktur.IdCustomer,
+			NamaCustomer:  faktur.Customer.NamaToko,
+			Npwp:          faktur.Customer.Npwp,
+			Eceran:        faktur.Eceran,
+			Dpp:           faktur.Dpp,
+			Ppn:           faktur.Ppn,
+			Total:         faktur.Total,
+		})
+	}
+
+	return rekap
+}
+
+func (s *laporanService) GetRekapPpn(ctx context.Context, req dto.RekapPpnRequest) (dto.RekapPpnResponse, error) {
+	mulai, akhir, err := parseBulan(req.Bulan)
+	if err != nil {
+		return dto.RekapPpnResponse{}, err
+	}
+
+	fakturs, err := s.laporanRepo.GetFakturPajak(ctx, mulai, akhir)
+	if err != nil {
+		return dto.RekapPpnResponse{}, fmt.Errorf("%v: %v", dto.ErrGetLaporan, err)
+	}
+
+	return hitungRekapPpn(mulai, fakturs), nil
+}
+
+func (s *laporanService) ExportEFaktur(ctx context.Context, req dto.RekapPpnRequest) ([]byte, error) {
+	if req.Format != constants.ENUM_EXPORT_CSV {
+		return nil, dto.ErrInvalidFormatLaporan
+	}
+
+	mulai, akhir, err := parseBulan(req.Bulan)
+	if err != nil {
+		return nil, err
+	}
+
+	fakturs, err := s.laporanRepo.GetFakturPajak(ctx, mulai, akhir)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", dto.ErrGetLaporan, err)
+	}
+
+	data, err := renderEFakturCSV(fakturs)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", dto.ErrExportLaporan, err)
+	}
+
+	return data, nil
+}
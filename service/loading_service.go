@@ -3,8 +3,10 @@ package service
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jejevj/ykp_pos/constants"
 	"github.com/jejevj/ykp_pos/dto"
 	"github.com/jejevj/ykp_pos/entity"
 	"github.com/jejevj/ykp_pos/repository"
@@ -12,11 +14,12 @@ import (
 
 type (
 	LoadingService interface {
-		AddLoading(ctx context.Context, req dto.LoadingCreateRequest) (dto.LoadingResponse, error)
-		GetAllLoadingWithPagination(ctx context.Context) (dto.LoadingPaginationResponse, error)
-		GetLoadingById(ctx context.Context, loadingId string) (dto.LoadingResponse, error)
-		UpdateLoading(ctx context.Context, req dto.LoadingUpdateRequest, loadingId string) (dto.LoadingUpdateResponse, error)
-		DeleteLoading(ctx context.Context, loadingId string) error
+		AddLoading(ctx context.Context, req dto.LoadingCreateRequest, ownerId string) (dto.LoadingResponse, error)
+		GetAllLoadingWithPagination(ctx context.Context, req dto.LoadingFilterRequest) (dto.LoadingPaginationResponse, error)
+		GetLoadingById(ctx context.Context, loadingId string, ownerId string) (dto.LoadingResponse, error)
+		UpdateLoading(ctx context.Context, req dto.LoadingUpdateRequest, loadingId string, ownerId string) (dto.LoadingUpdateResponse, error)
+		TransitionLoading(ctx context.Context, loadingId string, aksi string, userId string, ownerId string) (dto.LoadingResponse, error)
+		DeleteLoading(ctx context.Context, loadingId string, userId string, ownerId string) error
 	}
 	loadingService struct {
 		loadingRepo repository.LoadingRepository
@@ -24,63 +27,112 @@ type (
 	}
 )
 
+// Actions that move a loading through its lifecycle.
+const (
+	LoadingSubmit    = "submit"
+	LoadingApprove   = "approve"
+	LoadingUnapprove = "unapprove"
+	LoadingDispatch  = "dispatch"
+	LoadingReturn    = "return"
+)
+
+// loadingTransitions lists, per action, the status a loading must be in and
+// the status it ends up in. A returned loading is settled by creating its
+// setoran, not through a plain transition.
+var loadingTransitions = map[string][2]string{
+	LoadingSubmit:    {constants.ENUM_LOADING_DRAFT, constants.ENUM_LOADING_SUBMITTED},
+	LoadingApprove:   {constants.ENUM_LOADING_SUBMITTED, constants.ENUM_LOADING_APPROVED},
+	LoadingUnapprove: {constants.ENUM_LOADING_APPROVED, constants.ENUM_LOADING_SUBMITTED},
+	LoadingDispatch:  {constants.ENUM_LOADING_APPROVED, constants.ENUM_LOADING_DISPATCHED},
+	LoadingReturn:    {constants.ENUM_LOADING_DISPATCHED, constants.ENUM_LOADING_RETURNED},
+}
+
 func NewLoadingService(loadingRepo repository.LoadingRepository, jwtService JWTService) LoadingService {
 	return &loadingService{
 		loadingRepo: loadingRepo,
 		jwtService:  jwtService,
 	}
 }
-func (s *loadingService) AddLoading(ctx context.Context, req dto.LoadingCreateRequest) (dto.LoadingResponse, error) {
-	mu.Lock()
-	defer mu.Unlock()
+
+// checkLoadingOwner rejects a loading of another user when the caller may only
+// work on their own records (ownerId is empty for roles that see everything).
+func checkLoadingOwner(loading entity.Loading, ownerId string) error {
+	if ownerId != "" && loading.IdUser != ownerId {
+		return dto.ErrLoadingNotOwned
+	}
+
+	return nil
+}
+
+func formatWaktu(value *time.Time) string {
+	if value == nil {
+		return ""
+	}
+
+	return value.Format(time.RFC3339)
+}
+
+func toLoadingStatusResponse(loading entity.Loading) dto.LoadingStatusResponse {
+	return dto.LoadingStatusResponse{
+		SubmittedAt:  formatWaktu(loading.SubmittedAt),
+		SubmittedBy:  loading.SubmittedBy,
+		ApprovedAt:   formatWaktu(loading.ApprovedAt),
+		ApprovedBy:   loading.ApprovedBy,
+		DispatchedAt: formatWaktu(loading.DispatchedAt),
+		DispatchedBy: loading.DispatchedBy,
+		ReturnedAt:   formatWaktu(loading.ReturnedAt),
+		ReturnedBy:   loading.ReturnedBy,
+		SettledAt:    formatWaktu(loading.SettledAt),
+		SettledBy:    loading.SettledBy,
+	}
+}
+
+func toLoadingResponse(loading entity.Loading) dto.LoadingResponse {
+	return dto.LoadingResponse{
+		ID:        loading.ID.String(),
+		NoLoading: loading.NoLoading,
+		IdUser:    loading.IdUser,
+		User: dto.UserResponse{
+			ID:         loading.User.ID.String(),
+			Name:       loading.User.Name,
+			Email:      loading.User.Email,
+			TelpNumber: loading.User.TelpNumber,
+			Role:       loading.User.Role,
+			ImageUrl:   loading.User.ImageUrl,
+		},
+		Status:                loading.Status,
+		LoadingStatusResponse: toLoadingStatusResponse(loading),
+	}
+}
+
+func (s *loadingService) AddLoading(ctx context.Context, req dto.LoadingCreateRequest, ownerId string) (dto.LoadingResponse, error) {
+	// Sales users always load for themselves
+	if ownerId != "" {
+		req.IdUser = ownerId
+	}
 
 	loading := entity.Loading{
 		IdUser: req.IdUser,
+		Status: constants.ENUM_LOADING_DRAFT,
 	}
 
 	loadingAdd, err := s.loadingRepo.AddLoading(ctx, loading)
 	if err != nil {
 		return dto.LoadingResponse{}, dto.ErrCreateLoading
 	}
-	userResponse := dto.UserResponse{
-		ID:         loadingAdd.User.ID.String(),
-		Name:       loadingAdd.User.Name,
-		Email:      loadingAdd.User.Email,
-		TelpNumber: loadingAdd.User.TelpNumber,
-		Role:       loadingAdd.User.Role,
-		ImageUrl:   loadingAdd.User.ImageUrl,
-	}
 
-	return dto.LoadingResponse{
-		ID:         loadingAdd.ID.String(),
-		IdUser:     loadingAdd.IdUser,
-		User:       userResponse,
-		IsApproved: loadingAdd.IsApproved,
-	}, nil
+	return toLoadingResponse(loadingAdd), nil
 }
-func (s *loadingService) GetAllLoadingWithPagination(ctx context.Context) (dto.LoadingPaginationResponse, error) {
-	dataWithPaginate, err := s.loadingRepo.GetAllLoadingWithPagination(ctx)
+
+func (s *loadingService) GetAllLoadingWithPagination(ctx context.Context, req dto.LoadingFilterRequest) (dto.LoadingPaginationResponse, error) {
+	dataWithPaginate, err := s.loadingRepo.GetAllLoadingWithPagination(ctx, req)
 	if err != nil {
 		return dto.LoadingPaginationResponse{}, err
 	}
 
 	var datas []dto.LoadingResponse
 	for _, loading := range dataWithPaginate.Loadings {
-		data := dto.LoadingResponse{
-			ID:     loading.ID.String(),
-			IdUser: loading.IdUser,
-			User: dto.UserResponse{
-				ID:         loading.User.ID.String(),
-				Name:       loading.User.Name,
-				Email:      loading.User.Email,
-				TelpNumber: loading.User.TelpNumber,
-				Role:       loading.User.Role,
-				ImageUrl:   loading.User.ImageUrl,
-			},
-			IsApproved: loading.IsApproved,
-		}
-
-		datas = append(datas, data)
+		datas = append(datas, toLoadingResponse(loading))
 	}
 
 	return dto.LoadingPaginationResponse{
@@ -93,38 +145,47 @@ func (s *loadingService) GetAllLoadingWithPagination(ctx context.Context) (dto.L
 		},
 	}, nil
 }
-func (s *loadingService) GetLoadingById(ctx context.Context, loadingId string) (dto.LoadingResponse, error) {
+
+func (s *loadingService) GetLoadingById(ctx context.Context, loadingId string, ownerId string) (dto.LoadingResponse, error) {
 	loading, err := s.loadingRepo.GetLoadingById(ctx, loadingId)
 	if err != nil {
 		return dto.LoadingResponse{}, dto.ErrGetLoadingById
 	}
-	userResponse := dto.UserResponse{
-		ID:         loading.User.ID.String(),
-		Name:       loading.User.Name,
-		Email:      loading.User.Email,
-		TelpNumber: loading.User.TelpNumber,
-		ImageUrl:   loading.User.ImageUrl,
-		// Add other fields from Satuan entity if necessary
+	if err := checkLoadingOwner(loading, ownerId); err != nil {
+		return dto.LoadingResponse{}, err
 	}
 
-	return dto.LoadingResponse{
-		ID:         loading.ID.String(),
-		IdUser:     loading.IdUser,
-		User:       userResponse,
-		IsApproved: loading.IsApproved,
-	}, nil
+	return toLoadingResponse(loading), nil
 }
-func (s *loadingService) UpdateLoading(ctx context.Context, req dto.LoadingUpdateRequest, loadingId string) (dto.LoadingUpdateResponse, error) {
+
+func (s *loadingService) UpdateLoading(ctx context.Context, req dto.LoadingUpdateRequest, loadingId string, ownerId string) (dto.LoadingUpdateResponse, error) {
 	// Convert string ID to uuid.UUID (if needed)
 	id, err := uuid.Parse(loadingId)
 	if err != nil {
 		return dto.LoadingUpdateResponse{}, fmt.Errorf("invalid ID format: %v", err)
 	}
 
+	existingLoading, err := s.loadingRepo.GetLoadingById(ctx, loadingId)
+	if err != nil {
+		return dto.LoadingUpdateResponse{}, dto.ErrLoadingNotFound
+	}
+	if err := checkLoadingOwner(existingLoading, ownerId); err != nil {
+		return dto.LoadingUpdateResponse{}, err
+	}
+	// A sales user cannot hand the loading over to someone else
+	if ownerId != "" {
+		req.IdUser = ownerId
+	}
+
+	// The driver can only change before the loading is approved
+	if existingLoading.Status != constants.ENUM_LOADING_DRAFT && existingLoading.Status != constants.ENUM_LOADING_SUBMITTED {
+		return dto.LoadingUpdateResponse{}, dto.ErrLoadingNotEditable
+	}
+
 	// Prepare the entity to be updated
 	data := entity.Loading{
-		ID:         id,
-		IsApproved: req.IsApproved,
+		ID:     id,
+		IdUser: req.IdUser,
 	}
 
 	// Call the repository to update
@@ -133,22 +194,62 @@ func (s *loadingService) UpdateLoading(ctx context.Context, req dto.LoadingUpdat
 		return dto.LoadingUpdateResponse{}, fmt.Errorf("failed to update Loading: %v", err)
 	}
 
+	res := toLoadingResponse(loadingUpdate)
 	return dto.LoadingUpdateResponse{
-		ID:         loadingUpdate.ID.String(),
-		IdUser:     loadingUpdate.IdUser,
-		IsApproved: loadingUpdate.IsApproved,
+		ID:                    res.ID,
+		NoLoading:             res.NoLoading,
+		IdUser:                res.IdUser,
+		User:                  res.User,
+		Status:                res.Status,
+		LoadingStatusResponse: res.LoadingStatusResponse,
 	}, nil
 }
 
-func (s *loadingService) DeleteLoading(ctx context.Context, loadingId string) error {
+func (s *loadingService) TransitionLoading(ctx context.Context, loadingId string, aksi string, userId string, ownerId string) (dto.LoadingResponse, error) {
+	transition, ok := loadingTransitions[aksi]
+	if !ok {
+		return dto.LoadingResponse{}, fmt.Errorf("%w: unknown action %s", dto.ErrLoadingInvalidTransition, aksi)
+	}
+
+	loading, err := s.loadingRepo.GetLoadingById(ctx, loadingId)
+	if err != nil {
+		return dto.LoadingResponse{}, dto.ErrLoadingNotFound
+	}
+	if err := checkLoadingOwner(loading, ownerId); err != nil {
+		return dto.LoadingResponse{}, err
+	}
+
+	if loading.Status != transition[0] {
+		return dto.LoadingResponse{}, fmt.Errorf("%w: cannot %s a loading with status %s", dto.ErrLoadingInvalidTransition, aksi, loading.Status)
+	}
+
+	loadingUpdate, err := s.loadingRepo.UpdateStatusLoading(ctx, loadingId, transition[0], transition[1], userId)
+	if err != nil {
+		return dto.LoadingResponse{}, fmt.Errorf("%v: %w", dto.ErrUpdateLoading, err)
+	}
+
+	return toLoadingResponse(loadingUpdate), nil
+}
+
+func (s *loadingService) DeleteLoading(ctx context.Context, loadingId string, userId string, ownerId string) error {
 	loading, err := s.loadingRepo.GetLoadingById(ctx, loadingId)
 	if err != nil {
 		return dto.ErrLoadingNotFound
 	}
+	if err := checkLoadingOwner(loading, ownerId); err != nil {
+		return err
+	}
+
+	// Once the truck has left the loading is part of the sales history
+	switch loading.Status {
+	case constants.ENUM_LOADING_DRAFT, constants.ENUM_LOADING_SUBMITTED, constants.ENUM_LOADING_APPROVED:
+	default:
+		return dto.ErrLoadingNotEditable
+	}
 
-	err = s.loadingRepo.DeleteLoading(ctx, loading.ID.String())
+	err = s.loadingRepo.DeleteLoading(ctx, loading.ID.String(), userId)
 	if err != nil {
-		return dto.ErrDeleteLoading
+		return fmt.Errorf("%v: %v", dto.ErrDeleteLoading, err)
 	}
 
 	return nil
@@ -4,10 +4,13 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/google/uuid"
+	"github.com/jejevj/ykp_pos/constants"
 	"github.com/jejevj/ykp_pos/dto"
 	"github.com/jejevj/ykp_pos/entity"
+	"github.com/jejevj/ykp_pos/helpers"
 	"github.com/jejevj/ykp_pos/repository"
 	"github.com/jejevj/ykp_pos/utils"
 )
@@ -15,10 +18,13 @@ import (
 type (
 	MainSettingService interface {
 		AddMainSetting(ctx context.Context, req dto.MainSettingCreateRequest) (dto.MainSettingResponse, error)
-		GetAllMainSettingWithPagination(ctx context.Context) (dto.MainSettingPaginationResponse, error)
+		GetAllMainSettingWithPagination(ctx context.Context, req dto.PaginationRequest) (dto.MainSettingPaginationResponse, error)
 		GetMainSettingById(ctx context.Context, mainSettingId string) (dto.MainSettingResponse, error)
 		UpdateMainSetting(ctx context.Context, req dto.MainSettingUpdateRequest, mainSettingId string) (dto.MainSettingUpdateResponse, error)
 		DeleteMainSetting(ctx context.Context, mainSettingId string) error
+		UpdatePajakMainSetting(ctx context.Context, req dto.MainSettingPajakRequest) (dto.MainSettingResponse, error)
+		UpdateQrisMainSetting(ctx context.Context, req dto.MainSettingQrisRequest) (dto.MainSettingResponse, error)
+		BuatQris(ctx context.Context, req dto.QrisRequest) (dto.QrisResponse, error)
 	}
 	mainSettingService struct {
 		mainSettingRepo repository.MainSettingRepository
@@ -32,9 +38,87 @@ func NewMainSettingService(mainSettingRepo repository.MainSettingRepository, jwt
 		jwtService:      jwtService,
 	}
 }
+func toNomorDokumenSetting(mainSetting entity.MainSetting) dto.NomorDokumenSetting {
+	return dto.NomorDokumenSetting{
+		PrefixFaktur:     mainSetting.PrefixFaktur,
+		PrefixLoading:    mainSetting.PrefixLoading,
+		PrefixRetur:      mainSetting.PrefixRetur,
+		PrefixNotaKredit: mainSetting.PrefixNotaKredit,
+		PrefixPenjualan:  mainSetting.PrefixPenjualan,
+		PrefixPo:         mainSetting.PrefixPo,
+		PrefixPenerimaan: mainSetting.PrefixPenerimaan,
+		FormatNomor:      mainSetting.FormatNomor,
+		PanjangNomor:     mainSetting.PanjangNomor,
+		ResetNomor:       mainSetting.ResetNomor,
+	}
+}
+
+func toPajakSetting(mainSetting entity.MainSetting) dto.PajakSetting {
+	return dto.PajakSetting{
+		Npwp:             mainSetting.Npwp,
+		TarifPpn:         mainSetting.TarifPpn,
+		HargaTermasukPpn: mainSetting.HargaTermasukPpn,
+		UrutanDiskon:     mainSetting.UrutanDiskon,
+	}
+}
+
+func toQrisSetting(mainSetting entity.MainSetting) dto.QrisSetting {
+	return dto.QrisSetting{
+		QrisStatis: mainSetting.QrisStatis,
+	}
+}
+
+// validQrisStatis accepts an empty payload or a well formed QRIS.
+func validQrisStatis(payload string) error {
+	if payload == "" {
+		return nil
+	}
+
+	return helpers.CekQris(payload)
+}
+
+func validTarifPpn(tarif float64) bool {
+	return tarif >= 0 && tarif < 100
+}
+
+func validUrutanDiskon(urutan string) bool {
+	switch urutan {
+	case "", constants.ENUM_URUTAN_DISKON_PERSEN_NOMINAL, constants.ENUM_URUTAN_DISKON_NOMINAL_PERSEN:
+		return true
+	}
+
+	return false
+}
+
+func validResetNomor(reset string) bool {
+	switch reset {
+	case "", constants.ENUM_RESET_NOMOR_BULANAN, constants.ENUM_RESET_NOMOR_TAHUNAN, constants.ENUM_RESET_NOMOR_TIDAK:
+		return true
+	}
+
+	return false
+}
+
 func (s *mainSettingService) AddMainSetting(ctx context.Context, req dto.MainSettingCreateRequest) (dto.MainSettingResponse, error) {
 	var filename string
 
+	if !validResetNomor(req.ResetNomor) {
+		return dto.MainSettingResponse{}, dto.ErrInvalidResetNomor
+	}
+	if !helpers.ValidFormatNomor(req.FormatNomor, req.ResetNomor) {
+		return dto.MainSettingResponse{}, dto.ErrInvalidFormatNomor
+	}
+	if !validTarifPpn(req.TarifPpn) {
+		return dto.MainSettingResponse{}, dto.ErrInvalidTarifPpn
+	}
+	if !validUrutanDiskon(req.UrutanDiskon) {
+		return dto.MainSettingResponse{}, dto.ErrInvalidUrutanDiskon
+	}
+	req.QrisStatis = strings.TrimSpace(req.QrisStatis)
+	if err := validQrisStatis(req.QrisStatis); err != nil {
+		return dto.MainSettingResponse{}, err
+	}
+
 	fmt.Printf("AddMainSetting called with request: %+v\n", req)
 
 	if req.Logo != nil {
@@ -65,6 +149,24 @@ func (s *mainSettingService) AddMainSetting(ctx context.Context, req dto.MainSet
 		Alamat:     req.Alamat,
 		LogoUrl:    filename, // Save the generated logo URL in the entity
 		Hp:         req.Hp,
+
+		PrefixFaktur:     req.PrefixFaktur,
+		PrefixLoading:    req.PrefixLoading,
+		PrefixRetur:      req.PrefixRetur,
+		PrefixNotaKredit: req.PrefixNotaKredit,
+		PrefixPenjualan:  req.PrefixPenjualan,
+		PrefixPo:         req.PrefixPo,
+		PrefixPenerimaan: req.PrefixPenerimaan,
+		FormatNomor:      req.FormatNomor,
+		PanjangNomor:     req.PanjangNomor,
+		ResetNomor:       req.ResetNomor,
+
+		Npwp:             req.Npwp,
+		TarifPpn:         req.TarifPpn,
+		HargaTermasukPpn: req.HargaTermasukPpn,
+		UrutanDiskon:     req.UrutanDiskon,
+
+		QrisStatis: req.QrisStatis,
 	}
 
 	fmt.Printf("MainSetting entity to be saved: %+v\n", mainSetting)
@@ -84,11 +186,15 @@ func (s *mainSettingService) AddMainSetting(ctx context.Context, req dto.MainSet
 		Alamat:     mainSettingAdd.Alamat,
 		LogoUrl:    mainSettingAdd.LogoUrl,
 		Hp:         mainSettingAdd.Hp,
+
+		NomorDokumenSetting: toNomorDokumenSetting(mainSettingAdd),
+		PajakSetting:        toPajakSetting(mainSettingAdd),
+		QrisSetting:         toQrisSetting(mainSettingAdd),
 	}, nil
 }
 
-func (s *mainSettingService) GetAllMainSettingWithPagination(ctx context.Context) (dto.MainSettingPaginationResponse, error) {
-	dataWithPaginate, err := s.mainSettingRepo.GetAllMainSettingWithPagination(ctx)
+func (s *mainSettingService) GetAllMainSettingWithPagination(ctx context.Context, req dto.PaginationRequest) (dto.MainSettingPaginationResponse, error) {
+	dataWithPaginate, err := s.mainSettingRepo.GetAllMainSettingWithPagination(ctx, req)
 	if err != nil {
 		return dto.MainSettingPaginationResponse{}, err
 	}
@@ -102,6 +208,10 @@ func (s *mainSettingService) GetAllMainSettingWithPagination(ctx context.Context
 			Alamat:     mainSetting.Alamat,
 			LogoUrl:    mainSetting.LogoUrl,
 			Hp:         mainSetting.Hp,
+
+			NomorDokumenSetting: toNomorDokumenSetting(mainSetting),
+			PajakSetting:        toPajakSetting(mainSetting),
+			QrisSetting:         toQrisSetting(mainSetting),
 		}
 
 		datas = append(datas, data)
@@ -130,6 +240,10 @@ func (s *mainSettingService) GetMainSettingById(ctx context.Context, mainSetting
 		Alamat:     mainSetting.Alamat,
 		LogoUrl:    mainSetting.LogoUrl,
 		Hp:         mainSetting.Hp,
+
+		NomorDokumenSetting: toNomorDokumenSetting(mainSetting),
+		PajakSetting:        toPajakSetting(mainSetting),
+		QrisSetting:         toQrisSetting(mainSetting),
 	}, nil
 }
 
@@ -140,6 +254,10 @@ func (s *mainSettingService) UpdateMainSetting(ctx context.Context, req dto.Main
 		return dto.MainSettingUpdateResponse{}, fmt.Errorf("invalid ID format: %v", err)
 	}
 
+	if !validResetNomor(req.ResetNomor) {
+		return dto.MainSettingUpdateResponse{}, dto.ErrInvalidResetNomor
+	}
+
 	var filename string
 	var existingLogoUrl string
 
@@ -149,6 +267,18 @@ func (s *mainSettingService) UpdateMainSetting(ctx context.Context, req dto.Main
 		return dto.MainSettingUpdateResponse{}, fmt.Errorf("failed to retrieve existing MainSetting: %v", err)
 	}
 
+	// Fields left empty keep their current value, so check the pair that will be in force
+	formatNomor, resetNomor := req.FormatNomor, req.ResetNomor
+	if formatNomor == "" {
+		formatNomor = existingMainSetting.FormatNomor
+	}
+	if resetNomor == "" {
+		resetNomor = existingMainSetting.ResetNomor
+	}
+	if !helpers.ValidFormatNomor(formatNomor, resetNomor) {
+		return dto.MainSettingUpdateResponse{}, dto.ErrInvalidFormatNomor
+	}
+
 	// Retain the existing LogoUrl if no new logo is uploaded
 	existingLogoUrl = existingMainSetting.LogoUrl
 
@@ -178,6 +308,17 @@ func (s *mainSettingService) UpdateMainSetting(ctx context.Context, req dto.Main
 		Alamat:     req.Alamat,
 		LogoUrl:    filename, // Set the updated logo URL (or existing logo if not updated)
 		Hp:         req.Hp,
+
+		PrefixFaktur:     req.PrefixFaktur,
+		PrefixLoading:    req.PrefixLoading,
+		PrefixRetur:      req.PrefixRetur,
+		PrefixNotaKredit: req.PrefixNotaKredit,
+		PrefixPenjualan:  req.PrefixPenjualan,
+		PrefixPo:         req.PrefixPo,
+		PrefixPenerimaan: req.PrefixPenerimaan,
+		FormatNomor:      req.FormatNomor,
+		PanjangNomor:     req.PanjangNomor,
+		ResetNomor:       req.ResetNomor,
 	}
 
 	// Call the repository to update the main setting
@@ -194,6 +335,10 @@ func (s *mainSettingService) UpdateMainSetting(ctx context.Context, req dto.Main
 		Alamat:     mainSettingUpdate.Alamat,
 		Logo:       mainSettingUpdate.LogoUrl,
 		Hp:         mainSettingUpdate.Hp,
+
+		NomorDokumenSetting: toNomorDokumenSetting(mainSettingUpdate),
+		PajakSetting:        toPajakSetting(mainSettingUpdate),
+		QrisSetting:         toQrisSetting(mainSettingUpdate),
 	}, nil
 }
 
@@ -210,3 +355,95 @@ func (s *mainSettingService) DeleteMainSetting(ctx context.Context, mainSettingI
 
 	return nil
 }
+
+func (s *mainSettingService) UpdatePajakMainSetting(ctx context.Context, req dto.MainSettingPajakRequest) (dto.MainSettingResponse, error) {
+	id, err := uuid.Parse(req.ID)
+	if err != nil {
+		return dto.MainSettingResponse{}, fmt.Errorf("invalid ID format: %v", err)
+	}
+	if !validTarifPpn(req.TarifPpn) {
+		return dto.MainSettingResponse{}, dto.ErrInvalidTarifPpn
+	}
+	if !validUrutanDiskon(req.UrutanDiskon) {
+		return dto.MainSettingResponse{}, dto.ErrInvalidUrutanDiskon
+	}
+	if req.UrutanDiskon == "" {
+		req.UrutanDiskon = constants.ENUM_URUTAN_DISKON_PERSEN_NOMINAL
+	}
+
+	mainSetting, err := s.mainSettingRepo.UpdatePajakMainSetting(ctx, entity.MainSetting{
+		ID:               id,
+		Npwp:             req.Npwp,
+		TarifPpn:         req.TarifPpn,
+		HargaTermasukPpn: req.HargaTermasukPpn,
+		UrutanDiskon:     req.UrutanDiskon,
+	})
+	if err != nil {
+		return dto.MainSettingResponse{}, fmt.Errorf("%v: %v", dto.ErrUpdateMainSetting, err)
+	}
+
+	return dto.MainSettingResponse{
+		ID:         mainSetting.ID.String(),
+		NamaUsaha:  mainSetting.NamaUsaha,
+		JenisUsaha: mainSetting.JenisUsaha,
+		Alamat:     mainSetting.Alamat,
+		LogoUrl:    mainSetting.LogoUrl,
+		Hp:         mainSetting.Hp,
+
+		NomorDokumenSetting: toNomorDokumenSetting(mainSetting),
+		PajakSetting:        toPajakSetting(mainSetting),
+		QrisSetting:         toQrisSetting(mainSetting),
+	}, nil
+}
+
+func (s *mainSettingService) UpdateQrisMainSetting(ctx context.Context, req dto.MainSettingQrisRequest) (dto.MainSettingResponse, error) {
+	id, err := uuid.Parse(req.ID)
+	if err != nil {
+		return dto.MainSettingResponse{}, fmt.Errorf("invalid ID format: %v", err)
+	}
+	req.QrisStatis = strings.TrimSpace(req.QrisStatis)
+	if err := validQrisStatis(req.QrisStatis); err != nil {
+		return dto.MainSettingResponse{}, err
+	}
+
+	mainSetting, err := s.mainSettingRepo.UpdateQrisMainSetting(ctx, entity.MainSetting{
+		ID:         id,
+		QrisStatis: req.QrisStatis,
+	})
+	if err != nil {
+		return dto.MainSettingResponse{}, fmt.Errorf("%v: %v", dto.ErrUpdateMainSetting, err)
+	}
+
+	return dto.MainSettingResponse{
+		ID:         mainSetting.ID.String(),
+		NamaUsaha:  mainSetting.NamaUsaha,
+		JenisUsaha: mainSetting.JenisUsaha,
+		Alamat:     mainSetting.Alamat,
+		LogoUrl:    mainSetting.LogoUrl,
+		Hp:         mainSetting.Hp,
+
+		NomorDokumenSetting: toNomorDokumenSetting(mainSetting),
+		PajakSetting:        toPajakSetting(mainSetting),
+		QrisSetting:         toQrisSetting(mainSetting),
+	}, nil
+}
+
+// BuatQris builds the QRIS to show or print for a payment from the static
+// QRIS of the merchant, without going through any payment provider.
+func (s *mainSettingService) BuatQris(ctx context.Context, req dto.QrisRequest) (dto.QrisResponse, error) {
+	setting, err := s.mainSettingRepo.GetMainSetting(ctx)
+	if err != nil {
+		return dto.QrisResponse{}, fmt.Errorf("%v: %v", dto.ErrBuatQris, err)
+	}
+
+	payload, err := qrisPembayaran(setting, req.Jumlah, req.Referensi)
+	if err != nil {
+		return dto.QrisResponse{}, err
+	}
+
+	return dto.QrisResponse{
+		Jumlah:    req.Jumlah,
+		Referensi: req.Referensi,
+		Payload:   payload,
+	}, nil
+}
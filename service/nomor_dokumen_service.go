@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/jejevj/ykp_pos/constants"
+	"github.com/jejevj/ykp_pos/dto"
+	"github.com/jejevj/ykp_pos/repository"
+)
+
+type (
+	NomorDokumenService interface {
+		PreviewNomor(ctx context.Context, req dto.NomorDokumenPreviewRequest) (dto.NomorDokumenResponse, error)
+	}
+	nomorDokumenService struct {
+		nomorDokumenRepo repository.NomorDokumenRepository
+		jwtService       JWTService
+	}
+)
+
+func NewNomorDokumenService(nomorDokumenRepo repository.NomorDokumenRepository, jwtService JWTService) NomorDokumenService {
+	return &nomorDokumenService{
+		nomorDokumenRepo: nomorDokumenRepo,
+		jwtService:       jwtService,
+	}
+}
+
+func validJenisDokumen(jenis string) bool {
+	switch jenis {
+	case constants.ENUM_DOKUMEN_FAKTUR, constants.ENUM_DOKUMEN_LOADING, constants.ENUM_DOKUMEN_RETUR, constants.ENUM_DOKUMEN_NOTA_KREDIT, constants.ENUM_DOKUMEN_PENJUALAN,
+		constants.ENUM_DOKUMEN_PO, constants.ENUM_DOKUMEN_PENERIMAAN:
+		return true
+	}
+
+	return false
+}
+
+// PreviewNomor shows the number the next document would get. The number is
+// only reserved when the document itself is saved.
+func (s *nomorDokumenService) PreviewNomor(ctx context.Context, req dto.NomorDokumenPreviewRequest) (dto.NomorDokumenResponse, error) {
+	if !validJenisDokumen(req.Jenis) {
+		return dto.NomorDokumenResponse{}, dto.ErrInvalidJenisDokumen
+	}
+
+	tanggal, err := parseTanggal(req.Tanggal)
+	if err != nil {
+		return dto.NomorDokumenResponse{}, err
+	}
+	if tanggal == nil {
+		now := time.Now()
+		tanggal = &now
+	}
+
+	nomor, err := s.nomorDokumenRepo.PreviewNomor(ctx, req.Jenis, *tanggal)
+	if err != nil {
+		return dto.NomorDokumenResponse{}, err
+	}
+
+	return dto.NomorDokumenResponse{
+		Jenis: req.Jenis,
+		Nomor: nomor,
+	}, nil
+}
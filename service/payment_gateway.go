@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/jejevj/ykp_pos/constants"
+	"github.com/jejevj/ykp_pos/dto"
+	"github.com/jejevj/ykp_pos/helpers"
+)
+
+// PaymentGateway is a provider of virtual account and e-wallet payments.
+// VerifikasiNotifikasi checks the signature of a webhook body, CekStatus asks
+// the provider for the current state of an order.
+type PaymentGateway interface {
+	Nama() string
+	VerifikasiNotifikasi(body []byte) (dto.NotifikasiGateway, error)
+	CekStatus(ctx context.Context, orderId string) (dto.NotifikasiGateway, error)
+}
+
+type midtransGateway struct {
+	serverKey string
+	baseUrl   string
+	client    *http.Client
+}
+
+// NewMidtransGateway reads the server key from MIDTRANS_SERVER_KEY. The API
+// is the sandbox unless MIDTRANS_BASE_URL points elsewhere, such as the
+// production API or a local fake server.
+func NewMidtransGateway() PaymentGateway {
+	baseUrl := os.Getenv("MIDTRANS_BASE_URL")
+	if baseUrl == "" {
+		baseUrl = "https://api.sandbox.midtrans.com"
+	}
+
+	return &midtransGateway{
+		serverKey: os.Getenv("MIDTRANS_SERVER_KEY"),
+		baseUrl:   baseUrl,
+		client:    &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// notifikasiMidtrans is the body of a Midtrans notification and of its
+// status API; both are signed the same way.
+type notifikasiMidtrans struct {
+	OrderId           string `json:"order_id"`
+	TransactionId     string `json:"transaction_id"`
+	StatusCode        string `json:"status_code"`
+	GrossAmount       string `json:"gross_amount"`
+	SignatureKey      string `json:"signature_key"`
+	TransactionStatus string `json:"transaction_status"`
+	FraudStatus       string `json:"fraud_status"`
+	PaymentType       string `json:"payment_type"`
+	SettlementTime    string `json:"settlement_time"`
+	TransactionTime   string `json:"transaction_time"`
+}
+
+func (g *midtransGateway) Nama() string {
+	return constants.ENUM_GATEWAY_MIDTRANS
+}
+
+// signature is SHA512 over order id, status code, gross amount and the server
+// key, hex encoded.
+func (g *midtransGateway) signature(n notifikasiMidtrans) string {
+	sum := sha512.Sum512([]byte(n.OrderId + n.StatusCode + n.GrossAmount + g.serverKey))
+	return hex.EncodeToString(sum[:])
+}
+
+func (g *midtransGateway) VerifikasiNotifikasi(body []byte) (dto.NotifikasiGateway, error) {
+	var n notifikasiMidtrans
+	if err := json.Unmarshal(body, &n); err != nil {
+		return dto.NotifikasiGateway{}, err
+	}
+
+	// Without a server key every signature would be made with an empty secret
+	expected := g.signature(n)
+	if g.serverKey == "" || subtle.ConstantTimeCompare([]byte(expected), []byte(n.SignatureKey)) != 1 {
+		return dto.NotifikasiGateway{}, dto.ErrSignatureGateway
+	}
+
+	jumlah, err := strconv.ParseFloat(n.GrossAmount, 64)
+	if err != nil {
+		return dto.NotifikasiGateway{}, fmt.Errorf("invalid gross_amount %q", n.GrossAmount)
+	}
+
+	return dto.NotifikasiGateway{
+		OrderId:       n.OrderId,
+		IdTransaksi:   n.TransactionId,
+		Status:        statusMidtrans(n.TransactionStatus, n.FraudStatus),
+		StatusGateway: n.TransactionStatus,
+		Metode:        metodeMidtrans(n.PaymentType),
+		Jumlah:        helpers.BulatkanRupiah(jumlah),
+		WaktuBayar:    waktuMidtrans(n.SettlementTime, n.TransactionTime),
+		Payload:       string(body),
+	}, nil
+}
+
+func (g *midtransGateway) CekStatus(ctx context.Context, orderId string) (dto.NotifikasiGateway, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.baseUrl+"/v2/"+url.PathEscape(orderId)+"/status", nil)
+	if err != nil {
+		return dto.NotifikasiGateway{}, err
+	}
+	req.SetBasicAuth(g.serverKey, "")
+	req.Header.Set("Accept", "application/json")
+
+	res, err := g.client.Do(req)
+	if err != nil {
+		return dto.NotifikasiGateway{}, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, 1<<20))
+	if err != nil {
+		return dto.NotifikasiGateway{}, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return dto.NotifikasiGateway{}, fmt.Errorf("status API answered %d", res.StatusCode)
+	}
+
+	// The status API signs its answer like a notification
+	return g.VerifikasiNotifikasi(body)
+}
+
+// statusMidtrans translates a Midtrans transaction status. A card capture
+// only counts once the fraud check accepted it.
+func statusMidtrans(transactionStatus string, fraudStatus string) string {
+	switch transactionStatus {
+	case "settlement":
+		return constants.ENUM_GATEWAY_BERHASIL
+	case "capture":
+		switch fraudStatus {
+		case "", "accept":
+			return constants.ENUM_GATEWAY_BERHASIL
+		case "challenge":
+			return constants.ENUM_GATEWAY_PENDING
+		}
+		return constants.ENUM_GATEWAY_GAGAL
+	case "pending", "authorize":
+		return constants.ENUM_GATEWAY_PENDING
+	}
+
+	return constants.ENUM_GATEWAY_GAGAL
+}
+
+func metodeMidtrans(paymentType string) string {
+	switch paymentType {
+	case "bank_transfer", "echannel", "permata":
+		return constants.ENUM_METODE_BAYAR_VA
+	case "gopay", "shopeepay", "dana", "ovo", "akulaku":
+		return constants.ENUM_METODE_BAYAR_EWALLET
+	case "qris":
+		return constants.ENUM_METODE_BAYAR_QRIS
+	}
+
+	return paymentType
+}
+
+// waktuMidtrans reads the first time Midtrans filled in; its times are in
+// Western Indonesian Time.
+func waktuMidtrans(waktu ...string) *time.Time {
+	wib := time.FixedZone("WIB", 7*60*60)
+	for _, w := range waktu {
+		if t, err := time.ParseInLocation("2006-01-02 15:04:05", w, wib); err == nil {
+			return &t
+		}
+	}
+
+	return nil
+}
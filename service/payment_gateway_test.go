@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jejevj/ykp_pos/constants"
+	"github.com/jejevj/ykp_pos/dto"
+)
+
+const serverKeyTest = "SB-Mid-server-test"
+
+// notifikasiTest builds a Midtrans body, signed with the test server key
+// unless a signature is given.
+func notifikasiTest(t *testing.T, n notifikasiMidtrans) []byte {
+	t.Helper()
+
+	if n.SignatureKey == "" {
+		n.SignatureKey = (&midtransGateway{serverKey: serverKeyTest}).signature(n)
+	}
+	body, err := json.Marshal(n)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return body
+}
+
+func TestVerifikasiNotifikasi(t *testing.T) {
+	settlement := notifikasiMidtrans{
+		OrderId:           "FKT-0001-1",
+		TransactionId:     "trx-1",
+		StatusCode:        "200",
+		GrossAmount:       "150000.00",
+		TransactionStatus: "settlement",
+		PaymentType:       "bank_transfer",
+		SettlementTime:    "2026-10-18 10:15:00",
+	}
+
+	tests := []struct {
+		name      string
+		serverKey string
+		body      []byte
+		want      dto.NotifikasiGateway
+		wantErr   error
+	}{
+		{
+			name:      "settlement",
+			serverKey: serverKeyTest,
+			body:      notifikasiTest(t, settlement),
+			want: dto.NotifikasiGateway{
+				OrderId:       "FKT-0001-1",
+				IdTransaksi:   "trx-1",
+				Status:        constants.ENUM_GATEWAY_BERHASIL,
+				StatusGateway: "settlement",
+				Metode:        constants.ENUM_METODE_BAYAR_VA,
+				Jumlah:        150000,
+			},
+		},
+		{
+			name:      "capture ditahan fraud check",
+			serverKey: serverKeyTest,
+			body: notifikasiTest(t, notifikasiMidtrans{
+				OrderId:           "FKT-0002-1",
+				TransactionId:     "trx-2",
+				StatusCode:        "201",
+				GrossAmount:       "20000.00",
+				TransactionStatus: "capture",
+				FraudStatus:       "challenge",
+				PaymentType:       "credit_card",
+			}),
+			want: dto.NotifikasiGateway{
+				OrderId:       "FKT-0002-1",
+				IdTransaksi:   "trx-2",
+				Status:        constants.ENUM_GATEWAY_PENDING,
+				StatusGateway: "capture",
+				Metode:        "credit_card",
+				Jumlah:        20000,
+			},
+		},
+		{
+			name:      "signature salah",
+			serverKey: serverKeyTest,
+			body: notifikasiTest(t, notifikasiMidtrans{
+				OrderId:      "FKT-0001-1",
+				StatusCode:   "200",
+				GrossAmount:  "150000.00",
+				SignatureKey: "bukan-signature",
+			}),
+			wantErr: dto.ErrSignatureGateway,
+		},
+		{
+			name:      "jumlah diubah",
+			serverKey: serverKeyTest,
+			body: func() []byte {
+				var n notifikasiMidtrans
+				if err := json.Unmarshal(notifikasiTest(t, settlement), &n); err != nil {
+					t.Fatal(err)
+				}
+				n.GrossAmount = "1500000.00"
+				body, _ := json.Marshal(n)
+				return body
+			}(),
+			wantErr: dto.ErrSignatureGateway,
+		},
+		{
+			name:    "tanpa server key",
+			body:    notifikasiTest(t, settlement),
+			wantErr: dto.ErrSignatureGateway,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gateway := &midtransGateway{serverKey: tt.serverKey}
+
+			got, err := gateway.VerifikasiNotifikasi(tt.body)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if got.Payload != string(tt.body) {
+				t.Errorf("Payload = %q, want the body", got.Payload)
+			}
+			got.Payload, got.WaktuBayar = "", nil
+			if got != tt.want {
+				t.Errorf("VerifikasiNotifikasi() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifikasiNotifikasiBukanJson(t *testing.T) {
+	gateway := &midtransGateway{serverKey: serverKeyTest}
+
+	if _, err := gateway.VerifikasiNotifikasi([]byte("bukan json")); err == nil {
+		t.Fatal("expected an error for a body that is not JSON")
+	}
+}
+
+func TestCekStatus(t *testing.T) {
+	pending := notifikasiTest(t, notifikasiMidtrans{
+		OrderId:           "FKT-0003-1",
+		TransactionId:     "trx-3",
+		StatusCode:        "201",
+		GrossAmount:       "75000.00",
+		TransactionStatus: "pending",
+		PaymentType:       "qris",
+		TransactionTime:   "2026-10-18 09:00:00",
+	})
+	palsu := notifikasiTest(t, notifikasiMidtrans{
+		OrderId:           "FKT-0004-1",
+		StatusCode:        "200",
+		GrossAmount:       "75000.00",
+		TransactionStatus: "settlement",
+		SignatureKey:      "bukan-signature",
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, _, ok := r.BasicAuth(); !ok || user != serverKeyTest {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v2/FKT-0003-1/status":
+			w.Write(pending)
+		case "/v2/FKT-0004-1/status":
+			w.Write(palsu)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	gateway := &midtransGateway{
+		serverKey: serverKeyTest,
+		baseUrl:   server.URL,
+		client:    server.Client(),
+	}
+
+	t.Run("status pending", func(t *testing.T) {
+		got, err := gateway.CekStatus(context.Background(), "FKT-0003-1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.OrderId != "FKT-0003-1" || got.Status != constants.ENUM_GATEWAY_PENDING ||
+			got.Metode != constants.ENUM_METODE_BAYAR_QRIS || got.Jumlah != 75000 {
+			t.Errorf("CekStatus() = %+v", got)
+		}
+		if got.WaktuBayar == nil || got.WaktuBayar.Hour() != 9 {
+			t.Errorf("WaktuBayar = %v, want the transaction time", got.WaktuBayar)
+		}
+	})
+
+	t.Run("jawaban tidak bertanda tangan sah", func(t *testing.T) {
+		if _, err := gateway.CekStatus(context.Background(), "FKT-0004-1"); !errors.Is(err, dto.ErrSignatureGateway) {
+			t.Fatalf("error = %v, want %v", err, dto.ErrSignatureGateway)
+		}
+	})
+
+	t.Run("order tidak dikenal", func(t *testing.T) {
+		if _, err := gateway.CekStatus(context.Background(), "FKT-9999-1"); err == nil {
+			t.Fatal("expected an error for a 404 answer")
+		}
+	})
+
+	t.Run("server key salah", func(t *testing.T) {
+		salah := *gateway
+		salah.serverKey = "kunci-lain"
+		if _, err := salah.CekStatus(context.Background(), "FKT-0003-1"); err == nil {
+			t.Fatal("expected an error for a 401 answer")
+		}
+	})
+}
@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/jejevj/ykp_pos/constants"
+	"github.com/jejevj/ykp_pos/dto"
+	"github.com/jejevj/ykp_pos/entity"
+	"github.com/jejevj/ykp_pos/repository"
+	"github.com/jejevj/ykp_pos/utils"
+)
+
+type (
+	PembayaranService interface {
+		AddPembayaran(ctx context.Context, req dto.PembayaranCreateRequest, userId string) (dto.PembayaranResponse, error)
+		GetPembayaranByFaktur(ctx context.Context, fakturId string) ([]dto.PembayaranResponse, error)
+		GetPembayaranPending(ctx context.Context) ([]dto.PembayaranResponse, error)
+		TerimaPembayaran(ctx context.Context, pembayaranId string, reviewerId string) (dto.PembayaranResponse, error)
+		TolakPembayaran(ctx context.Context, req dto.PembayaranReviewRequest, reviewerId string) (dto.PembayaranResponse, error)
+		DeletePembayaran(ctx context.Context, pembayaranId string) error
+		PakaiKredit(ctx context.Context, req dto.PakaiKreditRequest) (dto.PakaiKreditResponse, error)
+		GetSaldoKredit(ctx context.Context, customerId string) (dto.SaldoKreditResponse, error)
+	}
+	pembayaranService struct {
+		pembayaranRepo repository.PembayaranRepository
+		jwtService     JWTService
+	}
+)
+
+func NewPembayaranService(pembayaranRepo repository.PembayaranRepository, jwtService JWTService) PembayaranService {
+	return &pembayaranService{
+		pembayaranRepo: pembayaranRepo,
+		jwtService:     jwtService,
+	}
+}
+
+func validMetodeBayar(metode string) bool {
+	switch metode {
+	case constants.ENUM_METODE_BAYAR_TUNAI,
+		constants.ENUM_METODE_BAYAR_TRANSFER,
+		constants.ENUM_METODE_BAYAR_QRIS,
+		constants.ENUM_METODE_BAYAR_GIRO:
+		return true
+	}
+
+	return false
+}
+
+// buktiBayarExtensions are the file types accepted as payment proof.
+var buktiBayarExtensions = map[string]bool{
+	".jpg":  true,
+	".jpeg": true,
+	".png":  true,
+	".pdf":  true,
+}
+
+func toPembayaranResponse(pembayaran entity.Pembayaran) dto.PembayaranResponse {
+	return dto.PembayaranResponse{
+		ID:           pembayaran.ID.String(),
+		IdFaktur:     pembayaran.IdFaktur,
+		NoFaktur:     pembayaran.Faktur.NoFaktur,
+		Jumlah:       pembayaran.Jumlah,
+		Kelebihan:    pembayaran.Kelebihan,
+		Metode:       pembayaran.Metode,
+		TanggalBayar: formatTanggal(pembayaran.TanggalBayar),
+		Referensi:    pembayaran.Referensi,
+		IdUser:       pembayaran.IdUser,
+		Penagih: dto.UserResponse{
+			ID:         pembayaran.Penagih.ID.String(),
+			Name:       pembayaran.Penagih.Name,
+			Email:      pembayaran.Penagih.Email,
+			TelpNumber: pembayaran.Penagih.TelpNumber,
+			Role:       pembayaran.Penagih.Role,
+			ImageUrl:   pembayaran.Penagih.ImageUrl,
+		},
+		Keterangan:    pembayaran.Keterangan,
+		BuktiBayar:    pembayaran.BuktiBayar,
+		StatusBukti:   pembayaran.StatusBukti,
+		AlasanTolak:   pembayaran.AlasanTolak,
+		IdReviewer:    pembayaran.IdReviewer,
+		TanggalReview: formatTanggal(pembayaran.TanggalReview),
+		StatusFaktur:  pembayaran.Faktur.Status,
+		SisaTagihan:   pembayaran.Faktur.Total - pembayaran.Faktur.TotalBayar,
+	}
+}
+
+func (s *pembayaranService) AddPembayaran(ctx context.Context, req dto.PembayaranCreateRequest, userId string) (dto.PembayaranResponse, error) {
+	if req.Jumlah <= 0 {
+		return dto.PembayaranResponse{}, dto.ErrPembayaranJumlahInvalid
+	}
+	if !validMetodeBayar(req.Metode) {
+		return dto.PembayaranResponse{}, dto.ErrInvalidMetodeBayar
+	}
+
+	tanggalBayar, err := parseTanggal(req.TanggalBayar)
+	if err != nil {
+		return dto.PembayaranResponse{}, err
+	}
+	if tanggalBayar == nil {
+		now := time.Now()
+		tanggalBayar = &now
+	}
+
+	if req.BuktiBayar == nil {
+		return dto.PembayaranResponse{}, dto.ErrBuktiBayarRequired
+	}
+	ext := strings.ToLower(filepath.Ext(req.BuktiBayar.Filename))
+	if !buktiBayarExtensions[ext] {
+		return dto.PembayaranResponse{}, dto.ErrBuktiBayarInvalid
+	}
+
+	filename := fmt.Sprintf("bukti-bayar/%s%s", uuid.New(), ext)
+	if err := utils.UploadFile(req.BuktiBayar, filename); err != nil {
+		return dto.PembayaranResponse{}, fmt.Errorf("%v: %v", dto.ErrCreatePembayaran, err)
+	}
+
+	pembayaran := entity.Pembayaran{
+		IdFaktur:     req.IdFaktur,
+		Jumlah:       req.Jumlah,
+		Metode:       req.Metode,
+		TanggalBayar: tanggalBayar,
+		Referensi:    req.Referensi,
+		IdUser:       userId,
+		Keterangan:   req.Keterangan,
+		BuktiBayar:   filename,
+	}
+
+	pembayaranAdd, err := s.pembayaranRepo.AddPembayaran(ctx, pembayaran, req.SimpanKredit)
+	if err != nil {
+		// Do not keep the proof of a payment that was never recorded
+		os.Remove(fmt.Sprintf("%s/%s", utils.PATH, filename))
+		return dto.PembayaranResponse{}, fmt.Errorf("%v: %v", dto.ErrCreatePembayaran, err)
+	}
+
+	return toPembayaranResponse(pembayaranAdd), nil
+}
+
+func (s *pembayaranService) GetPembayaranByFaktur(ctx context.Context, fakturId string) ([]dto.PembayaranResponse, error) {
+	pembayarans, err := s.pembayaranRepo.GetPembayaranByFaktur(ctx, fakturId)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", dto.ErrGetPembayaran, err)
+	}
+
+	datas := []dto.PembayaranResponse{}
+	for _, pembayaran := range pembayarans {
+		datas = append(datas, toPembayaranResponse(pembayaran))
+	}
+
+	return datas, nil
+}
+
+func (s *pembayaranService) GetPembayaranPending(ctx context.Context) ([]dto.PembayaranResponse, error) {
+	pembayarans, err := s.pembayaranRepo.GetPembayaranPending(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", dto.ErrGetPembayaran, err)
+	}
+
+	datas := []dto.PembayaranResponse{}
+	for _, pembayaran := range pembayarans {
+		datas = append(datas, toPembayaranResponse(pembayaran))
+	}
+
+	return datas, nil
+}
+
+func (s *pembayaranService) TerimaPembayaran(ctx context.Context, pembayaranId string, reviewerId string) (dto.PembayaranResponse, error) {
+	pembayaran, err := s.pembayaranRepo.ReviewPembayaran(ctx, pembayaranId, constants.ENUM_BUKTI_DITERIMA, "", reviewerId)
+	if err != nil {
+		return dto.PembayaranResponse{}, fmt.Errorf("%v: %v", dto.ErrReviewPembayaran, err)
+	}
+
+	return toPembayaranResponse(pembayaran), nil
+}
+
+func (s *pembayaranService) TolakPembayaran(ctx context.Context, req dto.PembayaranReviewRequest, reviewerId string) (dto.PembayaranResponse, error) {
+	if strings.TrimSpace(req.Alasan) == "" {
+		return dto.PembayaranResponse{}, dto.ErrAlasanTolakRequired
+	}
+
+	pembayaran, err := s.pembayaranRepo.ReviewPembayaran(ctx, req.ID, constants.ENUM_BUKTI_DITOLAK, strings.TrimSpace(req.Alasan), reviewerId)
+	if err != nil {
+		return dto.PembayaranResponse{}, fmt.Errorf("%v: %v", dto.ErrReviewPembayaran, err)
+	}
+
+	return toPembayaranResponse(pembayaran), nil
+}
+
+func (s *pembayaranService) DeletePembayaran(ctx context.Context, pembayaranId string) error {
+	if _, err := s.pembayaranRepo.GetPembayaranById(ctx, pembayaranId); err != nil {
+		return dto.ErrPembayaranNotFound
+	}
+
+	if err := s.pembayaranRepo.DeletePembayaran(ctx, pembayaranId); err != nil {
+		return fmt.Errorf("%v: %v", dto.ErrDeletePembayaran, err)
+	}
+
+	return nil
+}
+
+func (s *pembayaranService) PakaiKredit(ctx context.Context, req dto.PakaiKreditRequest) (dto.PakaiKreditResponse, error) {
+	if req.Jumlah < 0 {
+		return dto.PakaiKreditResponse{}, dto.ErrPembayaranInvalid
+	}
+
+	faktur, dipakai, err := s.pembayaranRepo.PakaiKredit(ctx, req.IdFaktur, req.Jumlah)
+	if err != nil {
+		return dto.PakaiKreditResponse{}, fmt.Errorf("%v: %v", dto.ErrPakaiKredit, err)
+	}
+
+	saldo, _, err := s.pembayaranRepo.GetSaldoKredit(ctx, faktur.IdCustomer)
+	if err != nil {
+		return dto.PakaiKreditResponse{}, fmt.Errorf("%v: %v", dto.ErrGetPembayaran, err)
+	}
+
+	return dto.PakaiKreditResponse{
+		IdFaktur:     faktur.ID.String(),
+		NoFaktur:     faktur.NoFaktur,
+		Dipakai:      dipakai,
+		StatusFaktur: faktur.Status,
+		SisaTagihan:  faktur.Total - faktur.TotalBayar,
+		SaldoKredit:  saldo,
+	}, nil
+}
+
+func (s *pembayaranService) GetSaldoKredit(ctx context.Context, customerId string) (dto.SaldoKreditResponse, error) {
+	saldo, mutasi, err := s.pembayaranRepo.GetSaldoKredit(ctx, customerId)
+	if err != nil {
+		return dto.SaldoKreditResponse{}, fmt.Errorf("%v: %v", dto.ErrGetPembayaran, err)
+	}
+
+	datas := []dto.KreditCustomerResponse{}
+	for _, item := range mutasi {
+		datas = append(datas, dto.KreditCustomerResponse{
+			ID:           item.ID.String(),
+			IdPembayaran: item.IdPembayaran,
+			IdNotaKredit: item.IdNotaKredit,
+			IdFaktur:     item.IdFaktur,
+			Jumlah:       item.Jumlah,
+			Keterangan:   item.Keterangan,
+			Tanggal:      item.CreatedAt.Format(constants.ENUM_DATE_FORMAT),
+		})
+	}
+
+	return dto.SaldoKreditResponse{
+		IdCustomer: customerId,
+		Saldo:      saldo,
+		Mutasi:     datas,
+	}, nil
+}
@@ -0,0 +1,269 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jejevj/ykp_pos/constants"
+	"github.com/jejevj/ykp_pos/dto"
+	"github.com/jejevj/ykp_pos/entity"
+	"github.com/jejevj/ykp_pos/repository"
+)
+
+type (
+	PenjualanService interface {
+		HitungPenjualan(ctx context.Context, req dto.PenjualanCreateRequest) (dto.PenjualanResponse, error)
+		AddPenjualan(ctx context.Context, req dto.PenjualanCreateRequest, userId string) (dto.PenjualanResponse, error)
+		GetPenjualanById(ctx context.Context, penjualanId string) (dto.PenjualanResponse, error)
+		GetAllPenjualanWithPagination(ctx context.Context, req dto.PenjualanFilterRequest) (dto.PenjualanPaginationResponse, error)
+		CetakStrukPenjualan(ctx context.Context, penjualanId string, req dto.StrukRequest) ([]byte, error)
+	}
+	penjualanService struct {
+		penjualanRepo   repository.PenjualanRepository
+		mainSettingRepo repository.MainSettingRepository
+		jwtService      JWTService
+	}
+)
+
+func NewPenjualanService(penjualanRepo repository.PenjualanRepository, mainSettingRepo repository.MainSettingRepository, jwtService JWTService) PenjualanService {
+	return &penjualanService{
+		penjualanRepo:   penjualanRepo,
+		mainSettingRepo: mainSettingRepo,
+		jwtService:      jwtService,
+	}
+}
+
+// validMetodePenjualan accepts the ways a counter sale can be paid; giro is
+// only taken on fakturs.
+func validMetodePenjualan(metode string) bool {
+	switch metode {
+	case constants.ENUM_METODE_BAYAR_TUNAI,
+		constants.ENUM_METODE_BAYAR_TRANSFER,
+		constants.ENUM_METODE_BAYAR_QRIS:
+		return true
+	}
+
+	return false
+}
+
+// toPenjualanEntity checks a cart and turns it into a sale to be priced.
+func toPenjualanEntity(req dto.PenjualanCreateRequest) (entity.Penjualan, error) {
+	if len(req.Items) == 0 {
+		return entity.Penjualan{}, dto.ErrPenjualanItemsEmpty
+	}
+
+	// A cart without tenders is paid in full with the one method given
+	tenders := req.Pembayaran
+	if len(tenders) == 0 {
+		tenders = []dto.PembayaranPenjualanRequest{{Metode: req.MetodeBayar, Jumlah: req.Bayar}}
+	}
+
+	var pembayarans []entity.PembayaranPenjualan
+	for _, tender := range tenders {
+		metode := tender.Metode
+		if metode == "" {
+			metode = constants.ENUM_METODE_BAYAR_TUNAI
+		}
+		if !validMetodePenjualan(metode) {
+			return entity.Penjualan{}, dto.ErrInvalidMetodePenjualan
+		}
+		if tender.Jumlah < 0 {
+			return entity.Penjualan{}, dto.ErrPembayaranInvalid
+		}
+
+		pembayarans = append(pembayarans, entity.PembayaranPenjualan{
+			Metode:    metode,
+			Jumlah:    tender.Jumlah,
+			Referensi: tender.Referensi,
+		})
+	}
+
+	var items []entity.TransaksiPenjualan
+	for _, item := range req.Items {
+		items = append(items, entity.TransaksiPenjualan{
+			IdBarang: item.IdBarang,
+			Krat:     item.Krat,
+			Lusin:    item.Lusin,
+			Satuan:   item.Satuan,
+			Rincian:  toRincian(item.Rincian),
+			Diskon:   item.Diskon,
+			DiskonP:  item.DiskonP,
+		})
+	}
+
+	return entity.Penjualan{
+		Diskon:      req.Diskon,
+		DiskonP:     req.DiskonP,
+		Keterangan:  req.Keterangan,
+		Items:       items,
+		Pembayarans: pembayarans,
+	}, nil
+}
+
+func toPenjualanResponse(penjualan entity.Penjualan) dto.PenjualanResponse {
+	items := []dto.TransaksiPenjualanResponse{}
+	for _, item := range penjualan.Items {
+		items = append(items, dto.TransaksiPenjualanResponse{
+			ID:           item.ID.String(),
+			IdBarang:     item.IdBarang,
+			Barang:       toBarangResponse(item.Barang),
+			Krat:         item.Krat,
+			Lusin:        item.Lusin,
+			Satuan:       item.Satuan,
+			Jumlah:       item.Jumlah,
+			JumlahFormat: formatJumlahBarang(item.Barang, item.Jumlah),
+			Harga:        item.Harga,
+			Diskon:       item.Diskon,
+			DiskonP:      item.DiskonP,
+			JumlahRP:     item.JumlahRP,
+			DiskonFaktur: item.DiskonFaktur,
+			Dpp:          item.Dpp,
+			Ppn:          item.Ppn,
+		})
+	}
+
+	pembayaran := []dto.PembayaranPenjualanResponse{}
+	for _, tender := range penjualan.Pembayarans {
+		pembayaran = append(pembayaran, dto.PembayaranPenjualanResponse{
+			ID:        tender.ID.String(),
+			Metode:    tender.Metode,
+			Jumlah:    tender.Jumlah,
+			Referensi: tender.Referensi,
+		})
+	}
+
+	return dto.PenjualanResponse{
+		ID:          penjualan.ID.String(),
+		NoPenjualan: penjualan.NoPenjualan,
+		Tanggal:     formatWaktu(penjualan.Tanggal),
+		IdShift:     penjualan.IdShift,
+		IdUser:      penjualan.IdUser,
+		Kasir: dto.UserResponse{
+			ID:         penjualan.Kasir.ID.String(),
+			Name:       penjualan.Kasir.Name,
+			Email:      penjualan.Kasir.Email,
+			TelpNumber: penjualan.Kasir.TelpNumber,
+			Role:       penjualan.Kasir.Role,
+			ImageUrl:   penjualan.Kasir.ImageUrl,
+		},
+		MetodeBayar:    penjualan.MetodeBayar,
+		Subtotal:       penjualan.Subtotal,
+		Diskon:         penjualan.Diskon,
+		DiskonP:        penjualan.DiskonP,
+		PotonganDiskon: penjualan.PotonganDiskon,
+		TarifPpn:       penjualan.TarifPpn,
+		Dpp:            penjualan.Dpp,
+		Ppn:            penjualan.Ppn,
+		Total:          penjualan.Total,
+		Bayar:          penjualan.Bayar,
+		Kembalian:      penjualan.Kembalian,
+		Tunai:          penjualan.Tunai,
+		Pembayaran:     pembayaran,
+		Keterangan:     penjualan.Keterangan,
+		Items:          items,
+	}
+}
+
+// HitungPenjualan prices a cart without saving it, so the counter can show
+// the totals while the cart is being built. Every QRIS tender comes with the
+// dynamic QRIS for its amount for the customer to scan.
+func (s *penjualanService) HitungPenjualan(ctx context.Context, req dto.PenjualanCreateRequest) (dto.PenjualanResponse, error) {
+	penjualan, err := toPenjualanEntity(req)
+	if err != nil {
+		return dto.PenjualanResponse{}, err
+	}
+
+	penjualan, err = s.penjualanRepo.HitungPenjualan(ctx, penjualan)
+	if err != nil {
+		return dto.PenjualanResponse{}, fmt.Errorf("%v: %v", dto.ErrCreatePenjualan, err)
+	}
+
+	res := toPenjualanResponse(penjualan)
+	for i, tender := range penjualan.Pembayarans {
+		if tender.Metode != constants.ENUM_METODE_BAYAR_QRIS || tender.Jumlah <= 0 {
+			continue
+		}
+
+		setting, err := s.mainSettingRepo.GetMainSetting(ctx)
+		if err != nil {
+			return dto.PenjualanResponse{}, fmt.Errorf("%v: %v", dto.ErrBuatQris, err)
+		}
+		if res.Pembayaran[i].Qris, err = qrisPembayaran(setting, tender.Jumlah, tender.Referensi); err != nil {
+			return dto.PenjualanResponse{}, err
+		}
+	}
+
+	return res, nil
+}
+
+func (s *penjualanService) AddPenjualan(ctx context.Context, req dto.PenjualanCreateRequest, userId string) (dto.PenjualanResponse, error) {
+	penjualan, err := toPenjualanEntity(req)
+	if err != nil {
+		return dto.PenjualanResponse{}, err
+	}
+	penjualan.IdUser = userId
+
+	penjualanAdd, err := s.penjualanRepo.AddPenjualan(ctx, penjualan)
+	if err != nil {
+		return dto.PenjualanResponse{}, fmt.Errorf("%v: %v", dto.ErrCreatePenjualan, err)
+	}
+
+	return toPenjualanResponse(penjualanAdd), nil
+}
+
+func (s *penjualanService) GetPenjualanById(ctx context.Context, penjualanId string) (dto.PenjualanResponse, error) {
+	penjualan, err := s.penjualanRepo.GetPenjualanById(ctx, penjualanId)
+	if err != nil {
+		return dto.PenjualanResponse{}, fmt.Errorf("%v: %v", dto.ErrGetPenjualan, err)
+	}
+
+	return toPenjualanResponse(penjualan), nil
+}
+
+func (s *penjualanService) GetAllPenjualanWithPagination(ctx context.Context, req dto.PenjualanFilterRequest) (dto.PenjualanPaginationResponse, error) {
+	if _, err := parseTanggal(req.TanggalMulai); err != nil {
+		return dto.PenjualanPaginationResponse{}, err
+	}
+	if _, err := parseTanggal(req.TanggalAkhir); err != nil {
+		return dto.PenjualanPaginationResponse{}, err
+	}
+
+	dataWithPaginate, err := s.penjualanRepo.GetAllPenjualanWithPagination(ctx, req)
+	if err != nil {
+		return dto.PenjualanPaginationResponse{}, err
+	}
+
+	datas := []dto.PenjualanResponse{}
+	for _, penjualan := range dataWithPaginate.Penjualans {
+		datas = append(datas, toPenjualanResponse(penjualan))
+	}
+
+	return dto.PenjualanPaginationResponse{
+		Data: datas,
+		PaginationResponse: dto.PaginationResponse{
+			Page:    dataWithPaginate.Page,
+			PerPage: dataWithPaginate.PerPage,
+			MaxPage: dataWithPaginate.MaxPage,
+			Count:   dataWithPaginate.Count,
+		},
+	}, nil
+}
+
+// CetakStrukPenjualan renders the receipt of a counter sale.
+func (s *penjualanService) CetakStrukPenjualan(ctx context.Context, penjualanId string, req dto.StrukRequest) ([]byte, error) {
+	if err := validStrukRequest(&req); err != nil {
+		return nil, err
+	}
+
+	penjualan, err := s.penjualanRepo.GetPenjualanById(ctx, penjualanId)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", dto.ErrGetPenjualan, err)
+	}
+
+	setting, err := s.mainSettingRepo.GetMainSetting(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", dto.ErrCetakStruk, err)
+	}
+
+	return renderStruk(strukPenjualan(penjualan, setting), req), nil
+}
@@ -0,0 +1,305 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jejevj/ykp_pos/constants"
+	"github.com/jejevj/ykp_pos/dto"
+	"github.com/jejevj/ykp_pos/entity"
+	"github.com/jejevj/ykp_pos/repository"
+)
+
+type (
+	PurchaseOrderService interface {
+		AddPurchaseOrder(ctx context.Context, req dto.PurchaseOrderCreateRequest, userId string) (dto.PurchaseOrderResponse, error)
+		GetPurchaseOrderById(ctx context.Context, poId string) (dto.PurchaseOrderResponse, error)
+		GetAllPurchaseOrderWithPagination(ctx context.Context, req dto.PurchaseOrderFilterRequest) (dto.PurchaseOrderPaginationResponse, error)
+		TutupPurchaseOrder(ctx context.Context, req dto.TutupPurchaseOrderRequest) (dto.PurchaseOrderResponse, error)
+		AddPenerimaan(ctx context.Context, req dto.PenerimaanBarangCreateRequest, userId string) (dto.PenerimaanBarangResponse, error)
+		GetPenerimaanById(ctx context.Context, penerimaanId string) (dto.PenerimaanBarangResponse, error)
+	}
+	purchaseOrderService struct {
+		purchaseOrderRepo repository.PurchaseOrderRepository
+		jwtService        JWTService
+	}
+)
+
+func NewPurchaseOrderService(purchaseOrderRepo repository.PurchaseOrderRepository, jwtService JWTService) PurchaseOrderService {
+	return &purchaseOrderService{
+		purchaseOrderRepo: purchaseOrderRepo,
+		jwtService:        jwtService,
+	}
+}
+
+func validStatusPo(status string) bool {
+	switch status {
+	case "", constants.ENUM_PO_OPEN, constants.ENUM_PO_PARTIAL, constants.ENUM_PO_CLOSED:
+		return true
+	}
+
+	return false
+}
+
+func toTransaksiPurchaseOrderEntities(items []dto.TransaksiPurchaseOrderRequest) []entity.TransaksiPurchaseOrder {
+	var datas []entity.TransaksiPurchaseOrder
+	for _, item := range items {
+		datas = append(datas, entity.TransaksiPurchaseOrder{
+			IdBarang:  item.IdBarang,
+			Krat:      item.Krat,
+			Lusin:     item.Lusin,
+			Satuan:    item.Satuan,
+			Rincian:   toRincian(item.Rincian),
+			HargaBeli: item.HargaBeli,
+		})
+	}
+
+	return datas
+}
+
+func toTransaksiPenerimaanEntities(items []dto.TransaksiPenerimaanRequest) []entity.TransaksiPenerimaan {
+	var datas []entity.TransaksiPenerimaan
+	for _, item := range items {
+		datas = append(datas, entity.TransaksiPenerimaan{
+			IdTransaksiPo: item.IdTransaksiPo,
+			Krat:          item.Krat,
+			Lusin:         item.Lusin,
+			Satuan:        item.Satuan,
+			Rincian:       toRincian(item.Rincian),
+			HargaBeli:     item.HargaBeli,
+		})
+	}
+
+	return datas
+}
+
+func toPenerimaanResponse(penerimaan entity.PenerimaanBarang) dto.PenerimaanBarangResponse {
+	items := []dto.TransaksiPenerimaanResponse{}
+	for _, item := range penerimaan.Items {
+		items = append(items, dto.TransaksiPenerimaanResponse{
+			ID:            item.ID.String(),
+			IdTransaksiPo: item.IdTransaksiPo,
+			IdBarang:      item.IdBarang,
+			Barang:        toBarangResponse(item.Barang),
+			Krat:          item.Krat,
+			Lusin:         item.Lusin,
+			Satuan:        item.Satuan,
+			Jumlah:        item.Jumlah,
+			JumlahFormat:  formatJumlahBarang(item.Barang, item.Jumlah),
+			HargaBeli:     item.HargaBeli,
+			JumlahRP:      item.JumlahRP,
+		})
+	}
+
+	return dto.PenerimaanBarangResponse{
+		ID:              penerimaan.ID.String(),
+		NoPenerimaan:    penerimaan.NoPenerimaan,
+		Tanggal:         formatTanggal(penerimaan.Tanggal),
+		IdPurchaseOrder: penerimaan.IdPurchaseOrder,
+		NoPo:            penerimaan.PurchaseOrder.NoPo,
+		StatusPo:        penerimaan.PurchaseOrder.Status,
+		IdUser:          penerimaan.IdUser,
+		User: dto.UserResponse{
+			ID:         penerimaan.User.ID.String(),
+			Name:       penerimaan.User.Name,
+			Email:      penerimaan.User.Email,
+			TelpNumber: penerimaan.User.TelpNumber,
+			Role:       penerimaan.User.Role,
+			ImageUrl:   penerimaan.User.ImageUrl,
+		},
+		Total:      penerimaan.Total,
+		Keterangan: penerimaan.Keterangan,
+		Items:      items,
+	}
+}
+
+func toPurchaseOrderResponse(po entity.PurchaseOrder) dto.PurchaseOrderResponse {
+	items := []dto.TransaksiPurchaseOrderResponse{}
+	for _, item := range po.Items {
+		items = append(items, dto.TransaksiPurchaseOrderResponse{
+			ID:             item.ID.String(),
+			IdBarang:       item.IdBarang,
+			Barang:         toBarangResponse(item.Barang),
+			Krat:           item.Krat,
+			Lusin:          item.Lusin,
+			Satuan:         item.Satuan,
+			Jumlah:         item.Jumlah,
+			JumlahFormat:   formatJumlahBarang(item.Barang, item.Jumlah),
+			HargaBeli:      item.HargaBeli,
+			JumlahRP:       item.JumlahRP,
+			Diterima:       item.Diterima,
+			DiterimaFormat: formatJumlahBarang(item.Barang, item.Diterima),
+			Sisa:           item.Jumlah - item.Diterima,
+		})
+	}
+
+	penerimaans := []dto.PenerimaanBarangResponse{}
+	for _, penerimaan := range po.Penerimaans {
+		// The deliveries are loaded under the order, not the other way round
+		data := toPenerimaanResponse(penerimaan)
+		data.NoPo = po.NoPo
+		data.StatusPo = po.Status
+		penerimaans = append(penerimaans, data)
+	}
+
+	return dto.PurchaseOrderResponse{
+		ID:         po.ID.String(),
+		NoPo:       po.NoPo,
+		Tanggal:    formatTanggal(po.Tanggal),
+		IdSupplier: po.IdSupplier,
+		Supplier:   toSupplierResponse(po.Supplier),
+		IdUser:     po.IdUser,
+		User: dto.UserResponse{
+			ID:         po.User.ID.String(),
+			Name:       po.User.Name,
+			Email:      po.User.Email,
+			TelpNumber: po.User.TelpNumber,
+			Role:       po.User.Role,
+			ImageUrl:   po.User.ImageUrl,
+		},
+		Status:      po.Status,
+		Total:       po.Total,
+		Keterangan:  po.Keterangan,
+		AlasanTutup: po.AlasanTutup,
+		Items:       items,
+		Penerimaans: penerimaans,
+	}
+}
+
+func (s *purchaseOrderService) AddPurchaseOrder(ctx context.Context, req dto.PurchaseOrderCreateRequest, userId string) (dto.PurchaseOrderResponse, error) {
+	if len(req.Items) == 0 {
+		return dto.PurchaseOrderResponse{}, dto.ErrPurchaseOrderItemsEmpty
+	}
+	for _, item := range req.Items {
+		if item.HargaBeli < 0 {
+			return dto.PurchaseOrderResponse{}, dto.ErrHargaBeliInvalid
+		}
+	}
+
+	tanggal, err := parseTanggal(req.Tanggal)
+	if err != nil {
+		return dto.PurchaseOrderResponse{}, err
+	}
+	if tanggal == nil {
+		now := time.Now()
+		tanggal = &now
+	}
+
+	po := entity.PurchaseOrder{
+		Tanggal:    tanggal,
+		IdSupplier: req.IdSupplier,
+		IdUser:     userId,
+		Keterangan: req.Keterangan,
+		Items:      toTransaksiPurchaseOrderEntities(req.Items),
+	}
+
+	poAdd, err := s.purchaseOrderRepo.AddPurchaseOrder(ctx, po)
+	if err != nil {
+		return dto.PurchaseOrderResponse{}, fmt.Errorf("%v: %v", dto.ErrCreatePurchaseOrder, err)
+	}
+
+	return toPurchaseOrderResponse(poAdd), nil
+}
+
+func (s *purchaseOrderService) GetPurchaseOrderById(ctx context.Context, poId string) (dto.PurchaseOrderResponse, error) {
+	po, err := s.purchaseOrderRepo.GetPurchaseOrderById(ctx, poId)
+	if err != nil {
+		return dto.PurchaseOrderResponse{}, fmt.Errorf("%v: %v", dto.ErrGetPurchaseOrder, err)
+	}
+
+	return toPurchaseOrderResponse(po), nil
+}
+
+func (s *purchaseOrderService) GetAllPurchaseOrderWithPagination(ctx context.Context, req dto.PurchaseOrderFilterRequest) (dto.PurchaseOrderPaginationResponse, error) {
+	if !validStatusPo(req.Status) {
+		return dto.PurchaseOrderPaginationResponse{}, dto.ErrInvalidStatusPo
+	}
+	if _, err := parseTanggal(req.TanggalMulai); err != nil {
+		return dto.PurchaseOrderPaginationResponse{}, err
+	}
+	if _, err := parseTanggal(req.TanggalAkhir); err != nil {
+		return dto.PurchaseOrderPaginationResponse{}, err
+	}
+
+	dataWithPaginate, err := s.purchaseOrderRepo.GetAllPurchaseOrderWithPagination(ctx, req)
+	if err != nil {
+		return dto.PurchaseOrderPaginationResponse{}, err
+	}
+
+	datas := []dto.PurchaseOrderResponse{}
+	for _, po := range dataWithPaginate.PurchaseOrders {
+		datas = append(datas, toPurchaseOrderResponse(po))
+	}
+
+	return dto.PurchaseOrderPaginationResponse{
+		Data: datas,
+		PaginationResponse: dto.PaginationResponse{
+			Page:    dataWithPaginate.Page,
+			PerPage: dataWithPaginate.PerPage,
+			MaxPage: dataWithPaginate.MaxPage,
+			Count:   dataWithPaginate.Count,
+		},
+	}, nil
+}
+
+// TutupPurchaseOrder closes what is left of an order; the reason is kept so
+// the shortfall can be followed up with the supplier.
+func (s *purchaseOrderService) TutupPurchaseOrder(ctx context.Context, req dto.TutupPurchaseOrderRequest) (dto.PurchaseOrderResponse, error) {
+	alasan := strings.TrimSpace(req.Alasan)
+	if alasan == "" {
+		return dto.PurchaseOrderResponse{}, dto.ErrAlasanTutupRequired
+	}
+
+	po, err := s.purchaseOrderRepo.TutupPurchaseOrder(ctx, req.ID, alasan)
+	if err != nil {
+		return dto.PurchaseOrderResponse{}, fmt.Errorf("%v: %v", dto.ErrTutupPurchaseOrder, err)
+	}
+
+	return toPurchaseOrderResponse(po), nil
+}
+
+func (s *purchaseOrderService) AddPenerimaan(ctx context.Context, req dto.PenerimaanBarangCreateRequest, userId string) (dto.PenerimaanBarangResponse, error) {
+	if len(req.Items) == 0 {
+		return dto.PenerimaanBarangResponse{}, dto.ErrPenerimaanItemsEmpty
+	}
+	for _, item := range req.Items {
+		if item.HargaBeli < 0 {
+			return dto.PenerimaanBarangResponse{}, dto.ErrHargaBeliInvalid
+		}
+	}
+
+	tanggal, err := parseTanggal(req.Tanggal)
+	if err != nil {
+		return dto.PenerimaanBarangResponse{}, err
+	}
+	if tanggal == nil {
+		now := time.Now()
+		tanggal = &now
+	}
+
+	penerimaan := entity.PenerimaanBarang{
+		Tanggal:         tanggal,
+		IdPurchaseOrder: req.IdPurchaseOrder,
+		IdUser:          userId,
+		Keterangan:      req.Keterangan,
+		Items:           toTransaksiPenerimaanEntities(req.Items),
+	}
+
+	penerimaanAdd, err := s.purchaseOrderRepo.AddPenerimaan(ctx, penerimaan)
+	if err != nil {
+		return dto.PenerimaanBarangResponse{}, fmt.Errorf("%v: %v", dto.ErrCreatePenerimaan, err)
+	}
+
+	return toPenerimaanResponse(penerimaanAdd), nil
+}
+
+func (s *purchaseOrderService) GetPenerimaanById(ctx context.Context, penerimaanId string) (dto.PenerimaanBarangResponse, error) {
+	penerimaan, err := s.purchaseOrderRepo.GetPenerimaanById(ctx, penerimaanId)
+	if err != nil {
+		return dto.PenerimaanBarangResponse{}, fmt.Errorf("%v: %v", dto.ErrGetPenerimaan, err)
+	}
+
+	return toPenerimaanResponse(penerimaan), nil
+}
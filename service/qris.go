@@ -0,0 +1,24 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/jejevj/ykp_pos/dto"
+	"github.com/jejevj/ykp_pos/entity"
+	"github.com/jejevj/ykp_pos/helpers"
+)
+
+// qrisPembayaran is the QRIS of the merchant for one payment, dynamic with
+// the amount embedded when jumlah is positive and static otherwise.
+func qrisPembayaran(setting entity.MainSetting, jumlah int, referensi string) (string, error) {
+	if setting.QrisStatis == "" {
+		return "", dto.ErrQrisBelumDiatur
+	}
+
+	payload, err := helpers.QrisDinamis(setting.QrisStatis, jumlah, referensi)
+	if err != nil {
+		return "", fmt.Errorf("%v: %v", dto.ErrBuatQris, err)
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jejevj/ykp_pos/constants"
+	"github.com/jejevj/ykp_pos/dto"
+	"github.com/jejevj/ykp_pos/entity"
+	"github.com/jejevj/ykp_pos/repository"
+)
+
+type (
+	ReturService interface {
+		AddRetur(ctx context.Context, req dto.ReturCreateRequest, userId string) (dto.ReturResponse, error)
+		GetReturById(ctx context.Context, returId string) (dto.ReturResponse, error)
+		GetReturByFaktur(ctx context.Context, fakturId string) ([]dto.ReturResponse, error)
+	}
+	returService struct {
+		returRepo  repository.ReturRepository
+		jwtService JWTService
+	}
+)
+
+func NewReturService(returRepo repository.ReturRepository, jwtService JWTService) ReturService {
+	return &returService{
+		returRepo:  returRepo,
+		jwtService: jwtService,
+	}
+}
+
+// validKondisiRetur accepts the condition of returned goods; goods without a
+// condition are taken back as sellable.
+func validKondisiRetur(kondisi string) bool {
+	switch kondisi {
+	case "", constants.ENUM_KONDISI_BAIK, constants.ENUM_KONDISI_RUSAK:
+		return true
+	}
+
+	return false
+}
+
+func toTransaksiReturEntities(items []dto.TransaksiReturRequest) []entity.TransaksiRetur {
+	var datas []entity.TransaksiRetur
+	for _, item := range items {
+		kondisi := item.Kondisi
+		if kondisi == "" {
+			kondisi = constants.ENUM_KONDISI_BAIK
+		}
+
+		datas = append(datas, entity.TransaksiRetur{
+			IdTransaksiFaktur: item.IdTransaksiFaktur,
+			Krat:              item.Krat,
+			Lusin:             item.Lusin,
+			Satuan:            item.Satuan,
+			Rincian:           toRincian(item.Rincian),
+			Kondisi:           kondisi,
+			Ket:               item.Ket,
+		})
+	}
+
+	return datas
+}
+
+func toReturResponse(retur entity.Retur) dto.ReturResponse {
+	items := []dto.TransaksiReturResponse{}
+	for _, item := range retur.Items {
+		items = append(items, dto.TransaksiReturResponse{
+			ID:                item.ID.String(),
+			IdTransaksiFaktur: item.IdTransaksiFaktur,
+			IdBarang:          item.IdBarang,
+			Barang:            toBarangResponse(item.Barang),
+			Krat:              item.Krat,
+			Lusin:             item.Lusin,
+			Satuan:            item.Satuan,
+			Jumlah:            item.Jumlah,
+			JumlahFormat:      formatJumlahBarang(item.Barang, item.Jumlah),
+			Kondisi:           item.Kondisi,
+			JumlahRP:          item.JumlahRP,
+			Dpp:               item.Dpp,
+			Ppn:               item.Ppn,
+			Ket:               item.Ket,
+		})
+	}
+
+	return dto.ReturResponse{
+		ID:           retur.ID.String(),
+		NoRetur:      retur.NoRetur,
+		Tanggal:      formatTanggal(retur.Tanggal),
+		IdFaktur:     retur.IdFaktur,
+		NoFaktur:     retur.Faktur.NoFaktur,
+		IdCustomer:   retur.IdCustomer,
+		NamaCustomer: retur.Customer.NamaToko,
+		IdUser:       retur.IdUser,
+		User: dto.UserResponse{
+			ID:         retur.User.ID.String(),
+			Name:       retur.User.Name,
+			Email:      retur.User.Email,
+			TelpNumber: retur.User.TelpNumber,
+			Role:       retur.User.Role,
+			ImageUrl:   retur.User.ImageUrl,
+		},
+		Total:        retur.Total,
+		Keterangan:   retur.Keterangan,
+		StatusFaktur: retur.Faktur.Status,
+		SisaTagihan:  retur.Faktur.Total - retur.Faktur.TotalBayar,
+		NotaKredit: dto.NotaKreditResponse{
+			ID:           retur.NotaKredit.ID.String(),
+			NoNotaKredit: retur.NotaKredit.NoNotaKredit,
+			Tanggal:      formatTanggal(retur.NotaKredit.Tanggal),
+			Dpp:          retur.NotaKredit.Dpp,
+			Ppn:          retur.NotaKredit.Ppn,
+			Total:        retur.NotaKredit.Total,
+			PotongFaktur: retur.NotaKredit.PotongFaktur,
+			Kredit:       retur.NotaKredit.Kredit,
+		},
+		Items: items,
+	}
+}
+
+func (s *returService) AddRetur(ctx context.Context, req dto.ReturCreateRequest, userId string) (dto.ReturResponse, error) {
+	if len(req.Items) == 0 {
+		return dto.ReturResponse{}, dto.ErrReturItemsEmpty
+	}
+	for _, item := range req.Items {
+		if !validKondisiRetur(item.Kondisi) {
+			return dto.ReturResponse{}, dto.ErrInvalidKondisiRetur
+		}
+	}
+
+	tanggal, err := parseTanggal(req.Tanggal)
+	if err != nil {
+		return dto.ReturResponse{}, err
+	}
+	if tanggal == nil {
+		now := time.Now()
+		tanggal = &now
+	}
+
+	retur := entity.Retur{
+		Tanggal:    tanggal,
+		IdFaktur:   req.IdFaktur,
+		IdUser:     userId,
+		Keterangan: req.Keterangan,
+		Items:      toTransaksiReturEntities(req.Items),
+	}
+
+	returAdd, err := s.returRepo.AddRetur(ctx, retur, req.KeKredit)
+	if err != nil {
+		return dto.ReturResponse{}, fmt.Errorf("%v: %v", dto.ErrCreateRetur, err)
+	}
+
+	return toReturResponse(returAdd), nil
+}
+
+func (s *returService) GetReturById(ctx context.Context, returId string) (dto.ReturResponse, error) {
+	retur, err := s.returRepo.GetReturById(ctx, returId)
+	if err != nil {
+		return dto.ReturResponse{}, fmt.Errorf("%v: %v", dto.ErrGetRetur, err)
+	}
+
+	return toReturResponse(retur), nil
+}
+
+func (s *returService) GetReturByFaktur(ctx context.Context, fakturId string) ([]dto.ReturResponse, error) {
+	returs, err := s.returRepo.GetReturByFaktur(ctx, fakturId)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", dto.ErrGetRetur, err)
+	}
+
+	datas := []dto.ReturResponse{}
+	for _, retur := range returs {
+		datas = append(datas, toReturResponse(retur))
+	}
+
+	return datas, nil
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/jejevj/ykp_pos/dto"
+	"github.com/jejevj/ykp_pos/helpers"
+)
+
+// renderSetoranPDF prints the settlement report of a loading on A4 paper.
+func renderSetoranPDF(setoran dto.SetoranResponse) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(15, 15, 15)
+	pdf.AddPage()
+
+	pdf.SetFont("Helvetica", "B", 14)
+	pdf.CellFormat(0, 8, "LAPORAN SETORAN", "", 1, "C", false, 0, "")
+	pdf.Ln(2)
+
+	tanggal := setoran.Tanggal
+	if parsed, err := time.Parse(time.RFC3339, setoran.Tanggal); err == nil {
+		tanggal = parsed.Format("02-01-2006 15:04")
+	}
+
+	pdf.SetFont("Helvetica", "", 10)
+	for _, row := range [][2]string{
+		{"No. Loading", setoran.NoLoading},
+		{"Driver", setoran.Driver.Name},
+		{"Tanggal", tanggal},
+	} {
+		pdf.CellFormat(30, 6, row[0], "", 0, "L", false, 0, "")
+		pdf.CellFormat(0, 6, ": "+row[1], "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(4)
+
+	// Goods
+	widths := []float64{10, 70, 25, 25, 25, 25}
+	pdf.SetFont("Helvetica", "B", 10)
+	for i, title := range []string{"No", "Barang", "Muat", "Terjual", "Kembali", "Selisih"} {
+		pdf.CellFormat(widths[i], 7, title, "1", 0, "C", false, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetFont("Helvetica", "", 10)
+	for i, item := range setoran.Items {
+		pdf.CellFormat(widths[0], 6, strconv.Itoa(i+1), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(widths[1], 6, item.Barang.NamaBarang, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[2], 6, helpers.FormatAngka(item.JumlahMuat), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[3], 6, helpers.FormatAngka(item.JumlahTerjual), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[4], 6, helpers.FormatAngka(item.JumlahKembali), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[5], 6, helpers.FormatAngka(item.Selisih), "1", 1, "R", false, 0, "")
+	}
+	pdf.Ln(4)
+
+	// Money
+	for _, row := range [][2]string{
+		{"Tagihan tunai", helpers.FormatRupiah(setoran.TotalTagihan)},
+		{"Setor tunai", helpers.FormatRupiah(setoran.TotalTunai)},
+		{"Setor transfer", helpers.FormatRupiah(setoran.TotalTransfer)},
+		{"Total setor", helpers.FormatRupiah(setoran.TotalSetor)},
+		{"Selisih", helpers.FormatRupiah(setoran.SelisihUang)},
+	} {
+		pdf.CellFormat(40, 6, row[0], "", 0, "L", false, 0, "")
+		pdf.CellFormat(45, 6, row[1], "", 1, "R", false, 0, "")
+	}
+
+	if len(setoran.Kekurangan) > 0 {
+		pdf.Ln(4)
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.CellFormat(0, 6, "Kekurangan driver", "", 1, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 10)
+		for _, item := range setoran.Kekurangan {
+			pdf.CellFormat(95, 6, item.Keterangan, "", 0, "L", false, 0, "")
+			pdf.CellFormat(45, 6, helpers.FormatRupiah(item.Nilai), "", 1, "R", false, 0, "")
+		}
+	}
+
+	if setoran.Keterangan != "" {
+		pdf.Ln(4)
+		pdf.MultiCell(0, 6, fmt.Sprintf("Keterangan: %s", setoran.Keterangan), "", "L", false)
+	}
+
+	// Signatures
+	pdf.Ln(12)
+	pdf.CellFormat(90, 6, "Driver", "", 0, "C", false, 0, "")
+	pdf.CellFormat(90, 6, "Petugas Gudang", "", 1, "C", false, 0, "")
+	pdf.Ln(18)
+	pdf.CellFormat(90, 6, "( "+setoran.Driver.Name+" )", "", 0, "C", false, 0, "")
+	pdf.CellFormat(90, 6, "(                    )", "", 1, "C", false, 0, "")
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jejevj/ykp_pos/dto"
+	"github.com/jejevj/ykp_pos/entity"
+	"github.com/jejevj/ykp_pos/repository"
+)
+
+type (
+	SetoranService interface {
+		AddSetoran(ctx context.Context, req dto.SetoranCreateRequest, userId string) (dto.SetoranResponse, error)
+		GetSetoranByLoading(ctx context.Context, loadingId string, ownerId string) (dto.SetoranResponse, error)
+		CetakSetoran(ctx context.Context, loadingId string, ownerId string) ([]byte, error)
+	}
+	setoranService struct {
+		setoranRepo repository.SetoranRepository
+		jwtService  JWTService
+	}
+)
+
+func NewSetoranService(setoranRepo repository.SetoranRepository, jwtService JWTService) SetoranService {
+	return &setoranService{
+		setoranRepo: setoranRepo,
+		jwtService:  jwtService,
+	}
+}
+
+func toSetoranResponse(setoran entity.Setoran) dto.SetoranResponse {
+	var items []dto.SetoranDetailResponse
+	for _, item := range setoran.Items {
+		items = append(items, dto.SetoranDetailResponse{
+			ID:            item.ID.String(),
+			IdBarang:      item.IdBarang,
+			Barang:        toBarangResponse(item.Barang),
+			JumlahMuat:    item.JumlahMuat,
+			JumlahTerjual: item.JumlahTerjual,
+			JumlahKembali: item.JumlahKembali,
+			Selisih:       item.Selisih,
+			MuatFormat:    formatJumlahBarang(item.Barang, item.JumlahMuat),
+			TerjualFormat: formatJumlahBarang(item.Barang, item.JumlahTerjual),
+			KembaliFormat: formatJumlahBarang(item.Barang, item.JumlahKembali),
+			SelisihFormat: formatJumlahBarang(item.Barang, item.Selisih),
+		})
+	}
+
+	var kekurangan []dto.KekuranganDriverResponse
+	for _, item := range setoran.Kekurangan {
+		kekurangan = append(kekurangan, dto.KekuranganDriverResponse{
+			ID:         item.ID.String(),
+			IdUser:     item.IdUser,
+			Jenis:      item.Jenis,
+			IdBarang:   item.IdBarang,
+			Jumlah:     item.Jumlah,
+			Nilai:      item.Nilai,
+			Keterangan: item.Keterangan,
+		})
+	}
+
+	return dto.SetoranResponse{
+		ID:        setoran.ID.String(),
+		IdLoading: setoran.IdLoading,
+		NoLoading: setoran.Loading.NoLoading,
+		IdUser:    setoran.IdUser,
+		Driver: dto.UserResponse{
+			ID:         setoran.Driver.ID.String(),
+			Name:       setoran.Driver.Name,
+			Email:      setoran.Driver.Email,
+			TelpNumber: setoran.Driver.TelpNumber,
+			Role:       setoran.Driver.Role,
+			ImageUrl:   setoran.Driver.ImageUrl,
+		},
+		IdPetugas:     setoran.IdPetugas,
+		Tanggal:       setoran.Tanggal.Format(time.RFC3339),
+		TotalTagihan:  setoran.TotalTagihan,
+		TotalTunai:    setoran.TotalTunai,
+		TotalTransfer: setoran.TotalTransfer,
+		TotalSetor:    setoran.TotalTunai + setoran.TotalTransfer,
+		SelisihUang:   setoran.SelisihUang,
+		Keterangan:    setoran.Keterangan,
+		Items:         items,
+		Kekurangan:    kekurangan,
+	}
+}
+
+func (s *setoranService) AddSetoran(ctx context.Context, req dto.SetoranCreateRequest, userId string) (dto.SetoranResponse, error) {
+	if req.TotalTunai < 0 || req.TotalTransfer < 0 {
+		return dto.SetoranResponse{}, dto.ErrSetoranJumlahInvalid
+	}
+
+	var items []entity.SetoranDetail
+	for _, item := range req.Items {
+		if item.JumlahKembali < 0 {
+			return dto.SetoranResponse{}, dto.ErrSetoranJumlahInvalid
+		}
+		for _, rincian := range item.Rincian {
+			if rincian.Jumlah < 0 {
+				return dto.SetoranResponse{}, dto.ErrSetoranJumlahInvalid
+			}
+		}
+
+		items = append(items, entity.SetoranDetail{
+			IdBarang:      item.IdBarang,
+			JumlahKembali: item.JumlahKembali,
+			Rincian:       toRincian(item.Rincian),
+		})
+	}
+
+	setoran := entity.Setoran{
+		IdLoading:     req.IdLoading,
+		IdPetugas:     userId,
+		TotalTunai:    req.TotalTunai,
+		TotalTransfer: req.TotalTransfer,
+		Keterangan:    req.Keterangan,
+		Items:         items,
+	}
+
+	setoranAdd, err := s.setoranRepo.AddSetoran(ctx, setoran)
+	if err != nil {
+		return dto.SetoranResponse{}, fmt.Errorf("%v: %w", dto.ErrCreateSetoran, err)
+	}
+
+	return toSetoranResponse(setoranAdd), nil
+}
+
+func (s *setoranService) GetSetoranByLoading(ctx context.Context, loadingId string, ownerId string) (dto.SetoranResponse, error) {
+	setoran, err := s.setoranRepo.GetSetoranByLoading(ctx, loadingId)
+	if err != nil {
+		return dto.SetoranResponse{}, dto.ErrSetoranNotFound
+	}
+	if err := checkLoadingOwner(setoran.Loading, ownerId); err != nil {
+		return dto.SetoranResponse{}, err
+	}
+
+	return toSetoranResponse(setoran), nil
+}
+
+func (s *setoranService) CetakSetoran(ctx context.Context, loadingId string, ownerId string) ([]byte, error) {
+	setoran, err := s.GetSetoranByLoading(ctx, loadingId, ownerId)
+	if err != nil {
+		return nil, err
+	}
+
+	pdf, err := renderSetoranPDF(setoran)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", dto.ErrCetakSetoran, err)
+	}
+
+	return pdf, nil
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/jejevj/ykp_pos/constants"
+	"github.com/jejevj/ykp_pos/dto"
+	"github.com/jejevj/ykp_pos/entity"
+	"github.com/jejevj/ykp_pos/repository"
+)
+
+type (
+	ShiftKasirService interface {
+		BukaShift(ctx context.Context, req dto.ShiftBukaRequest, userId string) (dto.ShiftKasirResponse, error)
+		GetShiftAktif(ctx context.Context, userId string) (dto.ShiftKasirResponse, error)
+		AddKasShift(ctx context.Context, req dto.KasShiftRequest, userId string) (dto.ShiftKasirResponse, error)
+		TutupShift(ctx context.Context, req dto.ShiftTutupRequest, userId string) (dto.ShiftKasirResponse, error)
+		GetShiftById(ctx context.Context, shiftId string) (dto.ShiftKasirResponse, error)
+		GetAllShiftWithPagination(ctx context.Context, req dto.ShiftFilterRequest) (dto.ShiftKasirPaginationResponse, error)
+		GetRekapShift(ctx context.Context, req dto.RekapShiftRequest) ([]dto.RekapShiftKasir, error)
+	}
+	shiftKasirService struct {
+		shiftKasirRepo repository.ShiftKasirRepository
+		jwtService     JWTService
+	}
+)
+
+func NewShiftKasirService(shiftKasirRepo repository.ShiftKasirRepository, jwtService JWTService) ShiftKasirService {
+	return &shiftKasirService{
+		shiftKasirRepo: shiftKasirRepo,
+		jwtService:     jwtService,
+	}
+}
+
+func toShiftKasirResponse(shift entity.ShiftKasir) dto.ShiftKasirResponse {
+	kas := []dto.KasShiftResponse{}
+	for _, item := range shift.Kas {
+		kas = append(kas, dto.KasShiftResponse{
+			ID:         item.ID.String(),
+			Jenis:      item.Jenis,
+			Jumlah:     item.Jumlah,
+			Waktu:      formatWaktu(&item.Waktu),
+			IdUser:     item.IdUser,
+			Keterangan: item.Keterangan,
+		})
+	}
+
+	return dto.ShiftKasirResponse{
+		ID:     shift.ID.String(),
+		IdUser: shift.IdUser,
+		Kasir: dto.UserResponse{
+			ID:         shift.Kasir.ID.String(),
+			Name:       shift.Kasir.Name,
+			Email:      shift.Kasir.Email,
+			TelpNumber: shift.Kasir.TelpNumber,
+			Role:       shift.Kasir.Role,
+			ImageUrl:   shift.Kasir.ImageUrl,
+		},
+		Status:          shift.Status,
+		WaktuBuka:       formatWaktu(&shift.WaktuBuka),
+		WaktuTutup:      formatWaktu(shift.WaktuTutup),
+		ModalAwal:       shift.ModalAwal,
+		JumlahPenjualan: shift.JumlahPenjualan,
+		TotalPenjualan:  shift.TotalPenjualan,
+		TotalTunai:      shift.TotalTunai,
+		KasMasuk:        shift.KasMasuk,
+		KasKeluar:       shift.KasKeluar,
+		KasDiharapkan:   shift.KasDiharapkan,
+		KasDihitung:     shift.KasDihitung,
+		Selisih:         shift.Selisih,
+		Keterangan:      shift.Keterangan,
+		Kas:             kas,
+	}
+}
+
+// rekapShift adds up closed shifts per cashier, ordered by name.
+func rekapShift(shifts []entity.ShiftKasir) []dto.RekapShiftKasir {
+	perKasir := map[string]*dto.RekapShiftKasir{}
+	for _, shift := range shifts {
+		rekap, ok := perKasir[shift.IdUser]
+		if !ok {
+			rekap = &dto.RekapShiftKasir{IdUser: shift.IdUser, NamaKasir: shift.Kasir.Name}
+			perKasir[shift.IdUser] = rekap
+		}
+
+		rekap.JumlahShift++
+		rekap.JumlahPenjualan += shift.JumlahPenjualan
+		rekap.TotalPenjualan += shift.TotalPenjualan
+		rekap.TotalTunai += shift.TotalTunai
+		rekap.KasDiharapkan += shift.KasDiharapkan
+		rekap.KasDihitung += shift.KasDihitung
+		if shift.Selisih < 0 {
+			rekap.Kurang -= shift.Selisih
+		} else {
+			rekap.Lebih += shift.Selisih
+		}
+		rekap.Selisih += shift.Selisih
+	}
+
+	datas := []dto.RekapShiftKasir{}
+	for _, rekap := range perKasir {
+		datas = append(datas, *rekap)
+	}
+	sort.Slice(datas, func(i, j int) bool {
+		if datas[i].NamaKasir != datas[j].NamaKasir {
+			return datas[i].NamaKasir < datas[j].NamaKasir
+		}
+		return datas[i].IdUser < datas[j].IdUser
+	})
+
+	return datas
+}
+
+func (s *shiftKasirService) BukaShift(ctx context.Context, req dto.ShiftBukaRequest, userId string) (dto.ShiftKasirResponse, error) {
+	if req.ModalAwal < 0 {
+		return dto.ShiftKasirResponse{}, dto.ErrModalAwalInvalid
+	}
+
+	shift, err := s.shiftKasirRepo.BukaShift(ctx, entity.ShiftKasir{
+		IdUser:     userId,
+		ModalAwal:  req.ModalAwal,
+		Keterangan: req.Keterangan,
+	})
+	if err != nil {
+		return dto.ShiftKasirResponse{}, fmt.Errorf("%v: %v", dto.ErrBukaShift, err)
+	}
+
+	return toShiftKasirResponse(shift), nil
+}
+
+func (s *shiftKasirService) GetShiftAktif(ctx context.Context, userId string) (dto.ShiftKasirResponse, error) {
+	shift, err := s.shiftKasirRepo.GetShiftAktif(ctx, userId)
+	if err != nil {
+		return dto.ShiftKasirResponse{}, fmt.Errorf("%v: %v", dto.ErrGetShift, err)
+	}
+
+	return toShiftKasirResponse(shift), nil
+}
+
+func (s *shiftKasirService) AddKasShift(ctx context.Context, req dto.KasShiftRequest, userId string) (dto.ShiftKasirResponse, error) {
+	if req.Jenis != constants.ENUM_KAS_MASUK && req.Jenis != constants.ENUM_KAS_KELUAR {
+		return dto.ShiftKasirResponse{}, dto.ErrInvalidJenisKas
+	}
+	if req.Jumlah <= 0 {
+		return dto.ShiftKasirResponse{}, dto.ErrKasJumlahInvalid
+	}
+
+	shift, err := s.shiftKasirRepo.AddKasShift(ctx, entity.KasShift{
+		Jenis:      req.Jenis,
+		Jumlah:     req.Jumlah,
+		IdUser:     userId,
+		Keterangan: req.Keterangan,
+	})
+	if err != nil {
+		return dto.ShiftKasirResponse{}, fmt.Errorf("%v: %v", dto.ErrCreateKasShift, err)
+	}
+
+	return toShiftKasirResponse(shift), nil
+}
+
+func (s *shiftKasirService) TutupShift(ctx context.Context, req dto.ShiftTutupRequest, userId string) (dto.ShiftKasirResponse, error) {
+	if req.KasDihitung < 0 {
+		return dto.ShiftKasirResponse{}, dto.ErrKasDihitungInvalid
+	}
+
+	shift, err := s.shiftKasirRepo.TutupShift(ctx, userId, req.KasDihitung, req.Keterangan)
+	if err != nil {
+		return dto.ShiftKasirResponse{}, fmt.Errorf("%v: %v", dto.ErrTutupShift, err)
+	}
+
+	return toShiftKasirResponse(shift), nil
+}
+
+func (s *shiftKasirService) GetShiftById(ctx context.Context, shiftId string) (dto.ShiftKasirResponse, error) {
+	shift, err := s.shiftKasirRepo.GetShiftById(ctx, shiftId)
+	if err != nil {
+		return dto.ShiftKasirResponse{}, fmt.Errorf("%v: %v", dto.ErrGetShift, err)
+	}
+
+	return toShiftKasirResponse(shift), nil
+}
+
+func (s *shiftKasirService) GetAllShiftWithPagination(ctx context.Context, req dto.ShiftFilterRequest) (dto.ShiftKasirPaginationResponse, error) {
+	if _, err := parseTanggal(req.TanggalMulai); err != nil {
+		return dto.ShiftKasirPaginationResponse{}, err
+	}
+	if _, err := parseTanggal(req.TanggalAkhir); err != nil {
+		return dto.ShiftKasirPaginationResponse{}, err
+	}
+
+	dataWithPaginate, err := s.shiftKasirRepo.GetAllShiftWithPagination(ctx, req)
+	if err != nil {
+		return dto.ShiftKasirPaginationResponse{}, err
+	}
+
+	datas := []dto.ShiftKasirResponse{}
+	for _, shift := range dataWithPaginate.Shifts {
+		datas = append(datas, toShiftKasirResponse(shift))
+	}
+
+	return dto.ShiftKasirPaginationResponse{
+		Data: datas,
+		PaginationResponse: dto.PaginationResponse{
+			Page:    dataWithPaginate.Page,
+			PerPage: dataWithPaginate.PerPage,
+			MaxPage: dataWithPaginate.MaxPage,
+			Count:   dataWithPaginate.Count,
+		},
+	}, nil
+}
+
+// GetRekapShift reports the cash variance of every cashier over the closed
+// shifts in the period.
+func (s *shiftKasirService) GetRekapShift(ctx context.Context, req dto.RekapShiftRequest) ([]dto.RekapShiftKasir, error) {
+	if _, err := parseTanggal(req.TanggalMulai); err != nil {
+		return nil, err
+	}
+	if _, err := parseTanggal(req.TanggalAkhir); err != nil {
+		return nil, err
+	}
+
+	shifts, err := s.shiftKasirRepo.GetShiftTutup(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", dto.ErrGetShift, err)
+	}
+
+	return rekapShift(shifts), nil
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/jejevj/ykp_pos/constants"
+	"github.com/jejevj/ykp_pos/dto"
+	"github.com/jejevj/ykp_pos/entity"
+	"github.com/jejevj/ykp_pos/helpers"
+)
+
+// validStrukRequest fills in the receipt defaults, ESC/POS on a 58mm printer,
+// and rejects anything else the printers cannot take.
+func validStrukRequest(req *dto.StrukRequest) error {
+	req.Format = strings.ToLower(req.Format)
+	if req.Format == "" {
+		req.Format = constants.ENUM_STRUK_ESCPOS
+	}
+	if req.Format != constants.ENUM_STRUK_ESCPOS && req.Format != constants.ENUM_STRUK_TEKS {
+		return dto.ErrInvalidFormatStruk
+	}
+
+	if req.Kolom == 0 {
+		req.Kolom = constants.ENUM_STRUK_KOLOM_58MM
+	}
+	if req.Kolom != constants.ENUM_STRUK_KOLOM_58MM && req.Kolom != constants.ENUM_STRUK_KOLOM_80MM {
+		return dto.ErrInvalidKolomStruk
+	}
+
+	return nil
+}
+
+func renderStruk(struk helpers.Struk, req dto.StrukRequest) []byte {
+	if req.Format == constants.ENUM_STRUK_TEKS {
+		return []byte(helpers.RenderStrukTeks(struk, req.Kolom))
+	}
+
+	return helpers.RenderStrukEscPos(struk, req.Kolom)
+}
+
+// headerStruk is the business name, address and phone on top of a receipt.
+func headerStruk(setting entity.MainSetting) []string {
+	var header []string
+	for _, line := range []string{setting.NamaUsaha, setting.Alamat, setting.Hp} {
+		if line != "" {
+			header = append(header, line)
+		}
+	}
+	if setting.Npwp != "" {
+		header = append(header, "NPWP "+setting.Npwp)
+	}
+
+	return header
+}
+
+func strukFaktur(faktur entity.Faktur, setting entity.MainSetting) helpers.Struk {
+	struk := helpers.Struk{
+		Header: headerStruk(setting),
+		Judul:  "FAKTUR " + faktur.NoFaktur,
+		Info: [][2]string{
+			{"Tanggal", formatTanggal(faktur.TanggalFaktur)},
+			{"Customer", faktur.Customer.NamaToko},
+			{"Sales", faktur.Driver.Name},
+			{"Bayar", faktur.CaraBayar},
+		},
+		QR: faktur.NoFaktur,
+	}
+	if faktur.TanggalTempo != nil {
+		struk.Info = append(struk.Info, [2]string{"Jatuh tempo", formatTanggal(faktur.TanggalTempo)})
+	}
+	if faktur.Status == constants.ENUM_FAKTUR_BATAL {
+		struk.Judul += " (BATAL)"
+	}
+
+	for _, item := range faktur.Items {
+		rincian := formatJumlahBarang(item.Barang, item.Jumlah) + " x " + helpers.FormatAngka(item.Harga)
+		if diskon := formatDiskon(item.DiskonP, item.Diskon); diskon != "-" {
+			rincian += " disc " + diskon
+		}
+		struk.Baris = append(struk.Baris, helpers.BarisStruk{
+			Nama:    item.Barang.NamaBarang,
+			Rincian: rincian,
+			Jumlah:  helpers.FormatAngka(item.JumlahRP),
+		})
+	}
+
+	if faktur.PotonganDiskon > 0 {
+		struk.Rincian = append(struk.Rincian,
+			[2]string{"Subtotal", helpers.FormatRupiah(faktur.Subtotal)},
+			[2]string{"Diskon", "-" + helpers.FormatRupiah(faktur.PotonganDiskon)},
+		)
+	}
+	if faktur.TarifPpn > 0 {
+		struk.Rincian = append(struk.Rincian,
+			[2]string{"DPP", helpers.FormatRupiah(faktur.Dpp)},
+			[2]string{"PPN", helpers.FormatRupiah(faktur.Ppn)},
+		)
+	}
+	struk.Total = [2]string{"TOTAL", helpers.FormatRupiah(faktur.Total)}
+	// A faktur is settled through pembayaran, so there is no change to give
+	sisa := faktur.Total - faktur.TotalBayar
+	struk.Bayar = [][2]string{
+		{"Dibayar", helpers.FormatRupiah(faktur.TotalBayar)},
+		{"Sisa", helpers.FormatRupiah(sisa)},
+	}
+
+	struk.Footer = []string{"Terima kasih"}
+
+	// What is still owed can be paid by scanning the QRIS of the merchant
+	if sisa > 0 && faktur.Status != constants.ENUM_FAKTUR_BATAL && setting.QrisStatis != "" {
+		if qris, err := qrisPembayaran(setting, sisa, faktur.NoFaktur); err == nil {
+			struk.QR = qris
+			struk.Footer = append([]string{"Scan QRIS untuk membayar sisa tagihan"}, struk.Footer...)
+		}
+	}
+
+	return struk
+}
+
+func strukPenjualan(penjualan entity.Penjualan, setting entity.MainSetting) helpers.Struk {
+	struk := helpers.Struk{
+		Header: headerStruk(setting),
+		Info: [][2]string{
+			{"No", penjualan.NoPenjualan},
+			{"Waktu", penjualan.Tanggal.Format("2006-01-02 15:04")},
+			{"Kasir", penjualan.Kasir.Name},
+		},
+		QR: penjualan.NoPenjualan,
+	}
+
+	for _, item := range penjualan.Items {
+		rincian := formatJumlahBarang(item.Barang, item.Jumlah) + " x " + helpers.FormatAngka(item.Harga)
+		if diskon := formatDiskon(item.DiskonP, item.Diskon); diskon != "-" {
+			rincian += " disc " + diskon
+		}
+		struk.Baris = append(struk.Baris, helpers.BarisStruk{
+			Nama:    item.Barang.NamaBarang,
+			Rincian: rincian,
+			Jumlah:  helpers.FormatAngka(item.JumlahRP),
+		})
+	}
+
+	if penjualan.PotonganDiskon > 0 {
+		struk.Rincian = append(struk.Rincian,
+			[2]string{"Subtotal", helpers.FormatRupiah(penjualan.Subtotal)},
+			[2]string{"Diskon", "-" + helpers.FormatRupiah(penjualan.PotonganDiskon)},
+		)
+	}
+	if penjualan.TarifPpn > 0 {
+		struk.Rincian = append(struk.Rincian,
+			[2]string{"DPP", helpers.FormatRupiah(penjualan.Dpp)},
+			[2]string{"PPN", helpers.FormatRupiah(penjualan.Ppn)},
+		)
+	}
+	struk.Total = [2]string{"TOTAL", helpers.FormatRupiah(penjualan.Total)}
+	for _, tender := range penjualan.Pembayarans {
+		struk.Bayar = append(struk.Bayar, [2]string{"Bayar " + tender.Metode, helpers.FormatRupiah(tender.Jumlah)})
+	}
+	struk.Bayar = append(struk.Bayar, [2]string{"Kembali", helpers.FormatRupiah(penjualan.Kembalian)})
+
+	struk.Footer = []string{"Barang yang sudah dibeli tidak dapat ditukar", "Terima kasih"}
+
+	return struk
+}
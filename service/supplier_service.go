@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jejevj/ykp_pos/dto"
+	"github.com/jejevj/ykp_pos/entity"
+	"github.com/jejevj/ykp_pos/repository"
+)
+
+type (
+	SupplierService interface {
+		AddSupplier(ctx context.Context, req dto.SupplierCreateRequest) (dto.SupplierResponse, error)
+		GetAllSupplierWithPagination(ctx context.Context, req dto.PaginationRequest) (dto.SupplierPaginationResponse, error)
+		GetSupplierById(ctx context.Context, supplierId string) (dto.SupplierResponse, error)
+		UpdateSupplier(ctx context.Context, req dto.SupplierUpdateRequest) (dto.SupplierResponse, error)
+		DeleteSupplier(ctx context.Context, supplierId string) error
+	}
+	supplierService struct {
+		supplierRepo repository.SupplierRepository
+		jwtService   JWTService
+	}
+)
+
+func NewSupplierService(supplierRepo repository.SupplierRepository, jwtService JWTService) SupplierService {
+	return &supplierService{
+		supplierRepo: supplierRepo,
+		jwtService:   jwtService,
+	}
+}
+
+func toSupplierResponse(supplier entity.Supplier) dto.SupplierResponse {
+	return dto.SupplierResponse{
+		ID:           supplier.ID.String(),
+		NamaSupplier: supplier.NamaSupplier,
+		NamaKontak:   supplier.NamaKontak,
+		Alamat:       supplier.Alamat,
+		HP:           supplier.HP,
+		Npwp:         supplier.Npwp,
+	}
+}
+
+func (s *supplierService) AddSupplier(ctx context.Context, req dto.SupplierCreateRequest) (dto.SupplierResponse, error) {
+	supplier, err := s.supplierRepo.AddSupplier(ctx, entity.Supplier{
+		NamaSupplier: req.NamaSupplier,
+		NamaKontak:   req.NamaKontak,
+		Alamat:       req.Alamat,
+		HP:           req.HP,
+		Npwp:         req.Npwp,
+	})
+	if err != nil {
+		return dto.SupplierResponse{}, dto.ErrCreateSupplier
+	}
+
+	return toSupplierResponse(supplier), nil
+}
+
+func (s *supplierService) GetAllSupplierWithPagination(ctx context.Context, req dto.PaginationRequest) (dto.SupplierPaginationResponse, error) {
+	dataWithPaginate, err := s.supplierRepo.GetAllSupplierWithPagination(ctx, req)
+	if err != nil {
+		return dto.SupplierPaginationResponse{}, err
+	}
+
+	var datas []dto.SupplierResponse
+	for _, supplier := range dataWithPaginate.Suppliers {
+		datas = append(datas, toSupplierResponse(supplier))
+	}
+
+	return dto.SupplierPaginationResponse{
+		Data: datas,
+		PaginationResponse: dto.PaginationResponse{
+			Page:    dataWithPaginate.Page,
+			PerPage: dataWithPaginate.PerPage,
+			MaxPage: dataWithPaginate.MaxPage,
+			Count:   dataWithPaginate.Count,
+		},
+	}, nil
+}
+
+func (s *supplierService) GetSupplierById(ctx context.Context, supplierId string) (dto.SupplierResponse, error) {
+	supplier, err := s.supplierRepo.GetSupplierById(ctx, supplierId)
+	if err != nil {
+		return dto.SupplierResponse{}, dto.ErrGetSupplierById
+	}
+
+	return toSupplierResponse(supplier), nil
+}
+
+func (s *supplierService) UpdateSupplier(ctx context.Context, req dto.SupplierUpdateRequest) (dto.SupplierResponse, error) {
+	id, err := uuid.Parse(req.ID)
+	if err != nil {
+		return dto.SupplierResponse{}, fmt.Errorf("invalid ID format: %v", err)
+	}
+
+	supplier, err := s.supplierRepo.UpdateSupplier(ctx, entity.Supplier{
+		ID:           id,
+		NamaSupplier: req.NamaSupplier,
+		NamaKontak:   req.NamaKontak,
+		Alamat:       req.Alamat,
+		HP:           req.HP,
+		Npwp:         req.Npwp,
+	})
+	if err != nil {
+		return dto.SupplierResponse{}, fmt.Errorf("%v: %v", dto.ErrUpdateSupplier, err)
+	}
+
+	return toSupplierResponse(supplier), nil
+}
+
+func (s *supplierService) DeleteSupplier(ctx context.Context, supplierId string) error {
+	supplier, err := s.supplierRepo.GetSupplierById(ctx, supplierId)
+	if err != nil {
+		return dto.ErrSupplierNotFound
+	}
+
+	if err := s.supplierRepo.DeleteSupplier(ctx, supplier.ID.String()); err != nil {
+		if errors.Is(err, dto.ErrSupplierAdaPo) {
+			return err
+		}
+		return dto.ErrDeleteSupplier
+	}
+
+	return nil
+}
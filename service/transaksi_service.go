@@ -12,39 +12,61 @@ import (
 
 type (
 	TransaksiService interface {
-		AddTransaksi(ctx context.Context, req dto.TransaksiCreateRequest) (dto.TransaksiResponse, error)
-		GetAllTransaksiWithPagination(ctx context.Context) (dto.TransaksiPaginationResponse, error)
-		GetTransaksiById(ctx context.Context, transaksiId string) (dto.TransaksiResponse, error)
-		UpdateTransaksi(ctx context.Context, req dto.TransaksiUpdateRequest, transaksiId string) (dto.TransaksiUpdateResponse, error)
-		DeleteTransaksi(ctx context.Context, transaksiId string) error
+		AddTransaksi(ctx context.Context, req dto.TransaksiCreateRequest, userId string, ownerId string) (dto.TransaksiResponse, error)
+		GetAllTransaksiWithPagination(ctx context.Context, req dto.TransaksiFilterRequest) (dto.TransaksiPaginationResponse, error)
+		GetTransaksiById(ctx context.Context, transaksiId string, ownerId string) (dto.TransaksiResponse, error)
+		UpdateTransaksi(ctx context.Context, req dto.TransaksiUpdateRequest, transaksiId string, userId string, ownerId string) (dto.TransaksiUpdateResponse, error)
+		DeleteTransaksi(ctx context.Context, transaksiId string, userId string, ownerId string) error
 	}
 	transaksiService struct {
 		transaksiRepo repository.TransaksiRepository
+		loadingRepo   repository.LoadingRepository
 		jwtService    JWTService
 	}
 )
 
-func NewTransaksiService(transaksiRepo repository.TransaksiRepository, jwtService JWTService) TransaksiService {
+func NewTransaksiService(transaksiRepo repository.TransaksiRepository, loadingRepo repository.LoadingRepository, jwtService JWTService) TransaksiService {
 	return &transaksiService{
 		transaksiRepo: transaksiRepo,
+		loadingRepo:   loadingRepo,
 		jwtService:    jwtService,
 	}
 }
-func (s *transaksiService) AddTransaksi(ctx context.Context, req dto.TransaksiCreateRequest) (dto.TransaksiResponse, error) {
+
+// checkLoadingAccess makes sure a sales user only books lines on a loading of
+// their own.
+func (s *transaksiService) checkLoadingAccess(ctx context.Context, loadingId string, ownerId string) error {
+	if ownerId == "" || loadingId == "" {
+		return nil
+	}
+
+	loading, err := s.loadingRepo.GetLoadingById(ctx, loadingId)
+	if err != nil {
+		return dto.ErrLoadingNotFound
+	}
+
+	return checkLoadingOwner(loading, ownerId)
+}
+func (s *transaksiService) AddTransaksi(ctx context.Context, req dto.TransaksiCreateRequest, userId string, ownerId string) (dto.TransaksiResponse, error) {
 	mu.Lock()
 	defer mu.Unlock()
 
+	if err := s.checkLoadingAccess(ctx, req.IdLoading, ownerId); err != nil {
+		return dto.TransaksiResponse{}, err
+	}
+
 	// Prepare the transaksi entity
 	transaksi := entity.Transaksi{
 		IdLoading: req.IdLoading,
 		IdBarang:  req.IdBarang,
 		Jumlah:    req.Jumlah,
+		Rincian:   toRincian(req.Rincian),
 	}
 
 	// Add the transaksi via the repository
-	transaksiAdd, err := s.transaksiRepo.AddTransaksi(ctx, transaksi)
+	transaksiAdd, err := s.transaksiRepo.AddTransaksi(ctx, transaksi, userId)
 	if err != nil {
-		return dto.TransaksiResponse{}, dto.ErrCreateTransaksi
+		return dto.TransaksiResponse{}, fmt.Errorf("%v: %v", dto.ErrCreateTransaksi, err)
 	}
 
 	// Map LoadingResponse with UserResponse
@@ -62,35 +84,23 @@ func (s *transaksiService) AddTransaksi(ctx context.Context, req dto.TransaksiCr
 	}
 
 	// Map BarangResponse with SatuanResponse
-	barangResponse := dto.BarangResponse{
-		ID:         transaksiAdd.Barang.ID.String(),
-		NamaBarang: transaksiAdd.Barang.NamaBarang,
-		KodeBarang: transaksiAdd.Barang.KodeBarang,
-		HargaBeli:  transaksiAdd.Barang.HargaBeli,
-		HargaJual:  transaksiAdd.Barang.HargaJual,
-		IdSatuan:   transaksiAdd.Barang.IdSatuan,
-		Stok:       transaksiAdd.Barang.Stok,
-		Satuan: dto.SatuanResponse{
-			ID:         transaksiAdd.Barang.Satuan.ID.String(),
-			NamaSatuan: transaksiAdd.Barang.Satuan.NamaSatuan,
-			Value:      transaksiAdd.Barang.Satuan.Value,
-		},
-	}
+	barangResponse := toBarangResponse(transaksiAdd.Barang)
 
 	// Return the mapped TransaksiResponse
 	return dto.TransaksiResponse{
-		ID:        transaksiAdd.ID.String(),
-		IdLoading: transaksiAdd.IdLoading,
-		Loading:   loadingResponse,
-		IdBarang:  transaksiAdd.IdBarang,
-		Barang:    barangResponse,
-		Jumlah:    transaksiAdd.Jumlah,
+		ID:           transaksiAdd.ID.String(),
+		IdLoading:    transaksiAdd.IdLoading,
+		Loading:      loadingResponse,
+		IdBarang:     transaksiAdd.IdBarang,
+		Barang:       barangResponse,
+		Jumlah:       transaksiAdd.Jumlah,
+		JumlahFormat: formatJumlahBarang(transaksiAdd.Barang, transaksiAdd.Jumlah),
 	}, nil
 }
 
-func (s *transaksiService) GetAllTransaksiWithPagination(ctx context.Context) (dto.TransaksiPaginationResponse, error) {
+func (s *transaksiService) GetAllTransaksiWithPagination(ctx context.Context, req dto.TransaksiFilterRequest) (dto.TransaksiPaginationResponse, error) {
 	// Fetch data with pagination and preloaded relationships
-	dataWithPaginate, err := s.transaksiRepo.GetAllTransaksiWithPagination(ctx)
+	dataWithPaginate, err := s.transaksiRepo.GetAllTransaksiWithPagination(ctx, req)
 	if err != nil {
 		return dto.TransaksiPaginationResponse{}, err
 	}
@@ -112,31 +122,17 @@ func (s *transaksiService) GetAllTransaksiWithPagination(ctx context.Context) (d
 		}
 
 		// Map BarangResponse
-		barangResponse := dto.BarangResponse{
-			ID:           transaksi.Barang.ID.String(),
-			NamaBarang:   transaksi.Barang.NamaBarang,
-			KodeBarang:   transaksi.Barang.KodeBarang,
-			HargaBeli:    transaksi.Barang.HargaBeli,
-			HargaJual:    transaksi.Barang.HargaJual,
-			IdSatuan:     transaksi.Barang.IdSatuan,
-			JumlahKrat:   transaksi.Barang.JumlahKrat,
-			JumlahSatuan: transaksi.Barang.JumlahSatuan,
-			Stok:         transaksi.Barang.Stok,
-			Satuan: dto.SatuanResponse{
-				ID:         transaksi.Barang.Satuan.ID.String(),
-				NamaSatuan: transaksi.Barang.Satuan.NamaSatuan,
-				Value:      transaksi.Barang.Satuan.Value,
-			},
-		}
+		barangResponse := toBarangResponse(transaksi.Barang)
 
 		// Map TransaksiResponse
 		data := dto.TransaksiResponse{
-			ID:        transaksi.ID.String(),
-			IdLoading: transaksi.IdLoading,
-			Loading:   loadingResponse,
-			IdBarang:  transaksi.IdBarang,
-			Barang:    barangResponse,
-			Jumlah:    transaksi.Jumlah,
+			ID:           transaksi.ID.String(),
+			IdLoading:    transaksi.IdLoading,
+			Loading:      loadingResponse,
+			IdBarang:     transaksi.IdBarang,
+			Barang:       barangResponse,
+			Jumlah:       transaksi.Jumlah,
+			JumlahFormat: formatJumlahBarang(transaksi.Barang, transaksi.Jumlah),
 		}
 
 		// Add the mapped transaction data to the response slice
@@ -155,12 +151,15 @@ func (s *transaksiService) GetAllTransaksiWithPagination(ctx context.Context) (d
 	}, nil
 }
 
-func (s *transaksiService) GetTransaksiById(ctx context.Context, transaksiId string) (dto.TransaksiResponse, error) {
+func (s *transaksiService) GetTransaksiById(ctx context.Context, transaksiId string, ownerId string) (dto.TransaksiResponse, error) {
 	// Fetch the transaksi with preloaded relationships
 	transaksi, err := s.transaksiRepo.GetTransaksiById(ctx, transaksiId)
 	if err != nil {
 		return dto.TransaksiResponse{}, dto.ErrGetTransaksiById
 	}
+	if err := checkLoadingOwner(transaksi.Loading, ownerId); err != nil {
+		return dto.TransaksiResponse{}, err
+	}
 
 	// Map LoadingResponse with UserResponse
 	loadingResponse := dto.LoadingResponse{
@@ -177,73 +176,75 @@ func (s *transaksiService) GetTransaksiById(ctx context.Context, transaksiId str
 	}
 
 	// Map BarangResponse with SatuanResponse
-	barangResponse := dto.BarangResponse{
-		ID:           transaksi.Barang.ID.String(),
-		NamaBarang:   transaksi.Barang.NamaBarang,
-		KodeBarang:   transaksi.Barang.KodeBarang,
-		HargaBeli:    transaksi.Barang.HargaBeli,
-		HargaJual:    transaksi.Barang.HargaJual,
-		IdSatuan:     transaksi.Barang.IdSatuan,
-		Stok:         transaksi.Barang.Stok,
-		JumlahKrat:   transaksi.Barang.JumlahKrat,
-		JumlahSatuan: transaksi.Barang.JumlahSatuan,
-		// Satuan: Uncomment if needed
-		Satuan: dto.SatuanResponse{
-			ID:         transaksi.Barang.Satuan.ID.String(),
-			NamaSatuan: transaksi.Barang.Satuan.NamaSatuan,
-			Value:      transaksi.Barang.Satuan.Value,
-		},
-	}
+	barangResponse := toBarangResponse(transaksi.Barang)
 
 	// Return the mapped TransaksiResponse
 	return dto.TransaksiResponse{
-		ID:        transaksi.ID.String(),
-		IdLoading: transaksi.IdLoading,
-		Loading:   loadingResponse,
-		IdBarang:  transaksi.IdBarang,
-		Barang:    barangResponse,
-		Jumlah:    transaksi.Jumlah,
+		ID:           transaksi.ID.String(),
+		IdLoading:    transaksi.IdLoading,
+		Loading:      loadingResponse,
+		IdBarang:     transaksi.IdBarang,
+		Barang:       barangResponse,
+		Jumlah:       transaksi.Jumlah,
+		JumlahFormat: formatJumlahBarang(transaksi.Barang, transaksi.Jumlah),
 	}, nil
 }
 
-func (s *transaksiService) UpdateTransaksi(ctx context.Context, req dto.TransaksiUpdateRequest, transaksiId string) (dto.TransaksiUpdateResponse, error) {
+func (s *transaksiService) UpdateTransaksi(ctx context.Context, req dto.TransaksiUpdateRequest, transaksiId string, userId string, ownerId string) (dto.TransaksiUpdateResponse, error) {
 	// Convert string ID to uuid.UUID (if needed)
 	id, err := uuid.Parse(transaksiId)
 	if err != nil {
 		return dto.TransaksiUpdateResponse{}, fmt.Errorf("invalid ID format: %v", err)
 	}
 
+	existingTransaksi, err := s.transaksiRepo.GetTransaksiById(ctx, transaksiId)
+	if err != nil {
+		return dto.TransaksiUpdateResponse{}, dto.ErrTransaksiNotFound
+	}
+	// Both the current loading and the one the line moves to must be owned
+	if err := checkLoadingOwner(existingTransaksi.Loading, ownerId); err != nil {
+		return dto.TransaksiUpdateResponse{}, err
+	}
+	if err := s.checkLoadingAccess(ctx, req.IdLoading, ownerId); err != nil {
+		return dto.TransaksiUpdateResponse{}, err
+	}
+
 	// Prepare the entity to be updated
 	data := entity.Transaksi{
 		ID:        id,
 		IdLoading: req.IdLoading,
 		IdBarang:  req.IdBarang,
 		Jumlah:    req.Jumlah,
+		Rincian:   toRincian(req.Rincian),
 	}
 
 	// Call the repository to update
-	transaksiUpdate, err := s.transaksiRepo.UpdateTransaksi(ctx, data)
+	transaksiUpdate, err := s.transaksiRepo.UpdateTransaksi(ctx, data, userId)
 	if err != nil {
 		return dto.TransaksiUpdateResponse{}, fmt.Errorf("failed to update Transaksi: %v", err)
 	}
 
 	return dto.TransaksiUpdateResponse{
-		ID:        transaksiUpdate.ID.String(),
-		IdLoading: transaksiUpdate.IdLoading,
-		IdBarang:  transaksiUpdate.IdBarang,
-		Jumlah:    transaksiUpdate.Jumlah,
+		ID:           transaksiUpdate.ID.String(),
+		IdLoading:    transaksiUpdate.IdLoading,
+		IdBarang:     transaksiUpdate.IdBarang,
+		Jumlah:       transaksiUpdate.Jumlah,
+		JumlahFormat: formatJumlahBarang(transaksiUpdate.Barang, transaksiUpdate.Jumlah),
 	}, nil
 }
 
-func (s *transaksiService) DeleteTransaksi(ctx context.Context, transaksiId string) error {
+func (s *transaksiService) DeleteTransaksi(ctx context.Context, transaksiId string, userId string, ownerId string) error {
 	transaksi, err := s.transaksiRepo.GetTransaksiById(ctx, transaksiId)
 	if err != nil {
 		return dto.ErrTransaksiNotFound
 	}
+	if err := checkLoadingOwner(transaksi.Loading, ownerId); err != nil {
+		return err
+	}
 
-	err = s.transaksiRepo.DeleteTransaksi(ctx, transaksi.ID.String())
+	err = s.transaksiRepo.DeleteTransaksi(ctx, transaksi.ID.String(), userId)
 	if err != nil {
-		return dto.ErrDeleteTransaksi
+		return fmt.Errorf("%v: %v", dto.ErrDeleteTransaksi, err)
 	}
 
 	return nil